@@ -20,7 +20,7 @@ GOROOT := $(shell echo $$GOROOT)
 GOPATH := $(shell echo $$GOPATH)
 
 LIBSISIMAI := libsisimai.org
-SISIMAIDIR := address moji rfc1123 rfc5322 rfc791 smtp/*/
+SISIMAIDIR := address authres dkim mailbox messageid moji publicsuffix reason resolver rfc1123 rfc2045 rfc2369 rfc3464 rfc5322 rfc5965 rfc791 rfc8098 smtp/*/
 COVERAGETO := coverage.txt
 EXECUTABLE := bin/maigo
 BUILDFLAGS := -ldflags="-s -w" -trimpath
@@ -29,6 +29,7 @@ GO_SYSNAME := $(shell echo $$GOOS   || $(GO) env GOOS  )
 GO_CPUARCH := $(shell echo $$GOARCH || $(GO) env GOARCH)
 LISTENADDR := 127.0.0.1:5322
 K          := neko
+IANACSV    := ./smtp-enhanced-status-codes-3.csv
 
 # -------------------------------------------------------------------------------------------------
 .PHONY: clean
@@ -92,6 +93,11 @@ init:
 update-go-mod:
 	@ $(GO) mod tidy
 
+update-status-registry:
+	# https://www.iana.org/assignments/smtp-enhanced-status-codes/smtp-enhanced-status-codes-3.csv
+	test -f "$(IANACSV)"
+	cd ./smtp/status && $(GO) run mkregistry.go -o table.go $(abspath $(IANACSV))
+
 start-godoc-server:
 	open http://$(LISTENADDR)
 	godoc -http=$(LISTENADDR)
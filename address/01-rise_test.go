@@ -131,3 +131,47 @@ func TestRise(t *testing.T) {
 	t.Logf("The number of tests = %d", cx)
 }
 
+func TestRegistrableDomain(t *testing.T) {
+	fn := "address.EmailAddress.RegistrableDomain"
+	cx := 0
+	ae := []struct {testname string; argument string; expected string}{
+		{"", "neko@mx1.example.co.jp", "example.co.jp"},
+		{"", "Kijitora <kijitora@example.jp>", "example.jp"},
+		{"", "neko@[IPv4:192.0.2.22]", ""},
+		{"", "MAILER-DAEMON", ""},
+	}
+
+	for _, e := range ae {
+		t.Run(e.testname, func(t *testing.T) {
+			cv := Rise(Find(e.argument))
+			if cw := cv.RegistrableDomain(); cw != e.expected { t.Errorf("[%6d]: %s(%s) is (%s) not (%s)", cx, fn, e.argument, cw, e.expected) }
+			cx += 1
+		})
+	}
+	cx++; if cv := (*EmailAddress)(nil).RegistrableDomain(); cv != "" { t.Errorf("[%6d]: %s(nil) is (%s)", cx, fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestString(t *testing.T) {
+	fn := "address.EmailAddress.String"
+	cx := 0
+	ae := []struct {testname string; argument string; expected string}{
+		{"", `"Neko" <neko@example.jp>`, "Neko <neko@example.jp>"},
+		{"", `<neko@example.jp> "Neko, Nyaan"`, `"Neko, Nyaan" <neko@example.jp>`},
+		{"", "<aoi@example.jp>", "aoi@example.jp"},
+		{"", `"ねこ" <neko@example.jp>`, "=?utf-8?b?44Gt44GT?= <neko@example.jp>"},
+		{"", "Mail Delivery Subsystem <MAILER-DAEMON>", "Mail Delivery Subsystem <MAILER-DAEMON>"},
+	}
+
+	for _, e := range ae {
+		t.Run(e.testname, func(t *testing.T) {
+			cv := Rise(Find(e.argument))
+			if cw := cv.String(); cw != e.expected { t.Errorf("[%6d]: %s(%s) is (%s) not (%s)", cx, fn, e.argument, cw, e.expected) }
+			cx += 1
+		})
+	}
+	cx++; if cv := (*EmailAddress)(nil).String(); cv != "" { t.Errorf("[%6d]: %s(nil) is (%s)", cx, fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
@@ -23,6 +23,8 @@ func TestExpandVERP(t *testing.T) {
 		{"", "+neko!example.jp", ""},
 		{"", "=neko!example.jp", ""},
 		{"", "cat+neko=meow@example@jp", ""},
+		{"", "unsub@b+", ""},
+		{"", "neko@+", ""},
 		{"", "neko", ""},
 	}
 
@@ -8,6 +8,8 @@
 
 package address
 import "strings"
+import "libsisimai.org/mailer-goemon/publicsuffix"
+import "libsisimai.org/mailer-goemon/rfc5322"
 
 type EmailAddress struct {
 	Address string // Email address
@@ -64,3 +66,18 @@ func Rise(addrs [3]string) *EmailAddress {
 	return thing
 }
 
+// RegistrableDomain returns the registrable domain (eTLD+1) of the domain part of the email address.
+//   Returns:
+//     - (string): Registrable domain such as "example.co.jp" of "neko@mx1.example.co.jp".
+func (this *EmailAddress) RegistrableDomain() string {
+	if this == nil || this.Host == "" { return "" }
+	return publicsuffix.RegistrableDomain(this.Host)
+}
+
+// String returns the email address with the display name for the address fields of the header.
+//   Returns:
+//     - (string): Formatted address such as `"Neko, Nyaan" <neko@example.jp>` or "neko@example.jp".
+func (this *EmailAddress) String() string {
+	if this == nil || this.Address == "" { return "" }
+	return rfc5322.FormatAddress(this.Address, this.Name)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package authres
+
+//  _____         _      __          _   _                   
+// |_   _|__  ___| |_   / /_ _ _   _| |_| |__  _ __ ___  ___ 
+//   | |/ _ \/ __| __| / / _` | | | | __| '_ \| '__/ _ \/ __|
+//   | |  __/\__ \ |_ / / (_| | |_| | |_| | | | | |  __/\__ \
+//   |_|\___||___/\__/_/ \__,_|\__,_|\__|_| |_|_|  \___||___/
+import "testing"
+
+func TestParse(t *testing.T) {
+	fn := "authres.Parse"
+	cx := 0
+
+	// Google
+	cv := Parse("mx.google.com;\r\n       dkim=pass header.i=@example.jp header.s=20230601 header.b=AbC+d/E=;\r\n" +
+		"       arc=pass (i=1 spf=pass spfdomain=example.jp dkim=pass dkdomain=example.jp dmarc=pass fromdomain=example.jp);\r\n" +
+		"       spf=pass (google.com: domain of neko@example.jp designates 192.0.2.1 as permitted sender) smtp.mailfrom=neko@example.jp;\r\n" +
+		"       dmarc=fail (p=REJECT sp=REJECT dis=REJECT) header.from=example.jp")
+	cx++; if cv == nil { t.Fatalf("%s() returns nil", fn) }
+	cx++; if cv.AuthServID != "mx.google.com" { t.Errorf("%s().AuthServID is %s", fn, cv.AuthServID) }
+	cx++; if len(cv.Methods) != 4 { t.Fatalf("%s() returns %d methods", fn, len(cv.Methods)) }
+	cx++; if cv.Methods[0].Method != "dkim" || cv.Methods[0].Result != "pass" { t.Errorf("%s().Methods[0] is %v", fn, cv.Methods[0]) }
+	cx++; if cv.Methods[0].Get("header.b") != "AbC+d/E=" { t.Errorf("%s(): header.b is %s", fn, cv.Methods[0].Get("header.b")) }
+	cx++; if cv.Methods[0].Get("Header.I") != "@example.jp" { t.Errorf("%s(): header.i is %s", fn, cv.Methods[0].Get("header.i")) }
+	cx++; if cv.Methods[1].Get("dkdomain") != "example.jp" { t.Errorf("%s(): dkdomain is %v", fn, cv.Methods[1].Extensions) }
+	cx++; if cv.Methods[1].Get("i") != "1" { t.Errorf("%s(): i is %v", fn, cv.Methods[1].Extensions) }
+	cx++; if cv.Methods[2].Get("smtp.mailfrom") != "neko@example.jp" { t.Errorf("%s(): smtp.mailfrom is %v", fn, cv.Methods[2].Properties) }
+	cx++; if len(cv.Methods[2].Comments) != 1 { t.Errorf("%s(): comments are %v", fn, cv.Methods[2].Comments) }
+	cx++; if cv.Methods[3].Get("p") != "REJECT" { t.Errorf("%s(): p is %v", fn, cv.Methods[3].Extensions) }
+	cx++; if cv.Methods[3].Failed() == false { t.Errorf("%s(): dmarc=fail is not failed", fn) }
+	cx++; if len(cv.Failed()) != 1 || cv.Failed()[0].Method != "dmarc" { t.Errorf("%s().Failed() returns %v", fn, cv.Failed()) }
+	cx++; if len(cv.Find("DKIM")) != 1 { t.Errorf("%s().Find(DKIM) returns %v", fn, cv.Find("DKIM")) }
+
+	// Microsoft
+	cv = Parse("spf=softfail (sender IP is 192.0.2.1) smtp.mailfrom=example.jp; dkim=none (message not signed)" +
+		" header.d=none;dmarc=fail action=oreject header.from=example.jp;compauth=fail reason=000")
+	cx++; if cv.AuthServID != "" { t.Errorf("%s().AuthServID is %s", fn, cv.AuthServID) }
+	cx++; if len(cv.Methods) != 4 { t.Fatalf("%s() returns %d methods", fn, len(cv.Methods)) }
+	cx++; if cv.Methods[1].Get("header.d") != "none" { t.Errorf("%s(): header.d is %v", fn, cv.Methods[1].Properties) }
+	cx++; if cv.Methods[2].Get("action") != "oreject" { t.Errorf("%s(): action is %v", fn, cv.Methods[2].Extensions) }
+	cx++; if cv.Methods[3].Method != "compauth" || cv.Methods[3].Reason != "000" { t.Errorf("%s(): compauth is %v", fn, cv.Methods[3]) }
+	cx++; if len(cv.Failed()) != 3 { t.Errorf("%s().Failed() returns %d methods", fn, len(cv.Failed())) }
+
+	// RFC8601 Appendix B
+	cv = Parse("example.com 1; none")
+	cx++; if cv.AuthServID != "example.com" || cv.Version != "1" || len(cv.Methods) != 0 { t.Errorf("%s() returns %v", fn, cv) }
+	cv = Parse(`example.com; dkim/1=fail reason="signature verification failed" (bad signature) header.d=example.org; iprev=pass policy.iprev=192.0.2.200`)
+	cx++; if len(cv.Methods) != 2 { t.Fatalf("%s() returns %d methods", fn, len(cv.Methods)) }
+	cx++; if cv.Methods[0].Method != "dkim" || cv.Methods[0].Reason != "signature verification failed" { t.Errorf("%s() returns %v", fn, cv.Methods[0]) }
+	cx++; if cv.Methods[1].Get("policy.iprev") != "192.0.2.200" { t.Errorf("%s() returns %v", fn, cv.Methods[1]) }
+
+	// Missing ";"
+	cv = Parse("mx.example.jp spf=pass smtp.mailfrom=example.jp dkim=temperror header.d=example.jp")
+	cx++; if len(cv.Methods) != 2 || cv.Methods[1].Method != "dkim" { t.Errorf("%s() returns %v", fn, cv.Methods) }
+	cx++; if cv.Methods[1].Failed() { t.Errorf("%s(): temperror is failed", fn) }
+
+	cx++; if cv := Parse(""); cv != nil { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv := (*Result)(nil).Find("spf"); len(cv) != 0 { t.Errorf("%s() returns %v", fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParseReceivedSPF(t *testing.T) {
+	fn := "authres.ParseReceivedSPF"
+	cx := 0
+
+	cv := ParseReceivedSPF(`Pass (mybox.example.org: domain of myname@example.com designates 192.0.2.1 as permitted sender)` +
+		` receiver=mybox.example.org; client-ip=192.0.2.1; envelope-from="myname@example.com"; helo=foo.example.com;`)
+	cx++; if cv == nil { t.Fatalf("%s() returns nil", fn) }
+	cx++; if cv.Method != "spf" || cv.Result != "pass" { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv.Get("client-ip") != "192.0.2.1" { t.Errorf("%s(): client-ip is %v", fn, cv.Properties) }
+	cx++; if cv.Get("envelope-from") != "myname@example.com" { t.Errorf("%s(): envelope-from is %v", fn, cv.Properties) }
+	cx++; if cv.Get("helo") != "foo.example.com" { t.Errorf("%s(): helo is %v", fn, cv.Properties) }
+	cx++; if len(cv.Comments) != 1 { t.Errorf("%s(): comments are %v", fn, cv.Comments) }
+
+	cv = ParseReceivedSPF("fail (example.org: domain of neko@example.jp does not designate 192.0.2.1 as permitted sender) problem=\"not permitted\"")
+	cx++; if cv.Failed() == false || cv.Get("problem") != "not permitted" { t.Errorf("%s() returns %v", fn, cv) }
+	cv = ParseReceivedSPF("none")
+	cx++; if cv.Result != "none" || len(cv.Properties) != 0 { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv := ParseReceivedSPF(" "); cv != nil { t.Errorf("%s() returns %v", fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//              _   _                   
+//   __ _ _   _| |_| |__  _ __ ___  ___ 
+//  / _` | | | | __| '_ \| '__/ _ \/ __|
+// | (_| | |_| | |_| | | | | |  __/\__ \
+//  \__,_|\__,_|\__|_| |_|_|  \___||___/
+
+// Package "authres" provides parsers of Authentication-Results (RFC8601) and Received-SPF (RFC7208)
+// headers to tell authentication failures from other rejections. https://datatracker.ietf.org/doc/html/rfc8601
+package authres
+import "strings"
+
+// Result is a parsed Authentication-Results header.
+type Result struct {
+	AuthServID string    // authserv-id such as "mx.google.com", empty when it is omitted
+	Version    string    // Version after the authserv-id such as "1", empty when it is omitted
+	Methods    []*Method // Method results in order of appearance, empty when the result is "none"
+}
+
+// Method is a result of an authentication method such as "spf=pass smtp.mailfrom=example.jp".
+type Method struct {
+	Method     string            // Method name in lower case such as "spf", "dkim", "dmarc", "arc", or "iprev"
+	Result     string            // Result in lower case such as "pass", "fail", "softfail", or "none"
+	Reason     string            // The value of "reason=" such as "signature verification failed"
+	Properties map[string]string // Properties such as {"smtp.mailfrom": "neko@example.jp", "header.d": "example.jp"}
+	Extensions map[string]string // Vendor extensions such as "action=none" and "p=REJECT" in comments
+	Comments   []string          // Comments such as "google.com: domain of ... designates ..."
+}
+
+// Find returns the method results of the given method.
+//   Arguments:
+//     - method (string): Method name such as "dkim", case-insensitive.
+//   Returns:
+//     - ([]*Method): Method results in order of appearance.
+func (this *Result) Find(method string) []*Method {
+	methods := []*Method{}
+	if this == nil { return methods }
+	for _, e := range this.Methods { if strings.EqualFold(e.Method, method) { methods = append(methods, e) } }
+	return methods
+}
+
+// Failed returns the method results which failed.
+//   Returns:
+//     - ([]*Method): Method results for which Method.Failed() returns true.
+func (this *Result) Failed() []*Method {
+	methods := []*Method{}
+	if this == nil { return methods }
+	for _, e := range this.Methods { if e.Failed() { methods = append(methods, e) } }
+	return methods
+}
+
+// Get returns the value of the property such as "header.d" or the vendor extension such as "action".
+//   Arguments:
+//     - name (string): Property name such as "smtp.mailfrom", case-insensitive.
+//   Returns:
+//     - (string): The value of the property, empty if the property does not exist.
+func (this *Method) Get(name string) string {
+	name = strings.ToLower(name)
+	if cv, ok := this.Properties[name]; ok { return cv }
+	return this.Extensions[name]
+}
+
+// Failed returns true if the result means the authentication failure. "temperror" is not a failure
+// because it is a transient error of the verifier.
+//   Returns:
+//     - (bool): true if the result is "fail", "softfail", "hardfail", "permerror", or "policy".
+func (this *Method) Failed() bool {
+	switch this.Result {
+		case "fail", "softfail", "hardfail", "permerror", "policy": return true
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//              _   _                      ______                       
+//   __ _ _   _| |_| |__  _ __ ___  ___   / /  _ \ __ _ _ __ ___  ___ _ 
+//  / _` | | | | __| '_ \| '__/ _ \/ __| / /| |_) / _` | '__/ __|/ _ (_)
+// | (_| | |_| | |_| | | | | |  __/\__ \/ / |  __/ (_| | |  \__ \  __/_ 
+//  \__,_|\__,_|\__|_| |_|_|  \___||___/_/  |_|   \__,_|_|  |___/\___(_)
+
+package authres
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// Method names which begin a new method result even if the preceding ";" is missing
+var methodNames = []string{
+	"arc", "auth", "bimi", "compauth", "dkim", "dkim-adsp", "dmarc", "domainkeys", "iprev", "rrvs",
+	"sender-id", "smime", "spf", "vbr",
+}
+
+// Parse parses the field body of Authentication-Results header. Comments, properties without
+// ptype such as "action=none" of Microsoft, and missing ";" between method results are dealt.
+//   Arguments:
+//     - value (string): Field body such as "mx.example.jp; spf=pass smtp.mailfrom=example.org".
+//   Returns:
+//     - (*Result): Parsed result, nil if the value is empty.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8601#section-2.2
+func Parse(value string) *Result {
+	//   authres-header-field = "Authentication-Results:" authres-payload
+	//   authres-payload = [CFWS] authserv-id [ CFWS authres-version ] ( no-result / 1*resinfo ) [CFWS] CRLF
+	//   resinfo         = [CFWS] ";" methodspec [ CFWS reasonspec ] [ 1*( CFWS propspec ) ]
+	tokens := rfc5322.Tokenize(value, ";=")
+	if len(tokens) == 0 { return nil }
+
+	result := &Result{Methods: []*Method{}}
+	j := 0
+	for ; j < len(tokens) && tokens[j].Kind == rfc5322.TokenComment; j++ {}
+	if j < len(tokens) && tokens[j].Kind != rfc5322.TokenSpecial && isAssignment(tokens, j) == false {
+		// authserv-id and authres-version such as "mx.example.jp 1"
+		result.AuthServID = tokens[j].Value; j++
+		if j < len(tokens) && tokens[j].Kind == rfc5322.TokenAtom && isDigits(tokens[j].Value) { result.Version = tokens[j].Value; j++ }
+	}
+
+	var method *Method
+	for j < len(tokens) {
+		// Each method result, reason, property, or comment
+		e := tokens[j]
+		if e.Kind == rfc5322.TokenComment {
+			if method != nil { method.addComment(e.Value) }
+			j++; continue
+		}
+		if e.Kind == rfc5322.TokenSpecial && e.Value == ";" { method = nil; j++; continue }
+		if e.Kind != rfc5322.TokenAtom || isAssignment(tokens, j) == false { j++; continue }
+
+		name := strings.ToLower(e.Value)
+		cv, next := readValue(tokens, j + 2); j = next
+		if p := strings.IndexByte(name, '/'); p > 0 { name = name[:p] } // methodspec may have a version: "dkim/1"
+
+		if method == nil || (isMethodName(name) && method.Result != "" && strings.IndexByte(name, '.') < 0) {
+			// A new method result such as "spf=pass"
+			method = &Method{Method: name, Result: strings.ToLower(cv), Properties: map[string]string{}, Extensions: map[string]string{}}
+			result.Methods = append(result.Methods, method)
+			continue
+		}
+		if name == "reason" && method.Reason == "" { method.Reason = cv; continue }
+		if strings.IndexByte(name, '.') > 0 {
+			// propspec: ptype "." property "=" pvalue such as "header.d=example.jp"
+			if _, ok := method.Properties[name]; ok == false { method.Properties[name] = cv }
+			continue
+		}
+		if _, ok := method.Extensions[name]; ok == false { method.Extensions[name] = cv }
+	}
+	return result
+}
+
+// ParseReceivedSPF parses the field body of Received-SPF header.
+//   Arguments:
+//     - value (string): Field body such as "pass (comment) client-ip=192.0.2.1; envelope-from=neko@example.jp".
+//   Returns:
+//     - (*Method): SPF result, Method is "spf", key-value pairs are set to Properties, nil if the
+//                  value is empty.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc7208#section-9.1
+func ParseReceivedSPF(value string) *Method {
+	//   Received-SPF = "Received-SPF:" [CFWS] result FWS [comment FWS] [ key-value-list ] CRLF
+	//   key-value-list = key-value-pair *( ";" [CFWS] key-value-pair ) [";"]
+	tokens := rfc5322.Tokenize(value, ";=")
+	if len(tokens) == 0 { return nil }
+
+	method := &Method{Method: "spf", Properties: map[string]string{}, Extensions: map[string]string{}}
+	for j := 0; j < len(tokens); {
+		e := tokens[j]
+		if e.Kind == rfc5322.TokenComment { method.addComment(e.Value); j++; continue }
+		if e.Kind != rfc5322.TokenAtom && e.Kind != rfc5322.TokenQuoted { j++; continue }
+
+		if isAssignment(tokens, j) {
+			// key-value-pair such as "client-ip=192.0.2.1"
+			name := strings.ToLower(e.Value)
+			cv, next := readValue(tokens, j + 2); j = next
+			if _, ok := method.Properties[name]; ok == false { method.Properties[name] = cv }
+			continue
+		}
+		if method.Result == "" && len(method.Properties) == 0 { method.Result = strings.ToLower(e.Value) }
+		j++
+	}
+	return method
+}
+
+// addComment appends the comment and sets "key=value" pairs in it such as "p=REJECT" to Extensions.
+func (this *Method) addComment(comment string) {
+	this.Comments = append(this.Comments, comment)
+	for _, e := range strings.Fields(comment) {
+		// Google: "(p=REJECT sp=REJECT dis=NONE)", "(i=1 spf=pass spfdomain=example.jp)"
+		p := strings.IndexByte(e, '='); if p < 1 || p == len(e) - 1 { continue }
+		name := strings.ToLower(e[:p])
+		if strings.Trim(name, "abcdefghijklmnopqrstuvwxyz0123456789-_.") != "" { continue }
+		if _, ok := this.Extensions[name]; ok == false { this.Extensions[name] = strings.TrimRight(e[p + 1:], ",;") }
+	}
+}
+
+// readValue returns the value consisting of the adjacent tokens from the index and the index of the
+// next token. A value such as "neko@example.jp" or "AbC+d/E=" is read as one value.
+func readValue(tokens []rfc5322.Token, index int) (string, int) {
+	cv, last := strings.Builder{}, -1
+	j := index
+	for ; j < len(tokens); j++ {
+		e := tokens[j]
+		if last > -1 && e.Offset > last { break }
+		if e.Kind == rfc5322.TokenComment { break }
+		if e.Kind == rfc5322.TokenSpecial && e.Value == ";" { break }
+		if e.Kind == rfc5322.TokenLiteral { cv.WriteString("[" + e.Value + "]") } else { cv.WriteString(e.Value) }
+		last = e.End
+	}
+	return cv.String(), j
+}
+
+// isAssignment returns true if the token at the index is followed by "=".
+func isAssignment(tokens []rfc5322.Token, index int) bool {
+	if index + 1 >= len(tokens) { return false }
+	return tokens[index + 1].Kind == rfc5322.TokenSpecial && tokens[index + 1].Value == "="
+}
+
+// isMethodName returns true if the name is a registered method name or begins with "x-".
+func isMethodName(name string) bool {
+	for _, e := range methodNames { if e == name { return true } }
+	return strings.HasPrefix(name, "x-")
+}
+
+func isDigits(text string) bool {
+	if text == "" { return false }
+	for j := 0; j < len(text); j++ { if text[j] < '0' || text[j] > '9' { return false } }
+	return true
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package dkim
+
+//  _____         _      __  _ _    _           
+// |_   _|__  ___| |_   / /_| | | _(_)_ __ ___  
+//   | |/ _ \/ __| __| / / _` | |/ / | '_ ` _ \ 
+//   | |  __/\__ \ |_ / / (_| |   <| | | | | | |
+//   |_|\___||___/\__/_/ \__,_|_|\_\_|_| |_| |_|
+import "time"
+import "errors"
+import "testing"
+
+func TestParseTagList(t *testing.T) {
+	fn := "dkim.ParseTagList"
+	cx := 0
+
+	cv, nyaan := ParseTagList(" v = 1 ;a=rsa-sha256;\r\n\tb=AbC d\r\n\teF=; x_y9= ;")
+	cx++; if nyaan != nil  { t.Fatalf("%s() returns error: %s", fn, nyaan) }
+	cx++; if len(cv) != 4  { t.Fatalf("%s() returns %v", fn, cv) }
+	cx++; if cv.Get("v") != "1" || cv.Get("b") != "AbC d\r\n\teF=" { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv.Has("x_y9") == false || cv.Get("x_y9") != "" { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv.Has("V") || cv.Get("d") != "" { t.Errorf("%s() returns %v", fn, cv) }
+
+	ae := []struct {argument string; tag string; expected error}{
+		{"v=1; a", "", ErrTagSyntax},
+		{"v=1; 9a=b", "9a", ErrTagSyntax},
+		{"v=1; a b=c", "a b", ErrTagSyntax},
+		{"d=example.jp; d=example.org", "d", ErrDuplicatedTag},
+	}
+	for _, e := range ae {
+		_, nyaan := ParseTagList(e.argument)
+		ce := new(TagError)
+		cx++; if errors.As(nyaan, &ce) == false || ce.Tag != e.tag || errors.Is(nyaan, e.expected) == false {
+			t.Errorf("%s(%s) returns %v, expected %v", fn, e.argument, nyaan, e.expected)
+		}
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParseSignature(t *testing.T) {
+	fn := "dkim.ParseSignature"
+	cx := 0
+
+	cv, nyaan := ParseSignature("v=1; a=rsa-sha256; c=relaxed; d=Example.JP; s=neko2026;\r\n" +
+		"\th=From:To : Subject:Date; i=kijitora@mail.example.jp; l=1024; t=1712345678; x=1712432078;\r\n" +
+		"\tz=From:neko@example.jp|To:kijitora@example.org;\r\n\tbh=2jUSOH9NhtVGCQWNr9BrIAPreKQjO6Sn7XIkfJVOzv8=;\r\n" +
+		"\tb=AuUoFEfDxTDkHlLXSZEpZj79LICEps6eda7W3deTVFOk4yAUoqOB\r\n\t 4nujc7YopdG5dWLSdNg6xNAZpOPr+kHxt1IrE+NahM6L/LbvaHut")
+	cx++; if nyaan != nil { t.Fatalf("%s() returns error: %s", fn, nyaan) }
+	cx++; if cv.Domain != "example.jp" || cv.Selector != "neko2026" { t.Errorf("%s() returns %s, %s", fn, cv.Domain, cv.Selector) }
+	cx++; if cv.KeyType() != "rsa" || cv.HashAlgorithm() != "sha256" { t.Errorf("%s() returns %s", fn, cv.Algorithm) }
+	cx++; if cv.HeaderCanon != "relaxed" || cv.BodyCanon != "simple" { t.Errorf("%s() returns %s/%s", fn, cv.HeaderCanon, cv.BodyCanon) }
+	cx++; if len(cv.Headers) != 4 || cv.Headers[2] != "Subject" { t.Errorf("%s().Headers is %v", fn, cv.Headers) }
+	cx++; if cv.Signs("date") == false || cv.Signs("Cc") { t.Errorf("%s().Signs() returns wrong value", fn) }
+	cx++; if cv.Identity != "kijitora@mail.example.jp" { t.Errorf("%s().Identity is %s", fn, cv.Identity) }
+	cx++; if cv.Length != 1024 { t.Errorf("%s().Length is %d", fn, cv.Length) }
+	cx++; if cv.Timestamp.Unix() != 1712345678 { t.Errorf("%s().Timestamp is %v", fn, cv.Timestamp) }
+	cx++; if cv.Expired(time.Unix(1712432079, 0)) == false || cv.Expired(time.Unix(1712345679, 0)) { t.Errorf("%s().Expired() returns wrong value", fn) }
+	cx++; if len(cv.CopiedHeaders) != 2 { t.Errorf("%s().CopiedHeaders is %v", fn, cv.CopiedHeaders) }
+	cx++; if len(cv.Signature) != 104 || cv.Signature[52:56] != "4nuj" { t.Errorf("%s().Signature is %s", fn, cv.Signature) }
+	cx++; if cv.QueryMethods[0] != "dns/txt" { t.Errorf("%s().QueryMethods is %v", fn, cv.QueryMethods) }
+
+	cv, nyaan = ParseSignature("v=1; a=ed25519-sha256; d=example.jp; s=neko; h=from; bh=AAA=; b=BBB=")
+	cx++; if nyaan != nil { t.Fatalf("%s() returns error: %s", fn, nyaan) }
+	cx++; if cv.Identity != "@example.jp" || cv.Length != -1 || cv.Timestamp.IsZero() == false { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv.Expired(time.Now()) { t.Errorf("%s().Expired() returns true", fn) }
+
+	ae := []struct {argument string; tag string; expected error}{
+		{"v=1; a=rsa-sha256; d=example.jp; s=neko; h=From; bh=AAA=", "b", ErrMissingTag},
+		{"v=2; a=rsa-sha256; d=example.jp; s=neko; h=From; bh=AAA=; b=BBB=", "v", ErrInvalidTag},
+		{"v=1; a=rsa; d=example.jp; s=neko; h=From; bh=AAA=; b=BBB=", "a", ErrInvalidTag},
+		{"v=1; a=rsa-sha256; d=example.jp; s=neko; h=To:Subject; bh=AAA=; b=BBB=", "h", ErrInvalidTag},
+		{"v=1; a=rsa-sha256; c=relaxed/loose; d=example.jp; s=neko; h=From; bh=AAA=; b=BBB=", "c", ErrInvalidTag},
+		{"v=1; a=rsa-sha256; d=example.jp; i=neko@example.org; s=neko; h=From; bh=AAA=; b=BBB=", "i", ErrInvalidTag},
+		{"v=1; a=rsa-sha256; d=example.jp; i=neko@badexample.jp; s=neko; h=From; bh=AAA=; b=BBB=", "i", ErrInvalidTag},
+		{"v=1; a=rsa-sha256; d=example.jp; s=neko; h=From; l=-1; bh=AAA=; b=BBB=", "l", ErrInvalidTag},
+		{"v=1; a=rsa-sha256; d=example.jp; s=neko; h=From; t=200; x=100; bh=AAA=; b=BBB=", "x", ErrInvalidTag},
+		{"v=1; a=rsa-sha256; d=example.jp; s=neko; h=From; t=now; bh=AAA=; b=BBB=", "t", ErrInvalidTag},
+		{"v=1; a=rsa-sha256; d=example.jp; s=neko; h=From; bh=AAA=; b=BBB=; d=example.org", "d", ErrDuplicatedTag},
+	}
+	for _, e := range ae {
+		cv, nyaan := ParseSignature(e.argument)
+		ce := new(TagError)
+		cx++; if cv != nil { t.Errorf("%s(%s) returns %v", fn, e.argument, cv) }
+		cx++; if errors.As(nyaan, &ce) == false || ce.Tag != e.tag || errors.Is(nyaan, e.expected) == false {
+			t.Errorf("%s(%s) returns %v, expected %v", fn, e.argument, nyaan, e.expected)
+		}
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package dkim
+
+//  _____         _      ___    ____   ____ 
+// |_   _|__  ___| |_   / / \  |  _ \ / ___|
+//   | |/ _ \/ __| __| / / _ \ | |_) | |    
+//   | |  __/\__ \ |_ / / ___ \|  _ <| |___ 
+//   |_|\___||___/\__/_/_/   \_\_| \_\\____|
+import "errors"
+import "strings"
+import "testing"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// arcSet returns the three ARC fields of the instance
+func arcSet(instance, cv string) []string {
+	return []string{
+		"ARC-Seal: i=" + instance + "; a=rsa-sha256; t=1712345678; cv=" + cv + "; d=example.org; s=arc-2026; b=AAA=\r\n",
+		"ARC-Message-Signature: i=" + instance + "; a=rsa-sha256; c=relaxed/relaxed; d=example.org; s=arc-2026;\r\n" +
+		"\th=from:to:subject; bh=BBB=; b=CCC=\r\n",
+		"ARC-Authentication-Results: i=" + instance + "; mx.example.org; dkim=pass header.d=example.jp; spf=pass smtp.mailfrom=example.jp\r\n",
+	}
+}
+
+func TestParseSeal(t *testing.T) {
+	fn := "dkim.ParseSeal"
+	cx := 0
+
+	cv, nyaan := ParseSeal("i=2; a=rsa-sha256; t=1712345678; cv=Pass; d=Example.ORG; s=arc-2026; b=AA\r\n\tA=")
+	cx++; if nyaan != nil { t.Fatalf("%s() returns error: %s", fn, nyaan) }
+	cx++; if cv.Instance != 2 || cv.ChainValidation != "pass" || cv.Domain != "example.org" { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv.Signature != "AAA=" || cv.Timestamp.Unix() != 1712345678 { t.Errorf("%s() returns %v", fn, cv) }
+
+	ae := []struct {argument string; tag string; expected error}{
+		{"i=1; a=rsa-sha256; d=example.org; s=arc; b=AAA=", "cv", ErrMissingTag},
+		{"i=1; a=rsa-sha256; cv=ok; d=example.org; s=arc; b=AAA=", "cv", ErrInvalidTag},
+		{"i=0; a=rsa-sha256; cv=none; d=example.org; s=arc; b=AAA=", "i", ErrInstance},
+		{"i=51; a=rsa-sha256; cv=none; d=example.org; s=arc; b=AAA=", "i", ErrInstance},
+		{"i=1; a=rsa-sha256; cv=none; d=example.org; s=arc; h=from; b=AAA=", "h", ErrForbiddenTag},
+	}
+	for _, e := range ae {
+		_, nyaan := ParseSeal(e.argument)
+		ce := new(TagError)
+		cx++; if errors.As(nyaan, &ce) == false || ce.Tag != e.tag || errors.Is(nyaan, e.expected) == false {
+			t.Errorf("%s(%s) returns %v, expected %v", fn, e.argument, nyaan, e.expected)
+		}
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParseMessageSignature(t *testing.T) {
+	fn := "dkim.ParseMessageSignature"
+	cx := 0
+
+	cv, nyaan := ParseMessageSignature("i=3; a=rsa-sha256; c=relaxed/relaxed; d=example.org; s=arc; h=From:To; bh=BBB=; b=CCC=")
+	cx++; if nyaan != nil { t.Fatalf("%s() returns error: %s", fn, nyaan) }
+	cx++; if cv.Instance != 3 || cv.Identity != "" || cv.Version != "" { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv.BodyCanon != "relaxed" { t.Errorf("%s().BodyCanon is %s", fn, cv.BodyCanon) }
+
+	_, nyaan = ParseMessageSignature("a=rsa-sha256; d=example.org; s=arc; h=From; bh=BBB=; b=CCC=")
+	cx++; if errors.Is(nyaan, ErrMissingTag) == false { t.Errorf("%s() returns %v", fn, nyaan) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParseResults(t *testing.T) {
+	fn := "dkim.ParseResults"
+	cx := 0
+
+	cv, nyaan := ParseResults("i=1; mx.google.com; dkim=pass header.i=@example.jp; dmarc=pass (p=NONE) header.from=example.jp")
+	cx++; if nyaan != nil { t.Fatalf("%s() returns error: %s", fn, nyaan) }
+	cx++; if cv.Instance != 1 || cv.Result.AuthServID != "mx.google.com" { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if len(cv.Result.Methods) != 2 || cv.Result.Methods[1].Get("p") != "NONE" { t.Errorf("%s() returns %v", fn, cv.Result.Methods) }
+
+	cv, nyaan = ParseResults("i=2")
+	cx++; if nyaan != nil || cv.Instance != 2 || len(cv.Result.Methods) != 0 { t.Errorf("%s() returns %v, %v", fn, cv, nyaan) }
+	_, nyaan = ParseResults("mx.google.com; dkim=pass")
+	cx++; if errors.Is(nyaan, ErrTagSyntax) == false { t.Errorf("%s() returns %v", fn, nyaan) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParseChain(t *testing.T) {
+	fn := "dkim.ParseChain"
+	cx := 0
+	ae := []struct {testname string; fields []string; status string; expected error}{
+		{"No ARC", []string{"Subject: Nyaan\r\n"}, "none", nil},
+		{"One set", arcSet("1", "none"), "pass", nil},
+		{"Two sets", append(arcSet("2", "pass"), arcSet("1", "none")...), "pass", nil},
+		{"cv=fail", append(arcSet("2", "fail"), arcSet("1", "none")...), "fail", ErrChainValidation},
+		{"cv=pass at i=1", arcSet("1", "pass"), "fail", ErrChainValidation},
+		{"cv=none at i=2", append(arcSet("2", "none"), arcSet("1", "none")...), "fail", ErrChainValidation},
+		{"Missing instance", append(arcSet("3", "pass"), arcSet("1", "none")...), "fail", ErrIncompleteSet},
+		{"Missing field", arcSet("1", "none")[1:], "fail", ErrIncompleteSet},
+		{"Duplicated", append(arcSet("1", "none"), arcSet("1", "none")[0]), "fail", ErrInstance},
+		{"Broken field", append(arcSet("1", "none"), "ARC-Seal: i=2; cv=pass\r\n"), "fail", ErrMissingTag},
+	}
+	for _, e := range ae {
+		ch, _ := rfc5322.ReadHeader(strings.NewReader(strings.Join(e.fields, "") + "\r\n"))
+		cv, nyaan := ParseChain(ch)
+		cx++; if cv.Status != e.status { t.Errorf("[%s] %s().Status is %s, expected %s", e.testname, fn, cv.Status, e.status) }
+		cx++; if errors.Is(nyaan, e.expected) == false || (e.expected == nil) != (nyaan == nil) {
+			t.Errorf("[%s] %s() returns %v, expected %v", e.testname, fn, nyaan, e.expected)
+		}
+		if cv.Status == "pass" {
+			cx++; if cv.Sets[0].Instance != 1 || cv.Sets[len(cv.Sets) - 1].Instance != len(cv.Sets) { t.Errorf("[%s] %s().Sets is not sorted", e.testname, fn) }
+		}
+	}
+	cv, nyaan := ParseChain(nil)
+	cx++; if cv.Status != "none" || nyaan != nil { t.Errorf("%s(nil) returns %v, %v", fn, cv, nyaan) }
+
+	t.Logf("The number of tests = %d", cx)
+}
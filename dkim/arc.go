@@ -0,0 +1,178 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//      _ _    _              ___    ____   ____   
+//   __| | | _(_)_ __ ___    / / \  |  _ \ / ___|_ 
+//  / _` | |/ / | '_ ` _ \  / / _ \ | |_) | |   (_)
+// | (_| |   <| | | | | | |/ / ___ \|  _ <| |___ _ 
+//  \__,_|_|\_\_|_| |_| |_/_/_/   \_\_| \_\\____(_)
+
+package dkim
+import "sort"
+import "time"
+import "strings"
+import "libsisimai.org/mailer-goemon/authres"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// Seal is a parsed ARC-Seal field.
+type Seal struct {
+	Instance        int       // i= between 1 and 50
+	Algorithm       string    // a= in lower case such as "rsa-sha256"
+	Signature       string    // b= without white spaces
+	ChainValidation string    // cv= in lower case: "none", "pass", or "fail"
+	Domain          string    // d= in lower case such as "example.jp"
+	Selector        string    // s= such as "arc-20240605"
+	Timestamp       time.Time // t=, zero value when it is omitted
+	Tags            TagList   // All the tags including unknown tags
+}
+
+// Results is a parsed ARC-Authentication-Results field.
+type Results struct {
+	Instance int             // i= between 1 and 50
+	Result   *authres.Result // Authentication-Results payload after "i=N;"
+}
+
+// Set is an ARC set: the three ARC fields of the same instance.
+type Set struct {
+	Instance         int
+	Seal             *Seal
+	MessageSignature *Signature
+	Results          *Results
+}
+
+// Chain is an ARC chain read from the header section.
+type Chain struct {
+	Sets   []*Set // ARC sets in order of the instance from 1
+	Status string // "none": no ARC field, "pass": the chain is structurally valid, "fail": otherwise
+}
+
+// ParseSeal parses the field body of ARC-Seal header.
+//   Arguments:
+//     - value (string): Field body such as "i=1; a=rsa-sha256; cv=none; d=example.jp; s=neko; t=1712345678; b=...".
+//   Returns:
+//     - (*Seal):  Parsed seal, nil if the tag-list is broken.
+//     - (error):  *TagError which wraps one of the Err* errors.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8617#section-4.1.3
+func ParseSeal(value string) (*Seal, error) {
+	taglist, nyaan := ParseTagList(value); if nyaan != nil { return nil, nyaan }
+	for _, e := range []string{"i", "a", "b", "cv", "d", "s"} {
+		// Required tags of ARC-Seal
+		if taglist.Has(e) == false { return nil, &TagError{Tag: e, Err: ErrMissingTag} }
+	}
+	if taglist.Has("h") { return nil, &TagError{Tag: "h", Err: ErrForbiddenTag} }
+
+	seal := &Seal{
+		Algorithm:       strings.ToLower(taglist.Get("a")),
+		Signature:       removeWhiteSpaces(taglist.Get("b")),
+		ChainValidation: strings.ToLower(taglist.Get("cv")),
+		Domain:          strings.ToLower(taglist.Get("d")),
+		Selector:        taglist.Get("s"),
+		Tags:            taglist,
+	}
+	if seal.Instance, nyaan = parseInstance(taglist); nyaan != nil { return nil, nyaan }
+	switch seal.ChainValidation {
+		case "none", "pass", "fail":
+		default: return nil, &TagError{Tag: "cv", Err: ErrInvalidTag}
+	}
+	if seal.Timestamp, nyaan = parseTimestamp(taglist, "t"); nyaan != nil { return nil, nyaan }
+	return seal, nil
+}
+
+// ParseMessageSignature parses the field body of ARC-Message-Signature header. The tags are the
+// same as DKIM-Signature except i= which is the instance number and v= which does not exist.
+//   Arguments:
+//     - value (string): Field body such as "i=1; a=rsa-sha256; c=relaxed/relaxed; d=example.jp; ...".
+//   Returns:
+//     - (*Signature): Parsed signature, Identity is empty, nil if the tag-list is broken.
+//     - (error):      *TagError which wraps one of the Err* errors.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8617#section-4.1.2
+func ParseMessageSignature(value string) (*Signature, error) {
+	taglist, nyaan := ParseTagList(value); if nyaan != nil { return nil, nyaan }
+	for _, e := range []string{"i", "a", "b", "bh", "d", "h", "s"} {
+		// Required tags of ARC-Message-Signature
+		if taglist.Has(e) == false { return nil, &TagError{Tag: e, Err: ErrMissingTag} }
+	}
+	signature, nyaan := parseSignature(taglist); if nyaan != nil { return nil, nyaan }
+	if signature.Instance, nyaan = parseInstance(taglist); nyaan != nil { return nil, nyaan }
+	return signature, nil
+}
+
+// ParseResults parses the field body of ARC-Authentication-Results header.
+//   Arguments:
+//     - value (string): Field body such as "i=1; mx.example.jp; spf=pass smtp.mailfrom=example.jp".
+//   Returns:
+//     - (*Results): Parsed results, nil if the instance is broken.
+//     - (error):    *TagError which wraps ErrMissingTag or ErrInstance.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8617#section-4.1.1
+func ParseResults(value string) (*Results, error) {
+	p := strings.IndexByte(value, ';'); if p < 0 { p = len(value) }
+	taglist, nyaan := ParseTagList(value[:p]); if nyaan != nil { return nil, nyaan }
+
+	results := &Results{Result: &authres.Result{Methods: []*authres.Method{}}}
+	if results.Instance, nyaan = parseInstance(taglist); nyaan != nil { return nil, nyaan }
+	if p < len(value) {
+		if cv := authres.Parse(value[p + 1:]); cv != nil { results.Result = cv }
+	}
+	return results, nil
+}
+
+// ParseChain reads all the ARC fields from the header section and checks the structure of the ARC
+// chain: each instance from 1 to the highest has exactly one ARC-Seal, ARC-Message-Signature, and
+// ARC-Authentication-Results, cv= of the instance 1 is "none" and others are "pass". Signatures
+// are not verified cryptographically.
+//   Arguments:
+//     - header (*rfc5322.Header): Header section read by rfc5322.ReadHeader().
+//   Returns:
+//     - (*Chain): ARC chain, Status is "none", "pass", or "fail".
+//     - (error):  *TagError which explains why Status is "fail".
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8617#section-5.2
+func ParseChain(header *rfc5322.Header) (*Chain, error) {
+	chain := &Chain{Sets: []*Set{}, Status: "none"}
+	if header == nil { return chain, nil }
+
+	instances := map[int]*Set{}
+	getset := func(instance int) *Set {
+		if instances[instance] == nil { instances[instance] = &Set{Instance: instance} }
+		return instances[instance]
+	}
+	fail := func(nyaan error) (*Chain, error) { chain.Status = "fail"; return chain, nyaan }
+
+	for _, e := range header.Fields {
+		// Each ARC field, the same field of the same instance must not appear twice
+		switch strings.ToLower(e.Name) {
+			case "arc-seal":
+				cv, nyaan := ParseSeal(e.Value); if nyaan != nil { return fail(nyaan) }
+				if getset(cv.Instance).Seal != nil { return fail(&TagError{Tag: "i", Err: ErrInstance}) }
+				getset(cv.Instance).Seal = cv
+
+			case "arc-message-signature":
+				cv, nyaan := ParseMessageSignature(e.Value); if nyaan != nil { return fail(nyaan) }
+				if getset(cv.Instance).MessageSignature != nil { return fail(&TagError{Tag: "i", Err: ErrInstance}) }
+				getset(cv.Instance).MessageSignature = cv
+
+			case "arc-authentication-results":
+				cv, nyaan := ParseResults(e.Value); if nyaan != nil { return fail(nyaan) }
+				if getset(cv.Instance).Results != nil { return fail(&TagError{Tag: "i", Err: ErrInstance}) }
+				getset(cv.Instance).Results = cv
+		}
+	}
+	if len(instances) == 0 { return chain, nil }
+
+	for _, e := range instances { chain.Sets = append(chain.Sets, e) }
+	sort.Slice(chain.Sets, func(a, b int) bool { return chain.Sets[a].Instance < chain.Sets[b].Instance })
+
+	for j, e := range chain.Sets {
+		// Instances must be 1, 2, 3, ... and each set must be complete
+		if e.Instance != j + 1 { return fail(&TagError{Tag: "i", Err: ErrIncompleteSet}) }
+		if e.Seal == nil || e.MessageSignature == nil || e.Results == nil { return fail(&TagError{Err: ErrIncompleteSet}) }
+
+		cv := e.Seal.ChainValidation
+		if cv == "fail" { return fail(&TagError{Tag: "cv", Err: ErrChainValidation}) }
+		if (e.Instance == 1) != (cv == "none") { return fail(&TagError{Tag: "cv", Err: ErrChainValidation}) }
+	}
+	chain.Status = "pass"
+	return chain, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//      _ _    _           
+//   __| | | _(_)_ __ ___  
+//  / _` | |/ / | '_ ` _ \ 
+// | (_| |   <| | | | | | |
+//  \__,_|_|\_\_|_| |_| |_|
+
+// Package "dkim" provides parsers of DKIM-Signature (RFC6376) and ARC (RFC8617) header fields and the
+// structural validation of ARC chains without cryptographic verification. https://datatracker.ietf.org/doc/html/rfc6376
+package dkim
+import "errors"
+import "strings"
+
+var (
+	ErrTagSyntax       = errors.New("tag-spec is not tag-name=tag-value")
+	ErrDuplicatedTag   = errors.New("tag appears more than once")
+	ErrMissingTag      = errors.New("required tag is missing")
+	ErrInvalidTag      = errors.New("tag value is invalid")
+	ErrForbiddenTag    = errors.New("tag must not appear in the field")
+	ErrInstance        = errors.New("instance number is out of range or duplicated")
+	ErrIncompleteSet   = errors.New("ARC set does not have all the three fields")
+	ErrChainValidation = errors.New("cv= is not valid for the instance")
+)
+
+// TagError is an error returned from parsers in this package, Err is one of the Err* errors above.
+type TagError struct {
+	Tag string // The tag name such as "bh", empty if the error is not related to a tag
+	Err error  // The error such as ErrMissingTag
+}
+
+func (this *TagError) Error() string {
+	if this.Tag == "" { return "dkim: " + this.Err.Error() }
+	return "dkim: " + this.Err.Error() + ": " + this.Tag + "="
+}
+func (this *TagError) Unwrap() error { return this.Err }
+
+// Tag is a tag-spec of a tag-list such as "d=example.jp".
+type Tag struct {
+	Name  string // tag-name such as "d"
+	Value string // tag-value without the leading and trailing white spaces
+}
+
+// TagList is a list of tag-specs in order of appearance.
+type TagList []Tag
+
+// Get returns the value of the tag.
+//   Arguments:
+//     - name (string): Tag name such as "d", case-sensitive.
+//   Returns:
+//     - (string): The value of the tag, empty if the tag does not exist.
+func (this TagList) Get(name string) string {
+	for _, e := range this { if e.Name == name { return e.Value } }
+	return ""
+}
+
+// Has returns true if the tag exists.
+func (this TagList) Has(name string) bool {
+	for _, e := range this { if e.Name == name { return true } }
+	return false
+}
+
+// ParseTagList parses a tag-list such as "v=1; a=rsa-sha256; d=example.jp;".
+//   Arguments:
+//     - value (string): Field body of DKIM-Signature, ARC-Seal, ARC-Message-Signature, or a DKIM key record.
+//   Returns:
+//     - (TagList): Tags in order of appearance.
+//     - (error):   *TagError which wraps ErrTagSyntax or ErrDuplicatedTag.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc6376#section-3.2
+func ParseTagList(value string) (TagList, error) {
+	//   tag-list  =  tag-spec *( ";" tag-spec ) [ ";" ]
+	//   tag-spec  =  [FWS] tag-name [FWS] "=" [FWS] tag-value [FWS]
+	//   tag-name  =  ALPHA *ALNUMPUNC
+	taglist := TagList{}
+	for _, e := range strings.Split(value, ";") {
+		// Each tag-spec, an empty tag-spec such as "a=b;;c=d" is ignored
+		if strings.Trim(e, " \t\r\n") == "" { continue }
+
+		p := strings.IndexByte(e, '='); if p < 0 { return taglist, &TagError{Err: ErrTagSyntax} }
+		name := strings.Trim(e[:p], " \t\r\n")
+		if isTagName(name) == false { return taglist, &TagError{Tag: name, Err: ErrTagSyntax} }
+		if taglist.Has(name)        { return taglist, &TagError{Tag: name, Err: ErrDuplicatedTag} }
+		taglist = append(taglist, Tag{Name: name, Value: strings.Trim(e[p + 1:], " \t\r\n")})
+	}
+	return taglist, nil
+}
+
+// isTagName returns true if the string is tag-name of RFC6376 3.2
+func isTagName(name string) bool {
+	if name == "" { return false }
+	for j := 0; j < len(name); j++ {
+		c := name[j]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' { continue }
+		if j > 0 && (c >= '0' && c <= '9' || c == '_')   { continue }
+		return false
+	}
+	return true
+}
+
+// removeWhiteSpaces removes all the white spaces from base64 values such as "b=" and "bh="
+func removeWhiteSpaces(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\t' || r == '\r' || r == '\n' { return -1 }
+		return r
+	}, text)
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//      _ _    _              ______  _                   _                    
+//   __| | | _(_)_ __ ___    / / ___|(_) __ _ _ __   __ _| |_ _   _ _ __ ___ _ 
+//  / _` | |/ / | '_ ` _ \  / /\___ \| |/ _` | '_ \ / _` | __| | | | '__/ _ (_)
+// | (_| |   <| | | | | | |/ /  ___) | | (_| | | | | (_| | |_| |_| | | |  __/_ 
+//  \__,_|_|\_\_|_| |_| |_/_/  |____/|_|\__, |_| |_|\__,_|\__|\__,_|_|  \___(_)
+//                                      |___/                                  
+
+package dkim
+import "time"
+import "strconv"
+import "strings"
+
+// Signature is a parsed DKIM-Signature or ARC-Message-Signature field.
+type Signature struct {
+	Instance      int       // i= of ARC-Message-Signature, 0 for DKIM-Signature
+	Version       string    // v= such as "1", empty for ARC-Message-Signature
+	Algorithm     string    // a= in lower case such as "rsa-sha256"
+	Signature     string    // b= without white spaces
+	BodyHash      string    // bh= without white spaces
+	HeaderCanon   string    // The header canonicalization of c=: "simple" or "relaxed"
+	BodyCanon     string    // The body canonicalization of c=: "simple" or "relaxed"
+	Domain        string    // d= in lower case such as "example.jp"
+	Headers       []string  // h= such as ["From", "To", "Subject"]
+	Identity      string    // i= of DKIM-Signature, "@" + d= when it is omitted
+	Length        int64     // l=, -1 when it is omitted
+	QueryMethods  []string  // q= such as ["dns/txt"]
+	Selector      string    // s= such as "20230601"
+	Timestamp     time.Time // t=, zero value when it is omitted
+	Expiration    time.Time // x=, zero value when it is omitted
+	CopiedHeaders []string  // z= such as ["From:neko@example.jp", "To:kijitora@example.org"]
+	Tags          TagList   // All the tags including unknown tags
+}
+
+// ParseSignature parses the field body of DKIM-Signature header.
+//   Arguments:
+//     - value (string): Field body such as "v=1; a=rsa-sha256; d=example.jp; s=neko; h=From:To; bh=...; b=...".
+//   Returns:
+//     - (*Signature): Parsed signature, nil if the tag-list is broken.
+//     - (error):      *TagError which wraps one of the Err* errors.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc6376#section-3.5
+func ParseSignature(value string) (*Signature, error) {
+	taglist, nyaan := ParseTagList(value); if nyaan != nil { return nil, nyaan }
+	for _, e := range []string{"v", "a", "b", "bh", "d", "h", "s"} {
+		// Required tags of DKIM-Signature
+		if taglist.Has(e) == false { return nil, &TagError{Tag: e, Err: ErrMissingTag} }
+	}
+	if taglist.Get("v") != "1" { return nil, &TagError{Tag: "v", Err: ErrInvalidTag} }
+
+	signature, nyaan := parseSignature(taglist); if nyaan != nil { return nil, nyaan }
+	signature.Identity = "@" + signature.Domain
+	if cv := taglist.Get("i"); cv != "" {
+		// The domain part of the AUID must be the same as or a subdomain of d=
+		p := strings.LastIndexByte(cv, '@'); if p < 0 { return nil, &TagError{Tag: "i", Err: ErrInvalidTag} }
+		domain := strings.ToLower(cv[p + 1:])
+		if domain != signature.Domain && strings.HasSuffix(domain, "." + signature.Domain) == false {
+			return nil, &TagError{Tag: "i", Err: ErrInvalidTag}
+		}
+		signature.Identity = cv
+	}
+	return signature, nil
+}
+
+// KeyType returns the signing algorithm of a= such as "rsa" or "ed25519".
+func (this *Signature) KeyType() string {
+	if p := strings.IndexByte(this.Algorithm, '-'); p > 0 { return this.Algorithm[:p] }
+	return this.Algorithm
+}
+
+// HashAlgorithm returns the hashing algorithm of a= such as "sha256".
+func (this *Signature) HashAlgorithm() string {
+	if p := strings.IndexByte(this.Algorithm, '-'); p > 0 { return this.Algorithm[p + 1:] }
+	return ""
+}
+
+// Signs returns true if the header field is included in h=.
+//   Arguments:
+//     - name (string): Field name such as "From", case-insensitive.
+//   Returns:
+//     - (bool): true if h= includes the field name.
+func (this *Signature) Signs(name string) bool {
+	for _, e := range this.Headers { if strings.EqualFold(e, name) { return true } }
+	return false
+}
+
+// Expired returns true if x= exists and the time is after it.
+//   Arguments:
+//     - now (time.Time): The current time.
+//   Returns:
+//     - (bool): true if the signature has expired.
+func (this *Signature) Expired(now time.Time) bool {
+	return this.Expiration.IsZero() == false && now.After(this.Expiration)
+}
+
+// parseSignature sets the tags shared with DKIM-Signature and ARC-Message-Signature.
+func parseSignature(taglist TagList) (*Signature, error) {
+	signature := &Signature{
+		Version:      taglist.Get("v"),
+		Algorithm:    strings.ToLower(taglist.Get("a")),
+		Signature:    removeWhiteSpaces(taglist.Get("b")),
+		BodyHash:     removeWhiteSpaces(taglist.Get("bh")),
+		HeaderCanon:  "simple",
+		BodyCanon:    "simple",
+		Domain:       strings.ToLower(taglist.Get("d")),
+		Headers:      []string{},
+		Length:       -1,
+		QueryMethods: []string{"dns/txt"},
+		Selector:     taglist.Get("s"),
+		Tags:         taglist,
+	}
+	if signature.Algorithm == "" || strings.IndexByte(signature.Algorithm, '-') < 1 { return nil, &TagError{Tag: "a", Err: ErrInvalidTag} }
+	if signature.Signature == "" { return nil, &TagError{Tag: "b", Err: ErrInvalidTag} }
+	if signature.Domain    == "" { return nil, &TagError{Tag: "d", Err: ErrInvalidTag} }
+	if signature.Selector  == "" { return nil, &TagError{Tag: "s", Err: ErrInvalidTag} }
+
+	if cv := taglist.Get("c"); cv != "" {
+		// "relaxed/simple", "relaxed" means "relaxed/simple"
+		p := strings.Split(strings.ToLower(cv), "/"); if len(p) > 2 { return nil, &TagError{Tag: "c", Err: ErrInvalidTag} }
+		for _, e := range p { if e != "simple" && e != "relaxed" { return nil, &TagError{Tag: "c", Err: ErrInvalidTag} } }
+		signature.HeaderCanon = p[0]; if len(p) == 2 { signature.BodyCanon = p[1] }
+	}
+
+	for _, e := range strings.Split(taglist.Get("h"), ":") {
+		// Signed header fields, From: must be signed
+		if e = strings.Trim(e, " \t\r\n"); e != "" { signature.Headers = append(signature.Headers, e) }
+	}
+	if signature.Signs("From") == false { return nil, &TagError{Tag: "h", Err: ErrInvalidTag} }
+
+	if taglist.Has("l") {
+		cv, nyaan := strconv.ParseInt(taglist.Get("l"), 10, 64)
+		if nyaan != nil || cv < 0 { return nil, &TagError{Tag: "l", Err: ErrInvalidTag} }
+		signature.Length = cv
+	}
+	if cv := taglist.Get("q"); cv != "" {
+		signature.QueryMethods = []string{}
+		for _, e := range strings.Split(cv, ":") { signature.QueryMethods = append(signature.QueryMethods, strings.Trim(e, " \t\r\n")) }
+	}
+
+	var nyaan error
+	if signature.Timestamp, nyaan  = parseTimestamp(taglist, "t"); nyaan != nil { return nil, nyaan }
+	if signature.Expiration, nyaan = parseTimestamp(taglist, "x"); nyaan != nil { return nil, nyaan }
+	if signature.Timestamp.IsZero() == false && signature.Expiration.IsZero() == false && signature.Expiration.Before(signature.Timestamp) {
+		return nil, &TagError{Tag: "x", Err: ErrInvalidTag}
+	}
+	if cv := removeWhiteSpaces(taglist.Get("z")); cv != "" { signature.CopiedHeaders = strings.Split(cv, "|") }
+	return signature, nil
+}
+
+// parseTimestamp returns the time of the tag such as "t=1712345678", the zero value when it is omitted.
+func parseTimestamp(taglist TagList, name string) (time.Time, error) {
+	if taglist.Has(name) == false { return time.Time{}, nil }
+	cv, nyaan := strconv.ParseInt(taglist.Get(name), 10, 64)
+	if nyaan != nil || cv < 0 || len(taglist.Get(name)) > 12 { return time.Time{}, &TagError{Tag: name, Err: ErrInvalidTag} }
+	return time.Unix(cv, 0).UTC(), nil
+}
+
+// parseInstance returns the value of "i=" of ARC fields which is between 1 and 50.
+func parseInstance(taglist TagList) (int, error) {
+	if taglist.Has("i") == false { return 0, &TagError{Tag: "i", Err: ErrMissingTag} }
+	cv, nyaan := strconv.Atoi(taglist.Get("i"))
+	if nyaan != nil || cv < 1 || cv > 50 { return 0, &TagError{Tag: "i", Err: ErrInstance} }
+	return cv, nil
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package mailbox
+
+//  _____         _      __               _ _ _               
+// |_   _|__  ___| |_   / / __ ___   __ _(_) | |__   _____  __
+//   | |/ _ \/ __| __| / / '_ ` _ \ / _` | | | '_ \ / _ \ \/ /
+//   | |  __/\__ \ |_ / /| | | | | | (_| | | | |_) | (_) >  < 
+//   |_|\___||___/\__/_/ |_| |_| |_|\__,_|_|_|_.__/ \___/_/\_\
+import "io"
+import "os"
+import "strings"
+import "testing"
+import "path/filepath"
+
+func TestReadMbox(t *testing.T) {
+	fn := "mailbox.ReadMbox"
+	cx := 0
+	ce := strings.Join([]string{
+		"\n",
+		"From MAILER-DAEMON Thu Apr 29 23:34:45 2010\n",
+		"Subject: 1\n",
+		"\n",
+		">From the body\n",
+		">>From the quoted body\n",
+		"\n",
+		"From neko@example.jp Fri Apr 30 00:00:00 2010\n",
+		"Subject: 2\n",
+		"\n",
+		"Body\n",
+		"\n",
+		"\n",
+		"From kijitora@example.jp broken date\n",
+		"Subject: 3\n",
+		"\n",
+		"Last\n",
+		"\n",
+	}, "")
+	ae := []struct {format MboxFormat; bodies []string}{
+		{MboxO,  []string{"Subject: 1\n\nFrom the body\n>>From the quoted body\n", "Subject: 2\n\nBody\n\n", "Subject: 3\n\nLast\n"}},
+		{MboxRD, []string{"Subject: 1\n\nFrom the body\n>From the quoted body\n", "Subject: 2\n\nBody\n\n", "Subject: 3\n\nLast\n"}},
+	}
+	for _, e := range ae {
+		cw := []Message{}
+		for f, nyaan := range ReadMbox(strings.NewReader(ce), e.format) {
+			cx++; if nyaan != nil { t.Fatalf("%s() yields error: %s", fn, nyaan) }
+			cv, _ := io.ReadAll(f.Reader)
+			cx++; if len(cw) < len(e.bodies) && string(cv) != e.bodies[len(cw)] {
+				t.Errorf("%s(%d) yields\n[%s], expected\n[%s]", fn, e.format, cv, e.bodies[len(cw)])
+			}
+			cw = append(cw, f)
+		}
+		cx++; if len(cw) != 3 { t.Fatalf("%s(%d) yields %d messages", fn, e.format, len(cw)) }
+		cx++; if cw[0].Sender != "MAILER-DAEMON" || cw[0].Date.Unix() != 1272584085 { t.Errorf("%s(%d) yields %v", fn, e.format, cw[0]) }
+		cx++; if cw[0].Offset != 1 || cw[1].Offset != int64(strings.Index(ce, "From neko")) { t.Errorf("%s(%d) yields offsets %d, %d", fn, e.format, cw[0].Offset, cw[1].Offset) }
+		cx++; if cw[2].Sender != "kijitora@example.jp" || cw[2].Date.IsZero() == false { t.Errorf("%s(%d) yields %v", fn, e.format, cw[2]) }
+	}
+
+	// The unread part of the message is skipped
+	cv := []string{}
+	for f, _ := range ReadMbox(strings.NewReader(ce), MboxRD) {
+		ch, nyaan := f.ReadHeader()
+		cx++; if nyaan != nil { t.Fatalf("%s().ReadHeader() returns %s", fn, nyaan) }
+		cv = append(cv, ch.Get("Subject"))
+	}
+	cx++; if strings.Join(cv, ",") != "1,2,3" { t.Errorf("%s() yields %v", fn, cv) }
+
+	// Break the iteration
+	cz := 0
+	for range ReadMbox(strings.NewReader(ce), MboxO) { cz++; break }
+	cx++; if cz != 1 { t.Errorf("%s() does not stop the iteration", fn) }
+
+	// Not an mbox, an empty mbox
+	for _, nyaan := range ReadMbox(strings.NewReader("Subject: Nyaan\n\n"), MboxO) {
+		cx++; if nyaan != ErrNotMbox { t.Errorf("%s() yields %v", fn, nyaan) }
+	}
+	for range ReadMbox(strings.NewReader(""), MboxO) { t.Errorf("%s() yields a message from an empty string", fn) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestReadMboxCL2(t *testing.T) {
+	fn := "mailbox.ReadMbox"
+	cx := 0
+	ce := strings.Join([]string{
+		"From MAILER-DAEMON Thu Apr 29 23:34:45 2010\r\n",
+		"Subject: 1\r\n",
+		"Content-Length: 22\r\n",
+		"\r\n",
+		"From the body\r\n",
+		">From\r\n",
+		"\r\n",
+		"From neko@example.jp Fri Apr 30 00:00:00 2010\r\n",
+		"Subject: 2\r\n",
+		"Content-Length: nyaan\r\n",
+		"\r\n",
+		">From the body\r\n",
+	}, "")
+	ae := []string{"Subject: 1\r\nContent-Length: 22\r\n\r\nFrom the body\r\n>From\r\n", "Subject: 2\r\nContent-Length: nyaan\r\n\r\n>From the body\r\n"}
+	cw := 0
+	for f, nyaan := range ReadMbox(strings.NewReader(ce), MboxCL2) {
+		cx++; if nyaan != nil { t.Fatalf("%s() yields error: %s", fn, nyaan) }
+		cv, _ := io.ReadAll(f.Reader)
+		cx++; if cw < len(ae) && string(cv) != ae[cw] { t.Errorf("%s() yields\n[%q], expected\n[%q]", fn, cv, ae[cw]) }
+		cw++
+	}
+	cx++; if cw != 2 { t.Errorf("%s() yields %d messages", fn, cw) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestOpenMbox(t *testing.T) {
+	fn := "mailbox.OpenMbox"
+	cx := 0
+	cf := filepath.Join(t.TempDir(), "mbox")
+	os.WriteFile(cf, []byte("From MAILER-DAEMON Thu Apr 29 23:34:45 2010\nContent-Type: multipart/report; boundary=\"B\"\n\n" +
+		"--B\nContent-Type: message/delivery-status\n\nStatus: 5.1.1\n--B--\n\n"), 0644)
+
+	cw := 0
+	for e, nyaan := range OpenMbox(cf, MboxRD) {
+		cx++; if nyaan != nil { t.Fatalf("%s() yields error: %s", fn, nyaan) }
+		cx++; if e.Path != cf { t.Errorf("%s().Path is %s", fn, e.Path) }
+		cr := e.MIME()
+		for {
+			part, nyaan := cr.NextPart(); if nyaan != nil { break }
+			cw++
+			if part.MediaType == "message/delivery-status" {
+				cv, _ := part.Text()
+				cx++; if strings.TrimSpace(cv) != "Status: 5.1.1" { t.Errorf("%s(): the body is %s", fn, cv) }
+			}
+		}
+	}
+	cx++; if cw != 2 { t.Errorf("%s(): MIME() returns %d parts", fn, cw) }
+
+	for _, nyaan := range OpenMbox(filepath.Join(t.TempDir(), "nyaan"), MboxO) {
+		cx++; if os.IsNotExist(nyaan) == false { t.Errorf("%s() yields %v", fn, nyaan) }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package mailbox
+
+//  _____         _      ____  __       _ _     _ _      
+// |_   _|__  ___| |_   / /  \/  | __ _(_) | __| (_)_ __ 
+//   | |/ _ \/ __| __| / /| |\/| |/ _` | | |/ _` | | '__|
+//   | |  __/\__ \ |_ / / | |  | | (_| | | | (_| | | |   
+//   |_|\___||___/\__/_/  |_|  |_|\__,_|_|_|\__,_|_|_|   
+import "os"
+import "testing"
+import "path/filepath"
+
+func TestReadMaildir(t *testing.T) {
+	fn := "mailbox.ReadMaildir"
+	cx := 0
+	cd := t.TempDir()
+	for _, e := range []string{"new", "cur", "tmp", "cur/.subdir"} { os.MkdirAll(filepath.Join(cd, e), 0755) }
+	for _, e := range []string{
+		"new/1712345678.M1P2.mx.example.jp", "cur/1712345600.M1P1.mx.example.jp:2,RS", "cur/1712345601.M1P1.mx.example.jp!2,FT",
+		"cur/.hidden", "tmp/1712345679.M1P3.mx.example.jp",
+	} {
+		os.WriteFile(filepath.Join(cd, e), []byte("Subject: " + filepath.Base(e) + "\n\nBody\n"), 0644)
+	}
+
+	cw := []Message{}
+	for e, nyaan := range ReadMaildir(cd) {
+		cx++; if nyaan != nil { t.Fatalf("%s() yields error: %s", fn, nyaan) }
+		ch, _ := e.ReadHeader()
+		cx++; if ch.Get("Subject") != filepath.Base(e.Path) { t.Errorf("%s() yields %s for %s", fn, ch.Get("Subject"), e.Path) }
+		cx++; if e.Date.IsZero() { t.Errorf("%s().Date is zero", fn) }
+		cw = append(cw, e)
+	}
+	cx++; if len(cw) != 3 { t.Fatalf("%s() yields %d messages", fn, len(cw)) }
+	cx++; if cw[0].Subdir != "new" || cw[0].Flags != "" { t.Errorf("%s() yields %v", fn, cw[0]) }
+	cx++; if cw[1].Subdir != "cur" || cw[1].Flags != "RS" || cw[1].HasFlag('S') == false || cw[1].HasFlag('T') { t.Errorf("%s() yields %v", fn, cw[1]) }
+	cx++; if cw[2].Flags != "FT" || cw[2].HasFlag('T') == false { t.Errorf("%s() yields %v", fn, cw[2]) }
+
+	for _, nyaan := range ReadMaildir(t.TempDir()) {
+		cx++; if nyaan != ErrNotMaildir { t.Errorf("%s() yields %v", fn, nyaan) }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
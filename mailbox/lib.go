@@ -0,0 +1,57 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                  _ _ _               
+//  _ __ ___   __ _(_) | |__   _____  __
+// | '_ ` _ \ / _` | | | '_ \ / _ \ \/ /
+// | | | | | | (_| | | | |_) | (_) >  < 
+// |_| |_| |_|\__,_|_|_|_.__/ \___/_/\_\
+
+// Package "mailbox" provides streaming readers of mbox files and Maildir directories to process
+// archived bounce messages one by one. https://datatracker.ietf.org/doc/html/rfc4155
+package mailbox
+import "time"
+import "bufio"
+import "errors"
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc2045"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+var ErrNotMbox    = errors.New("mailbox: the data does not begin with a \"From \" line")
+var ErrNotMaildir = errors.New("mailbox: the directory has neither \"new\" nor \"cur\"")
+
+// Message is a message in the mbox or the Maildir. Reader can be read only until the iteration goes
+// to the next message.
+type Message struct {
+	Reader *bufio.Reader // The message including the header, "From " line of the mbox is not included
+	Path   string        // Path of the Maildir file or the mbox file given to OpenMbox()
+	Offset int64         // Byte offset of the "From " line in the mbox, 0 for the Maildir
+	Sender string        // Envelope sender in the "From " line of the mbox such as "MAILER-DAEMON"
+	Date   time.Time     // Date in the "From " line of the mbox or the modification time of the Maildir file
+	Subdir string        // "new" or "cur" of the Maildir, empty for the mbox
+	Flags  string        // Info flags of the Maildir file name such as "RS", empty for the mbox
+}
+
+// ReadHeader reads the header section of the message, the body can be read from Reader after this.
+//   Returns:
+//     - (*rfc5322.Header): The header section.
+//     - (error):           An error returned from the reader.
+func (this Message) ReadHeader() (*rfc5322.Header, error) {
+	return rfc5322.ReadHeader(this.Reader)
+}
+
+// MIME returns a MIME message reader reading the message from the beginning.
+//   Returns:
+//     - (*rfc2045.Reader): MIME message reader.
+func (this Message) MIME() *rfc2045.Reader {
+	return rfc2045.NewReader(this.Reader)
+}
+
+// HasFlag returns true if the Maildir info flags include the flag.
+//   Arguments:
+//     - flag (byte): Flag such as 'S' (seen), 'R' (replied), 'F' (flagged), 'T' (trashed),
+//                    'D' (draft), or 'P' (passed).
+//   Returns:
+//     - (bool): true if the message has the flag.
+func (this Message) HasFlag(flag byte) bool {
+	return strings.IndexByte(this.Flags, flag) > -1
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                  _ _ _                   ____  __       _ _     _ _        
+//  _ __ ___   __ _(_) | |__   _____  __   / /  \/  | __ _(_) | __| (_)_ __ _ 
+// | '_ ` _ \ / _` | | | '_ \ / _ \ \/ /  / /| |\/| |/ _` | | |/ _` | | '__(_)
+// | | | | | | (_| | | | |_) | (_) >  <  / / | |  | | (_| | | | (_| | | |   _ 
+// |_| |_| |_|\__,_|_|_|_.__/ \___/_/\_\/_/  |_|  |_|\__,_|_|_|\__,_|_|_|  (_)
+
+package mailbox
+import "os"
+import "iter"
+import "bufio"
+import "errors"
+import "strings"
+import "io/fs"
+import "path/filepath"
+
+// ReadMaildir returns messages in "new" and "cur" of the Maildir in order of the file name. Files in
+// "tmp" are not read because they are being delivered. Each file is opened when the iteration goes
+// to it and is closed when the iteration goes to the next.
+//   Arguments:
+//     - dir (string): Path to the Maildir which has "new", "cur", and "tmp".
+//   Returns:
+//     - (iter.Seq2[Message, error]): Messages, an error is yielded with the empty Message.
+//   See:
+//     - https://cr.yp.to/proto/maildir.html
+func ReadMaildir(dir string) iter.Seq2[Message, error] {
+	return func(yield func(Message, error) bool) {
+		found := false
+		for _, subdir := range []string{"new", "cur"} {
+			// Messages in "new" have no info, messages in "cur" have the info such as ":2,RS"
+			entries, nyaan := os.ReadDir(filepath.Join(dir, subdir))
+			if nyaan != nil {
+				if errors.Is(nyaan, fs.ErrNotExist) { continue }
+				yield(Message{}, nyaan); return
+			}
+			found = true
+
+			for _, e := range entries {
+				// Each file except dot files and directories
+				if e.Type().IsRegular() == false || strings.HasPrefix(e.Name(), ".") { continue }
+
+				path := filepath.Join(dir, subdir, e.Name())
+				handle, nyaan := os.Open(path)
+				if nyaan != nil {
+					// The file may have been moved from "new" to "cur" or deleted by other programs
+					if errors.Is(nyaan, fs.ErrNotExist) { continue }
+					if yield(Message{}, nyaan) == false { return }
+					continue
+				}
+
+				message := Message{Reader: bufio.NewReader(handle), Path: path, Subdir: subdir, Flags: infoFlags(e.Name())}
+				if cv, nyaan := handle.Stat(); nyaan == nil { message.Date = cv.ModTime() }
+				next := yield(message, nil)
+				handle.Close(); if next == false { return }
+			}
+		}
+		if found == false { yield(Message{}, ErrNotMaildir) }
+	}
+}
+
+// infoFlags returns the flags of the info such as "RS" of "1712345678.M1P2.mx.example.jp:2,RS".
+// Some programs use "!" or ";" instead of ":" because ":" cannot be used in file names on Windows.
+func infoFlags(name string) string {
+	for _, e := range []string{":2,", "!2,", ";2,"} {
+		if p := strings.LastIndex(name, e); p > 0 { return name[p + 3:] }
+	}
+	return ""
+}
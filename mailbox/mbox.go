@@ -0,0 +1,190 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                  _ _ _                   ____  __ _                 
+//  _ __ ___   __ _(_) | |__   _____  __   / /  \/  | |__   _____  ___ 
+// | '_ ` _ \ / _` | | | '_ \ / _ \ \/ /  / /| |\/| | '_ \ / _ \ \/ (_)
+// | | | | | | (_| | | | |_) | (_) >  <  / / | |  | | |_) | (_) >  < _ 
+// |_| |_| |_|\__,_|_|_|_.__/ \___/_/\_\/_/  |_|  |_|_.__/ \___/_/\_(_)
+
+package mailbox
+import "io"
+import "os"
+import "iter"
+import "time"
+import "bytes"
+import "bufio"
+import "errors"
+import "strconv"
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// MboxFormat is a variant of the mbox format.
+type MboxFormat uint8
+const (
+	MboxO   MboxFormat = iota // mboxo: ">From " lines are unquoted
+	MboxRD                    // mboxrd: ">From ", ">>From ", ... lines are unquoted one level
+	MboxCL2                   // mboxcl2: the body is read with Content-Length header without unquoting
+)
+
+// mboxReader is the state shared by the messages in the mbox
+type mboxReader struct {
+	reader *bufio.Reader
+	format MboxFormat
+	offset int64  // Byte offset of the next byte to be read
+	next   []byte // "From " line of the next message, nil at the end of the mbox
+	nextat int64  // Byte offset of the "From " line of the next message
+	nyaan  error  // An error returned from the reader
+}
+
+// OpenMbox opens the mbox file and returns messages in it, the file is closed when the iteration ends.
+//   Arguments:
+//     - path (string):        Path to the mbox file.
+//     - format (MboxFormat):  MboxO, MboxRD, or MboxCL2.
+//   Returns:
+//     - (iter.Seq2[Message, error]): Messages, an error is yielded with the empty Message.
+func OpenMbox(path string, format MboxFormat) iter.Seq2[Message, error] {
+	return func(yield func(Message, error) bool) {
+		handle, nyaan := os.Open(path); if nyaan != nil { yield(Message{}, nyaan); return }
+		defer handle.Close()
+
+		for e, nyaan := range ReadMbox(handle, format) {
+			if nyaan == nil { e.Path = path }
+			if yield(e, nyaan) == false { return }
+		}
+	}
+}
+
+// ReadMbox returns messages in the mbox one by one without reading the whole mbox into memory.
+//   Arguments:
+//     - r (io.Reader):        The mbox beginning with a "From " line.
+//     - format (MboxFormat):  MboxO, MboxRD, or MboxCL2.
+//   Returns:
+//     - (iter.Seq2[Message, error]): Messages, an error is yielded with the empty Message and the
+//                                    iteration ends.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc4155
+func ReadMbox(r io.Reader, format MboxFormat) iter.Seq2[Message, error] {
+	return func(yield func(Message, error) bool) {
+		mbox := &mboxReader{reader: bufio.NewReader(r), format: format}
+		for {
+			// Find the first "From " line, empty lines before it are ignored
+			line, nyaan := mbox.reader.ReadBytes('\n')
+			at := mbox.offset; mbox.offset += int64(len(line))
+			if len(line) == 0 {
+				if nyaan != nil && nyaan != io.EOF { yield(Message{}, nyaan) }
+				return
+			}
+			if len(bytes.TrimRight(line, "\r\n")) == 0 { continue }
+			if bytes.HasPrefix(line, []byte("From ")) == false { yield(Message{}, ErrNotMbox); return }
+			mbox.next, mbox.nextat = line, at
+			break
+		}
+
+		for mbox.next != nil {
+			// Each message from the "From " line until the next "From " line
+			message := Message{Offset: mbox.nextat}
+			message.Sender, message.Date = parseFromLine(string(mbox.next))
+			mbox.next = nil
+
+			body := &mboxBody{mbox: mbox, header: true, remains: -1}
+			message.Reader = bufio.NewReader(body)
+			if yield(message, nil) == false { return }
+
+			io.Copy(io.Discard, body)
+			if mbox.nyaan != nil { yield(Message{}, mbox.nyaan); return }
+		}
+	}
+}
+
+// mboxBody reads a message until the next "From " line. The empty line just before the "From "
+// line is the separator and is not a part of the message.
+type mboxBody struct {
+	mbox    *mboxReader
+	buffer  []byte // Data to be returned from Read()
+	held    []byte // An empty line which may be the separator
+	hbytes  []byte // The header section for Content-Length of mboxcl2
+	header  bool   // Reading the header section
+	remains int64  // Bytes of the body to be read as is for mboxcl2, -1 when it is not used
+	done    bool
+}
+
+func (this *mboxBody) Read(p []byte) (int, error) {
+	for len(this.buffer) == 0 {
+		if this.done { return 0, io.EOF }
+
+		if this.remains > 0 {
+			// mboxcl2: the body of Content-Length bytes may include "From " lines
+			chunk := make([]byte, min(this.remains, 32768))
+			n, nyaan := io.ReadFull(this.mbox.reader, chunk)
+			this.mbox.offset += int64(n); this.remains -= int64(n)
+			this.buffer = append(this.held, chunk[:n]...); this.held = nil
+			if nyaan == io.ErrUnexpectedEOF || nyaan == io.EOF { this.remains = 0; continue }
+			if nyaan != nil { this.mbox.nyaan = nyaan; this.done = true; return 0, nyaan }
+			continue
+		}
+
+		line, nyaan := this.mbox.reader.ReadBytes('\n')
+		at := this.mbox.offset; this.mbox.offset += int64(len(line))
+		if len(line) == 0 {
+			// The end of the mbox, the held empty line is the separator
+			this.done = true
+			if nyaan != nil && nyaan != io.EOF { this.mbox.nyaan = nyaan; return 0, nyaan }
+			continue
+		}
+		if bytes.HasPrefix(line, []byte("From ")) {
+			// The "From " line of the next message
+			this.mbox.next, this.mbox.nextat = line, at
+			this.done = true
+			continue
+		}
+
+		if this.header {
+			if len(bytes.TrimRight(line, "\r\n")) == 0 {
+				// The end of the header section
+				this.header = false
+				if this.mbox.format == MboxCL2 { this.remains = contentLength(this.hbytes) }
+				this.buffer = line
+				continue
+			}
+			if this.mbox.format == MboxCL2 { this.hbytes = append(this.hbytes, line...) }
+		}
+		if this.mbox.format != MboxCL2 { line = unquoteFromLine(line, this.mbox.format) }
+
+		if len(bytes.TrimRight(line, "\r\n")) == 0 {
+			// Hold the empty line until the next line is read
+			this.buffer = this.held; this.held = line
+			continue
+		}
+		this.buffer = append(this.held, line...); this.held = nil
+	}
+	n := copy(p, this.buffer); this.buffer = this.buffer[n:]
+	return n, nil
+}
+
+// unquoteFromLine removes ">" from ">From " of mboxo and ">>From " of mboxrd.
+func unquoteFromLine(line []byte, format MboxFormat) []byte {
+	if len(line) < 6 || line[0] != '>' { return line }
+	if format == MboxO { if bytes.HasPrefix(line, []byte(">From ")) { return line[1:] }; return line }
+
+	cv := bytes.TrimLeft(line, ">")
+	if bytes.HasPrefix(cv, []byte("From ")) { return line[1:] }
+	return line
+}
+
+// parseFromLine returns the envelope sender and the date of "From MAILER-DAEMON Thu Apr 29 23:34:45 2010".
+func parseFromLine(line string) (string, time.Time) {
+	fields := strings.Fields(line); if len(fields) < 2 { return "", time.Time{} }
+	if len(fields) < 3 { return fields[1], time.Time{} }
+
+	date, nyaan := rfc5322.ParseDate(strings.Join(fields[2:], " "))
+	if nyaan != nil && errors.Is(nyaan, rfc5322.ErrMissingTimeZone) == false { return fields[1], time.Time{} }
+	return fields[1], date
+}
+
+// contentLength returns the value of Content-Length in the header section, -1 when it is not valid.
+func contentLength(hbytes []byte) int64 {
+	header, _ := rfc5322.ReadHeader(bytes.NewReader(hbytes))
+	cv, nyaan := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	if nyaan != nil || cv < 0 { return -1 }
+	return cv
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package messageid
+
+//  _____         _      __                                        _     _ 
+// |_   _|__  ___| |_   / / __ ___   ___  ___ ___  __ _  __ _  ___(_) __| |
+//   | |/ _ \/ __| __| / / '_ ` _ \ / _ \/ __/ __|/ _` |/ _` |/ _ \ |/ _` |
+//   | |  __/\__ \ |_ / /| | | | | |  __/\__ \__ \ (_| | (_| |  __/ | (_| |
+//   |_|\___||___/\__/_/ |_| |_| |_|\___||___/___/\__,_|\__, |\___|_|\__,_|
+//                                                      |___/              
+import "strings"
+import "testing"
+
+func TestIsMessageID(t *testing.T) {
+	fn := "messageid.IsMessageID"
+	cx := 0
+	ae := []struct {argument string; expected bool}{
+		{"<1234.abcd@example.jp>", true},
+		{"1234.abcd@example.jp", true},
+		{"<1234.abcd@[192.0.2.1]>", true},
+		{"<CAF+neko=x_Y{z}@mail.gmail.com>", true},
+		{"<1234.abcd@example.jp", false},
+		{"<1234..abcd@example.jp>", false},
+		{"<.1234@example.jp>", false},
+		{"<1234@>", false},
+		{"<@example.jp>", false},
+		{"<1234 abcd@example.jp>", false},
+		{`<"1234"@example.jp>`, false},
+		{"<1234@[192.0.2.1\\]>", false},
+		{"neko", false},
+		{"", false},
+	}
+	for _, e := range ae {
+		cx++; if cv := IsMessageID(e.argument); cv != e.expected { t.Errorf("%s(%s) returns %t", fn, e.argument, cv) }
+	}
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestNormalize(t *testing.T) {
+	fn := "messageid.Normalize"
+	cx := 0
+	ae := []struct {argument string; expected string}{
+		{"<1234.abcd@example.jp>", "1234.abcd@example.jp"},
+		{"<1234.ABCD@Example.JP>", "1234.ABCD@example.jp"},
+		{"  <1234.abcd@example.jp> (Nyaan)", "1234.abcd@example.jp"},
+		{"<1234 . abcd @ example . jp>", "1234.abcd@example.jp"},
+		{"<1234.abcd(comment)@(nested (comment))example.jp>", "1234.abcd@example.jp"},
+		{`<"1234.abcd"@example.jp>`, "1234.abcd@example.jp"},
+		{`<"1234 abcd"@example.jp>`, `"1234 abcd"@example.jp`},
+		{`<"neko@nyaan"@example.jp>`, `"neko@nyaan"@example.jp`},
+		{"<1234@[192.0.2.1]>", "1234@[192.0.2.1]"},
+		{"1234.abcd@example.jp", "1234.abcd@example.jp"},
+		{"<1234.abcd>", ""},
+		{"<1234@>", ""},
+		{"<<1234@example.jp>>", ""},
+		{"", ""},
+	}
+	for _, e := range ae {
+		cx++; if cv := Normalize(e.argument); cv != e.expected { t.Errorf("%s(%s) returns %s, expected %s", fn, e.argument, cv, e.expected) }
+	}
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestDomain(t *testing.T) {
+	fn := "messageid.Domain"
+	cx := 0
+	ae := []struct {argument string; expected string}{
+		{"<1234.abcd@Example.JP>", "example.jp"},
+		{`<"neko@nyaan"@mx.example.org>`, "mx.example.org"},
+		{"<1234@[192.0.2.1]>", "[192.0.2.1]"},
+		{"<1234>", ""},
+		{"", ""},
+	}
+	for _, e := range ae {
+		cx++; if cv := Domain(e.argument); cv != e.expected { t.Errorf("%s(%s) returns %s, expected %s", fn, e.argument, cv, e.expected) }
+	}
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParse(t *testing.T) {
+	fn := "messageid.Parse"
+	cx := 0
+	ae := []struct {argument string; expected []string}{
+		{"<1@example.jp>", []string{"1@example.jp"}},
+		{"<1@example.jp> <2@example.jp>\r\n <3@example.jp>", []string{"1@example.jp", "2@example.jp", "3@example.jp"}},
+		{"<1@example.jp>,<2@Example.JP>", []string{"1@example.jp", "2@example.jp"}},
+		{"(<0@example.jp>) <1@example.jp> (Nyaan \\) <0@example.jp>)", []string{"1@example.jp"}},
+		{`Your message of "Thu, 29 Apr 2010 <x>" <1@example.jp>`, []string{"1@example.jp"}},
+		{"<1@example.jp> from neko@example.jp", []string{"1@example.jp"}},
+		{"<<1@example.jp>", []string{"1@example.jp"}},
+		{"<1 @ example.jp (comment)>", []string{"1@example.jp"}},
+		{"1@example.jp, 2@example.jp (Nyaan)", []string{"1@example.jp", "2@example.jp"}},
+		{"<neko>", []string{}},
+		{"Nyaan", []string{}},
+		{"", []string{}},
+	}
+	for _, e := range ae {
+		cv := Parse(e.argument)
+		cx++; if strings.Join(cv, " ") != strings.Join(e.expected, " ") { t.Errorf("%s(%s) returns %v, expected %v", fn, e.argument, cv, e.expected) }
+	}
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestGenerate(t *testing.T) {
+	fn := "messageid.Generate"
+	cx := 0
+	seen := map[string]bool{}
+
+	for j := 0; j < 1000; j++ {
+		cv := Generate("MX.Example.JP.")
+		cx++; if IsMessageID(cv) == false { t.Errorf("%s() returns invalid Message-ID %s", fn, cv) }
+		cx++; if Domain(cv) != "mx.example.jp" { t.Errorf("%s() returns %s", fn, cv) }
+		cx++; if seen[cv] { t.Errorf("%s() returns duplicated %s", fn, cv) }
+		seen[cv] = true
+	}
+	for _, e := range []string{"", "neko nyaan", "[192.0.2.1]"} {
+		cv := Generate(e)
+		cx++; if IsMessageID(cv) == false { t.Errorf("%s(%s) returns invalid Message-ID %s", fn, e, cv) }
+	}
+	cx++; if cv := Domain(Generate("[192.0.2.1]")); cv != "[192.0.2.1]" { t.Errorf("%s() returns %s", fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
@@ -0,0 +1,75 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package messageid
+
+//  _____         _      __                                        _     _ 
+// |_   _|__  ___| |_   / / __ ___   ___  ___ ___  __ _  __ _  ___(_) __| |
+//   | |/ _ \/ __| __| / / '_ ` _ \ / _ \/ __/ __|/ _` |/ _` |/ _ \ |/ _` |
+//   | |  __/\__ \ |_ / /| | | | | |  __/\__ \__ \ (_| | (_| |  __/ | (_| |
+//   |_|\___||___/\__/_/ |_| |_| |_|\___||___/___/\__,_|\__, |\___|_|\__,_|
+//                                                      |___/              
+import "strings"
+import "testing"
+
+// threadOf returns the thread as a string such as "a(b(c) d)", "-" is a missing message, "?" has no Message-ID
+func threadOf(nodes []*Node) string {
+	cv := []string{}
+	for _, e := range nodes {
+		ce := strings.Split(e.ID, "@")[0]; if e.Message == nil { ce = "-" } else if ce == "" { ce = "?" }
+		if len(e.Children) > 0 { ce += "(" + threadOf(e.Children) + ")" }
+		cv = append(cv, ce)
+	}
+	return strings.Join(cv, " ")
+}
+
+func TestThread(t *testing.T) {
+	fn := "messageid.Thread"
+	cx := 0
+	ae := []struct {testname string; messages []*Message; expected string}{
+		{"Simple", []*Message{
+			{MessageID: "<a@example.jp>"},
+			{MessageID: "<b@example.jp>", InReplyTo: "<a@example.jp>", References: "<a@example.jp>"},
+			{MessageID: "<c@example.jp>", References: "<a@example.jp> <b@example.jp>"},
+			{MessageID: "<d@example.jp>", InReplyTo: "<a@example.jp>"},
+			{MessageID: "<e@example.jp>"},
+		}, "a(b(c) d) e"},
+		{"Out of order", []*Message{
+			{MessageID: "<c@example.jp>", References: "<a@example.jp> <b@example.jp>"},
+			{MessageID: "<b@example.jp>", References: "<a@example.jp>"},
+			{MessageID: "<a@example.jp>"},
+		}, "a(b(c))"},
+		{"Missing root", []*Message{
+			{MessageID: "<b@example.jp>", References: "<a@example.jp>"},
+			{MessageID: "<c@example.jp>", References: "<a@example.jp>"},
+			{MessageID: "<x@example.jp>", References: "<w@example.jp>"},
+		}, "-(b c) x"},
+		{"Missing middle", []*Message{
+			{MessageID: "<a@example.jp>"},
+			{MessageID: "<c@example.jp>", References: "<a@example.jp> <b@example.jp>"},
+		}, "a(c)"},
+		{"Loop", []*Message{
+			{MessageID: "<a@example.jp>", References: "<b@example.jp>"},
+			{MessageID: "<b@example.jp>", References: "<a@example.jp>"},
+		}, "b(a)"},
+		{"Self reference", []*Message{
+			{MessageID: "<a@example.jp>", References: "<a@example.jp>"},
+		}, "a"},
+		{"Duplicated", []*Message{
+			{MessageID: "<a@example.jp>"},
+			{MessageID: "<a@example.jp>"},
+			{MessageID: ""},
+		}, "a ? ?"},
+		{"Empty", []*Message{nil}, ""},
+	}
+	for _, e := range ae {
+		cv := threadOf(Thread(e.messages))
+		cx++; if cv != e.expected { t.Errorf("[%s] %s() returns %s, expected %s", e.testname, fn, cv, e.expected) }
+	}
+
+	cv := Thread([]*Message{{MessageID: "<a@example.jp>"}, {MessageID: "<b@example.jp>", References: "<a@example.jp>"}})
+	cx++; if cv[0].Children[0].Parent != cv[0] { t.Errorf("%s().Children[0].Parent is %v", fn, cv[0].Children[0].Parent) }
+	cx++; if cv[0].Message.MessageID != "<a@example.jp>" { t.Errorf("%s().Message is %v", fn, cv[0].Message) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
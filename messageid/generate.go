@@ -0,0 +1,41 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                                           _     _    ______                           _         
+//  _ __ ___   ___  ___ ___  __ _  __ _  ___(_) __| |  / / ___| ___ _ __   ___ _ __ __ _| |_ ___ _ 
+// | '_ ` _ \ / _ \/ __/ __|/ _` |/ _` |/ _ \ |/ _` | / / |  _ / _ \ '_ \ / _ \ '__/ _` | __/ _ (_)
+// | | | | | |  __/\__ \__ \ (_| | (_| |  __/ | (_| |/ /| |_| |  __/ | | |  __/ | | (_| | ||  __/_ 
+// |_| |_| |_|\___||___/___/\__,_|\__, |\___|_|\__,_/_/  \____|\___|_| |_|\___|_|  \__,_|\__\___(_)
+//                                |___/                                                            
+
+package messageid
+import "os"
+import "time"
+import "strconv"
+import "strings"
+import "sync/atomic"
+import "crypto/rand"
+import "encoding/hex"
+
+var sequence atomic.Uint64
+
+// Generate returns a unique Message-ID to be used in the Message-ID header.
+//   Arguments:
+//     - host (string): The id-right such as "mx.example.jp", os.Hostname() is used when it is empty.
+//   Returns:
+//     - (string): Message-ID with angle brackets such as "<lx2n8c1w.1.3f9a1c0e5b7d2a64@mx.example.jp>".
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-3.6.4
+//     - https://datatracker.ietf.org/doc/html/draft-ietf-usefor-message-id-01
+func Generate(host string) string {
+	host = strings.ToLower(strings.Trim(strings.TrimSpace(host), "."))
+	if isDotAtomText(host) == false && isNoFoldLiteral(host) == false {
+		// Use the hostname of this machine when the host is empty or invalid
+		cv, nyaan := os.Hostname(); host = strings.ToLower(strings.Trim(cv, "."))
+		if nyaan != nil || isDotAtomText(host) == false { host = "localhost.localdomain" }
+	}
+
+	// The id-left consists of the current time, the sequence number in this process, and random octets
+	random := make([]byte, 8); rand.Read(random)
+	idleft := strconv.FormatInt(time.Now().UnixNano(), 36) + "." + strconv.FormatUint(sequence.Add(1), 36)
+	return "<" + idleft + "." + hex.EncodeToString(random) + "@" + host + ">"
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                                           _     _ 
+//  _ __ ___   ___  ___ ___  __ _  __ _  ___(_) __| |
+// | '_ ` _ \ / _ \/ __/ __|/ _` |/ _` |/ _ \ |/ _` |
+// | | | | | |  __/\__ \__ \ (_| | (_| |  __/ | (_| |
+// |_| |_| |_|\___||___/___/\__,_|\__, |\___|_|\__,_|
+//                                |___/              
+
+// Package "messageid" provides functions related to Message-ID, In-Reply-To and References headers
+// to correlate bounce messages with the original message. https://datatracker.ietf.org/doc/html/rfc5322#section-3.6.4
+package messageid
+import "strings"
+
+// IsMessageID checks that the argument is a msg-id defined in RFC5322 (not obsolete forms).
+//   Arguments:
+//     - id (string): Message-ID with or without angle brackets such as "<1234.abcd@example.jp>".
+//   Returns:
+//     - (bool): true if the argument is "id-left@id-right" consisting of dot-atom-text and no-fold-literal.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-3.6.4
+func IsMessageID(id string) bool {
+	//   msg-id          =   [CFWS] "<" id-left "@" id-right ">" [CFWS]
+	//   id-left         =   dot-atom-text / obs-id-left
+	//   id-right        =   dot-atom-text / no-fold-literal / obs-id-right
+	//   no-fold-literal =   "[" *dtext "]"
+	if strings.HasPrefix(id, "<") != strings.HasSuffix(id, ">") { return false }
+	id = strings.TrimSuffix(strings.TrimPrefix(id, "<"), ">")
+
+	p := strings.LastIndexByte(id, '@'); if p < 1 || p == len(id) - 1 { return false }
+	if isDotAtomText(id[:p]) == false { return false }
+	if isDotAtomText(id[p + 1:])      { return true  }
+	return isNoFoldLiteral(id[p + 1:])
+}
+
+// Normalize returns the Message-ID for comparison: CFWS and angle brackets are removed, the quoted
+// id-left is unquoted if possible, and the id-right is converted to lower case.
+//   Arguments:
+//     - id (string): Message-ID such as `<"1234.abcd" @ Example.JP (comment)>`.
+//   Returns:
+//     - (string): Normalized Message-ID such as "1234.abcd@example.jp", or "" when it is not a msg-id.
+func Normalize(id string) string {
+	cv := stripCFWS(id)
+	cv  = strings.TrimSuffix(strings.TrimPrefix(cv, "<"), ">")
+	if strings.ContainsAny(cv, "<>") { return "" }
+
+	p := lastAtSign(cv); if p < 1 || p == len(cv) - 1 { return "" }
+	idleft, idright := cv[:p], strings.ToLower(cv[p + 1:])
+
+	if len(idleft) > 1 && strings.HasPrefix(idleft, `"`) && strings.HasSuffix(idleft, `"`) {
+		// obs-id-left: the quoted local-part such as `"1234.abcd"@example.jp`
+		inner := strings.ReplaceAll(idleft[1:len(idleft) - 1], `\`, "")
+		if isDotAtomText(inner) { idleft = inner }
+	}
+	return idleft + "@" + idright
+}
+
+// Domain returns the id-right of the Message-ID in lower case.
+//   Arguments:
+//     - id (string): Message-ID such as "<1234.abcd@Example.JP>".
+//   Returns:
+//     - (string): The id-right such as "example.jp", or "" when it is not a msg-id.
+func Domain(id string) string {
+	cv := Normalize(id); if cv == "" { return "" }
+	return cv[lastAtSign(cv) + 1:]
+}
+
+// isAtext returns true if the character is atext defined in RFC5322 3.2.3
+func isAtext(c byte) bool {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' { return true }
+	return strings.IndexByte("!#$%&'*+-/=?^_`{|}~", c) > -1
+}
+
+// isDotAtomText returns true if the string is dot-atom-text defined in RFC5322 3.2.3
+func isDotAtomText(text string) bool {
+	if text == "" || strings.HasPrefix(text, ".") || strings.HasSuffix(text, ".") { return false }
+	if strings.Contains(text, "..") { return false }
+	for j := 0; j < len(text); j++ { if text[j] != '.' && isAtext(text[j]) == false { return false } }
+	return true
+}
+
+// isNoFoldLiteral returns true if the string is no-fold-literal such as "[192.0.2.1]"
+func isNoFoldLiteral(text string) bool {
+	if len(text) < 2 || text[0] != '[' || text[len(text) - 1] != ']' { return false }
+	for j := 1; j < len(text) - 1; j++ {
+		// dtext = %d33-90 / %d94-126
+		if text[j] < 33 || text[j] > 126 || text[j] == '[' || text[j] == ']' || text[j] == '\\' { return false }
+	}
+	return true
+}
+
+// stripCFWS removes comments and white spaces out of quoted strings and domain literals.
+func stripCFWS(text string) string {
+	cv := strings.Builder{}
+	depth, quoted, literal := 0, false, false
+
+	for j := 0; j < len(text); j++ {
+		c := text[j]
+		if c == '\\' && (quoted || depth > 0) {
+			// quoted-pair such as `\"`
+			if depth == 0 { cv.WriteByte(c); if j + 1 < len(text) { cv.WriteByte(text[j + 1]) } }
+			j++; continue
+		}
+		switch {
+			case depth > 0:
+				if c == '(' { depth++ } else if c == ')' { depth-- }
+				continue
+			case quoted:
+				if c == '"' { quoted = false }
+			case c == '(':
+				depth++; continue
+			case c == '"':
+				quoted = true
+			case c == '[':
+				literal = true
+			case c == ']':
+				literal = false
+			case literal == false && (c == ' ' || c == '\t' || c == '\r' || c == '\n'):
+				continue
+		}
+		cv.WriteByte(c)
+	}
+	return cv.String()
+}
+
+// lastAtSign returns the index of the last "@" out of quoted strings.
+func lastAtSign(text string) int {
+	quoted, p := false, -1
+	for j := 0; j < len(text); j++ {
+		if text[j] == '\\' && quoted { j++; continue }
+		if text[j] == '"' { quoted = !quoted; continue }
+		if text[j] == '@' && quoted == false { p = j }
+	}
+	return p
+}
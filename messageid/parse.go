@@ -0,0 +1,87 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                                           _     _    ______                       
+//  _ __ ___   ___  ___ ___  __ _  __ _  ___(_) __| |  / /  _ \ __ _ _ __ ___  ___ _ 
+// | '_ ` _ \ / _ \/ __/ __|/ _` |/ _` |/ _ \ |/ _` | / /| |_) / _` | '__/ __|/ _ (_)
+// | | | | | |  __/\__ \__ \ (_| | (_| |  __/ | (_| |/ / |  __/ (_| | |  \__ \  __/_ 
+// |_| |_| |_|\___||___/___/\__,_|\__, |\___|_|\__,_/_/  |_|   \__,_|_|  |___/\___(_)
+//                                |___/                                              
+
+package messageid
+import "strings"
+
+// Parse returns Message-IDs in the msg-id list of Message-ID, In-Reply-To and References headers.
+//   Arguments:
+//     - text (string): The value of the header such as "<1@example.jp> (comment) <2@example.jp>".
+//   Returns:
+//     - ([]string): Normalized Message-IDs such as []string{"1@example.jp", "2@example.jp"}.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-3.6.4
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-4.5.4
+func Parse(text string) []string {
+	//   in-reply-to     =   "In-Reply-To:" 1*msg-id CRLF
+	//   references      =   "References:" 1*msg-id CRLF
+	//   obs-in-reply-to =   "In-Reply-To:" *(phrase / msg-id) CRLF
+	//   obs-references  =   "References:" *(phrase / msg-id) CRLF
+	//   obs-id-left     =   local-part
+	//   obs-id-right    =   domain
+	idlist := []string{}
+	depth  := 0     // Depth of the nested comment
+	quoted := false // In a quoted string
+	begin  := -1    // The position of "<"
+
+	for j := 0; j < len(text); j++ {
+		// Pick each msg-id enclosed in "<" and ">" out of comments and quoted strings
+		c := text[j]
+		if c == '\\' && (quoted || depth > 0) { j++; continue }
+
+		switch {
+			case depth > 0:
+				if c == '(' { depth++ } else if c == ')' { depth-- }
+			case quoted:
+				if c == '"' { quoted = false }
+			case c == '(':
+				depth++
+			case c == '"':
+				quoted = true
+			case c == '<':
+				// "<<1@example.jp>" is dealt as "<1@example.jp>"
+				begin = j
+			case c == '>' && begin > -1:
+				if cv := Normalize(text[begin:j + 1]); cv != "" { idlist = append(idlist, cv) }
+				begin = -1
+		}
+	}
+	if len(idlist) > 0 { return idlist }
+
+	for _, e := range strings.FieldsFunc(stripComments(text), func(r rune) bool {
+		// Broken MUAs write Message-IDs without angle brackets: "1@example.jp, 2@example.jp"
+		return r == ' ' || r == '\t' || r == '\r' || r == '\n' || r == ','
+	}) {
+		if strings.Count(e, "@") != 1 { continue }
+		if cv := Normalize(e); cv != "" && IsMessageID(cv) { idlist = append(idlist, cv) }
+	}
+	return idlist
+}
+
+// stripComments removes comments out of quoted strings.
+func stripComments(text string) string {
+	cv := strings.Builder{}
+	depth, quoted := 0, false
+
+	for j := 0; j < len(text); j++ {
+		c := text[j]
+		if c == '\\' && depth > 0 { j++; continue }
+		switch {
+			case depth > 0:
+				if c == '(' { depth++ } else if c == ')' { depth-- }
+				continue
+			case c == '"':
+				quoted = !quoted
+			case c == '(' && quoted == false:
+				depth++; cv.WriteByte(' '); continue
+		}
+		cv.WriteByte(c)
+	}
+	return cv.String()
+}
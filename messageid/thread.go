@@ -0,0 +1,117 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                                           _     _    _______ _                        _   
+//  _ __ ___   ___  ___ ___  __ _  __ _  ___(_) __| |  / /_   _| |__  _ __ ___  __ _  __| |_ 
+// | '_ ` _ \ / _ \/ __/ __|/ _` |/ _` |/ _ \ |/ _` | / /  | | | '_ \| '__/ _ \/ _` |/ _` (_)
+// | | | | | |  __/\__ \__ \ (_| | (_| |  __/ | (_| |/ /   | | | | | | | |  __/ (_| | (_| |_ 
+// |_| |_| |_|\___||___/___/\__,_|\__, |\___|_|\__,_/_/    |_| |_| |_|_|  \___|\__,_|\__,_(_)
+//                                |___/                                                      
+
+package messageid
+import "slices"
+
+// Message is a set of headers used to reconstruct threads.
+type Message struct {
+	MessageID  string // The value of Message-ID header
+	InReplyTo  string // The value of In-Reply-To header
+	References string // The value of References header
+}
+
+// Node is a message or a missing message referred by other messages in a thread.
+type Node struct {
+	ID       string   // Normalized Message-ID
+	Message  *Message // nil when the message is referred but not given
+	Parent   *Node    // nil when the node is a root of the thread
+	Children []*Node  // Replies in order of appearance
+}
+
+// Thread reconstructs threads from References and In-Reply-To headers of the messages.
+//   Arguments:
+//     - messages ([]*Message): Messages to be threaded.
+//   Returns:
+//     - ([]*Node): Root nodes of each thread in order of appearance.
+//   See:
+//     - https://www.jwz.org/doc/threading.html
+//     - https://datatracker.ietf.org/doc/html/rfc5256#section-2.2
+func Thread(messages []*Message) []*Node {
+	nodes := map[string]*Node{}
+	order := []*Node{} // All the nodes in order of appearance
+	nodeOf := func(id string) *Node {
+		// Return the node of the Message-ID, create a new node when it does not exist
+		if cv, ok := nodes[id]; ok { return cv }
+		cv := &Node{ID: id}; nodes[id] = cv; order = append(order, cv)
+		return cv
+	}
+
+	for _, e := range messages {
+		// Link the messages in References in order, then link this message to the last one
+		if e == nil { continue }
+		var this *Node
+		if cv := Parse(e.MessageID); len(cv) > 0 && (nodes[cv[0]] == nil || nodes[cv[0]].Message == nil) {
+			this = nodeOf(cv[0])
+
+		} else {
+			// Message-ID is missing or duplicated
+			this = &Node{}; order = append(order, this)
+		}
+		this.Message = e
+
+		parents := Parse(e.References)
+		if cv := Parse(e.InReplyTo); len(cv) > 0 && slices.Contains(parents, cv[0]) == false {
+			// In-Reply-To is used when References is missing or does not include it
+			parents = append(parents, cv[0])
+		}
+
+		var upper *Node
+		for _, f := range parents {
+			// Link each reference as the child of the previous reference without making a loop
+			cv := nodeOf(f)
+			if upper != nil && cv.Parent == nil && cv != upper && isAncestor(cv, upper) == false { linkNode(upper, cv) }
+			upper = cv
+		}
+
+		if upper == nil || upper == this || isAncestor(this, upper) { continue }
+		if this.Parent != nil {
+			// The references of this message are more reliable than the references of other messages
+			this.Parent.Children = slices.DeleteFunc(this.Parent.Children, func(n *Node) bool { return n == this })
+			this.Parent = nil
+		}
+		linkNode(upper, this)
+	}
+
+	roots := []*Node{}
+	for _, e := range order {
+		// Pick root nodes, a missing root message which has only one reply is replaced with the reply,
+		// missing messages in the thread are replaced with their replies
+		if e.Parent != nil { continue }
+		pruneNode(e)
+		if e.Message == nil {
+			if len(e.Children) == 0 { continue }
+			if len(e.Children) == 1 { e = e.Children[0]; e.Parent = nil }
+		}
+		roots = append(roots, e)
+	}
+	return roots
+}
+
+// pruneNode replaces each missing message under the node with its replies.
+func pruneNode(node *Node) {
+	children := []*Node{}
+	for _, e := range node.Children {
+		pruneNode(e)
+		if e.Message != nil { children = append(children, e); continue }
+		for _, f := range e.Children { f.Parent = node; children = append(children, f) }
+	}
+	node.Children = children
+}
+
+// linkNode appends the child to the children of the parent.
+func linkNode(parent, child *Node) {
+	child.Parent = parent; parent.Children = append(parent.Children, child)
+}
+
+// isAncestor returns true if the node is the ancestor of the other node or the same node.
+func isAncestor(node, other *Node) bool {
+	for cv := other; cv != nil; cv = cv.Parent { if cv == node { return true } }
+	return false
+}
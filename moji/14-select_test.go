@@ -31,6 +31,8 @@ func TestSelect(t *testing.T) {
 		{"n", "e", "", -1, "" },
 		{"n", "e", "k", -1, "" },
 		{"n", "e", "k",  0, "" },
+		{"unsub@b+", "+", "@", 0, ""},
+		{"neko@e+", "+", "@", 0, ""},
 	}
 
 	for _, e := range ae {
@@ -122,6 +122,7 @@ func Select(whole, begin, until string, start int) string {
 
 	ci    := [3]int{0, -1, -1}
 	ci[1]  = strings.Index(cv, begin);                     if ci[1] < 0 { return "" }
+	if ci[1] + cw[1] + 1 > cw[0] { return "" } // Nothing after the begin string like "neko@example.jp+"
 	ci[2]  = strings.Index(cv[ci[1] + cw[1] + 1:], until); if ci[2] < 0 { return "" }
 	ci[2] += ci[1] + cw[1] + 1
 	return cv[ci[1] + cw[1]:ci[2]]
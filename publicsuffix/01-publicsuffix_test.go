@@ -0,0 +1,101 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package publicsuffix
+
+//  _____         _      __           _     _ _                 __  __ _      
+// |_   _|__  ___| |_   / / __  _   _| |__ | (_) ___ ___ _   _ / _|/ _(_)_  __
+//   | |/ _ \/ __| __| / / '_ \| | | | '_ \| | |/ __/ __| | | | |_| |_| \ \/ /
+//   | |  __/\__ \ |_ / /| |_) | |_| | |_) | | | (__\__ \ |_| |  _|  _| |>  < 
+//   |_|\___||___/\__/_/ | .__/ \__,_|_.__/|_|_|\___|___/\__,_|_| |_| |_/_/\_\
+//                       |_|                                                  
+import "testing"
+
+func TestPublicSuffix(t *testing.T) {
+	fn := "publicsuffix.PublicSuffix"
+	cx := 0
+	ae := []struct {host string; expected string}{
+		{"mx1.mail.example.co.jp", "co.jp"},
+		{"example.co.jp", "co.jp"},
+		{"co.jp", "co.jp"},
+		{"MX.EXAMPLE.JP.", "jp"},
+		{"www.example.com", "com"},
+		{"neko.example.nyaan", "nyaan"},
+		{"www.example.kawasaki.jp", "example.kawasaki.jp"},
+		{"city.kawasaki.jp", "kawasaki.jp"},
+		{"www.city.kawasaki.jp", "kawasaki.jp"},
+		{"neko.github.io", "github.io"},
+		{"www.ck", "ck"},
+		{"neko.www.ck", "ck"},
+		{"neko.example.ck", "example.ck"},
+		{"localhost", "localhost"},
+		{"192.0.2.25", ""},
+		{"[IPv4:192.0.2.25]", ""},
+		{"mx..example.jp", ""},
+		{"", ""},
+	}
+
+	for _, e := range ae {
+		cx++; if cv := PublicSuffix(e.host); cv != e.expected { t.Errorf("%s(%s) returns %s", fn, e.host, cv) }
+	}
+	cx++; if Default().Version == ""                    { t.Errorf("%s: the embedded list has no version", fn) }
+	cx++; if Default().IsPublicSuffix("co.jp") == false { t.Errorf("%s: co.jp is not a public suffix", fn)     }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestRegistrableDomain(t *testing.T) {
+	fn := "publicsuffix.RegistrableDomain"
+	cx := 0
+	ae := []struct {host string; expected string}{
+		{"mx1.mail.example.co.jp", "example.co.jp"},
+		{"example.co.jp", "example.co.jp"},
+		{"co.jp", ""},
+		{"jp", ""},
+		{"MX.EXAMPLE.JP.", "example.jp"},
+		{"a.b.c.example.com", "example.com"},
+		{"neko.example.nyaan", "example.nyaan"},
+		{"www.example.kawasaki.jp", "www.example.kawasaki.jp"},
+		{"example.kawasaki.jp", ""},
+		{"city.kawasaki.jp", "city.kawasaki.jp"},
+		{"www.city.kawasaki.jp", "city.kawasaki.jp"},
+		{"neko.github.io", "neko.github.io"},
+		{"www.ck", "www.ck"},
+		{"localhost", ""},
+		{"192.0.2.25", ""},
+		{"", ""},
+	}
+
+	for _, e := range ae {
+		cx++; if cv := RegistrableDomain(e.host); cv != e.expected { t.Errorf("%s(%s) returns %s", fn, e.host, cv) }
+	}
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParse(t *testing.T) {
+	fn := "publicsuffix.Parse"
+	cx := 0
+	cv := Parse([]byte(`// VERSION: neko
+// ===BEGIN ICANN DOMAINS===
+jp
+*.nyaan.jp
+!www.nyaan.jp
+// ===END ICANN DOMAINS===
+// ===BEGIN PRIVATE DOMAINS===
+neko.jp  // trailing comment
+// ===END PRIVATE DOMAINS===
+`))
+	cx++; if cv.Version != "neko"                   { t.Errorf("%s: Version is %s", fn, cv.Version)           }
+	cx++; if len(cv.Rules) != 4                     { t.Errorf("%s: %d rules", fn, len(cv.Rules))             }
+	cx++; if cv.Rules["nyaan.jp"] != IsWildcardRule { t.Errorf("%s: nyaan.jp is not a wildcard rule", fn)     }
+	cx++; if cv.Rules["www.nyaan.jp"] != IsExceptionRule { t.Errorf("%s: www.nyaan.jp is not an exception", fn) }
+	cx++; if cv.Rules["neko.jp"] & IsPrivateDomain == 0  { t.Errorf("%s: neko.jp is not private", fn)           }
+
+	cx++; if cw := cv.RegistrableDomain("a.b.nyaan.jp");   cw != "a.b.nyaan.jp" { t.Errorf("%s: wildcard returns %s", fn, cw)  }
+	cx++; if cw := cv.RegistrableDomain("a.www.nyaan.jp"); cw != "www.nyaan.jp" { t.Errorf("%s: exception returns %s", fn, cw) }
+	cx++; if cw := cv.RegistrableDomain("a.neko.jp");      cw != "a.neko.jp"    { t.Errorf("%s: private returns %s", fn, cw)   }
+
+	_, nyaan := ReadFile("/nonexistent/public_suffix_list.dat")
+	cx++; if nyaan == nil { t.Errorf("ReadFile() does not return an error") }
+
+	t.Logf("The number of tests = %d", cx)
+}
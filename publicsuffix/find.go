@@ -0,0 +1,84 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//              _     _ _                 __  __ _      
+//  _ __  _   _| |__ | (_) ___ ___ _   _ / _|/ _(_)_  __
+// | '_ \| | | | '_ \| | |/ __/ __| | | | |_| |_| \ \/ /
+// | |_) | |_| | |_) | | | (__\__ \ |_| |  _|  _| |>  < 
+// | .__/ \__,_|_.__/|_|_|\___|___/\__,_|_| |_| |_/_/\_\
+// |_|                                                  
+
+package publicsuffix
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc791"
+
+// PublicSuffix returns the public suffix of the hostname.
+//   Arguments:
+//     - host (string): Hostname such as "mx1.mail.example.co.jp".
+//   Returns:
+//     - (string): Public suffix such as "co.jp", empty when the hostname is not a domain name.
+//   See:
+//     - https://github.com/publicsuffix/list/wiki/Format#algorithm
+func (this *List) PublicSuffix(host string) string {
+	labels := tidy(host); if len(labels) == 0 { return "" }
+	return strings.Join(labels[len(labels) - this.suffixLength(labels):], ".")
+}
+
+// RegistrableDomain returns the registrable domain (eTLD+1) of the hostname.
+//   Arguments:
+//     - host (string): Hostname such as "mx1.mail.example.co.jp".
+//   Returns:
+//     - (string): Registrable domain such as "example.co.jp", empty when the hostname is a public
+//                 suffix itself such as "co.jp" or is not a domain name.
+func (this *List) RegistrableDomain(host string) string {
+	labels := tidy(host); if len(labels) == 0 { return "" }
+	suffix := this.suffixLength(labels); if suffix >= len(labels) { return "" }
+	return strings.Join(labels[len(labels) - suffix - 1:], ".")
+}
+
+// IsPublicSuffix returns true if the hostname is a public suffix such as "co.jp".
+//   Arguments:
+//     - host (string): Hostname.
+//   Returns:
+//     - (bool): true if the hostname is a public suffix.
+func (this *List) IsPublicSuffix(host string) bool {
+	labels := tidy(host); if len(labels) == 0 { return false }
+	return this.suffixLength(labels) == len(labels)
+}
+
+// suffixLength returns the number of labels of the public suffix matched with the prevailing rule.
+//   Arguments:
+//     - labels ([]string): Labels of the hostname such as ["mx1", "example", "co", "jp"].
+//   Returns:
+//     - (int): The number of labels of the public suffix such as 2 for "co.jp".
+func (this *List) suffixLength(labels []string) int {
+	if this == nil || len(this.Rules) == 0 { return 1 } // The default rule is "*"
+
+	for j := range labels {
+		// Try the longest suffix first, the longest matching rule is the prevailing rule. An excep-
+		// tion rule is always longer than the wildcard rule it belongs to.
+		cv := strings.Join(labels[j:], ".")
+		cw := this.Rules[cv]
+		if cw & IsExceptionRule > 0 { return len(labels) - j - 1 } // !city.kawasaki.jp
+		if cw & IsNormalRule    > 0 { return len(labels) - j     } // co.jp
+
+		if j + 1 == len(labels) { break }
+		if this.Rules[strings.Join(labels[j + 1:], ".")] & IsWildcardRule > 0 { return len(labels) - j }
+	}
+	return 1
+}
+
+// tidy splits the hostname into labels.
+//   Arguments:
+//     - host (string): Hostname such as "MX1.Example.JP.".
+//   Returns:
+//     - ([]string): Lower-cased labels such as ["mx1", "example", "jp"], nil if the hostname is an
+//                   IP address or includes an empty label.
+func tidy(host string) []string {
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "."))
+	if host == "" || strings.HasPrefix(host, "[") || rfc791.IsIPv4Address(host) { return nil }
+	if strings.IndexByte(host, ':') > -1 || strings.IndexByte(host, ' ') > -1   { return nil }
+
+	labels := strings.Split(host, ".")
+	for _, e := range labels { if e == "" { return nil } }
+	return labels
+}
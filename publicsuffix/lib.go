@@ -0,0 +1,92 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//              _     _ _                 __  __ _      
+//  _ __  _   _| |__ | (_) ___ ___ _   _ / _|/ _(_)_  __
+// | '_ \| | | | '_ \| | |/ __/ __| | | | |_| |_| \ \/ /
+// | |_) | |_| | |_) | | | (__\__ \ |_| |  _|  _| |>  < 
+// | .__/ \__,_|_.__/|_|_|\___|___/\__,_|_| |_| |_/_/\_\
+// |_|                                                  
+
+// Package "publicsuffix" provides functions related to the Public Suffix List to find the public
+// suffix and the registrable domain (eTLD+1) of a hostname. https://publicsuffix.org/
+package publicsuffix
+import "os"
+import "sync"
+import _ "embed"
+
+//go:embed public_suffix_list.dat
+var embeddedList []byte
+
+const (
+	IsNormalRule    = 1 << iota // example.jp
+	IsWildcardRule              // *.kawasaki.jp
+	IsExceptionRule             // !city.kawasaki.jp
+	IsPrivateDomain             // The rule is in "===BEGIN PRIVATE DOMAINS===" section
+)
+
+// List is a set of rules loaded from the Public Suffix List.
+type List struct {
+	Version string           // The value of "// VERSION: " line in the list
+	Rules   map[string]uint8 // Rules keyed by the suffix without "*." and "!" such as "kawasaki.jp"
+}
+
+var defaultList *List
+var defaultOnce sync.Once
+var defaultLock sync.RWMutex
+
+// Default returns the list loaded from the embedded copy or the list replaced by LoadFile().
+//   Returns:
+//     - (*List): The Public Suffix List used by PublicSuffix() and RegistrableDomain().
+func Default() *List {
+	defaultOnce.Do(func() {
+		// Parse the embedded copy of public_suffix_list.dat only once
+		defaultLock.Lock(); defer defaultLock.Unlock()
+		if defaultList == nil { defaultList = Parse(embeddedList) }
+	})
+	defaultLock.RLock(); defer defaultLock.RUnlock()
+	return defaultList
+}
+
+// LoadFile replaces the default list with the list read from the given file.
+//   Arguments:
+//     - path (string): Path to the newer public_suffix_list.dat.
+//   Returns:
+//     - (error): An error occurred while reading the file or the file has no rule.
+func LoadFile(path string) error {
+	list, nyaan := ReadFile(path); if nyaan != nil { return nyaan }
+
+	defaultOnce.Do(func() {}) // Do not overwrite the list loaded from the file with the embedded copy
+	defaultLock.Lock(); defer defaultLock.Unlock()
+	defaultList = list
+	return nil
+}
+
+// ReadFile reads the Public Suffix List from the given file.
+//   Arguments:
+//     - path (string): Path to public_suffix_list.dat.
+//   Returns:
+//     - (*List):  The Public Suffix List.
+//     - (error):  An error occurred while reading the file or the file has no rule.
+func ReadFile(path string) (*List, error) {
+	data, nyaan := os.ReadFile(path); if nyaan != nil { return nil, nyaan }
+	list := Parse(data); if len(list.Rules) == 0 { return nil, ErrNoRules }
+	return list, nil
+}
+
+// PublicSuffix returns the public suffix of the hostname using the default list.
+//   Arguments:
+//     - host (string): Hostname such as "mx1.mail.example.co.jp".
+//   Returns:
+//     - (string): Public suffix such as "co.jp".
+func PublicSuffix(host string) string {
+	return Default().PublicSuffix(host)
+}
+
+// RegistrableDomain returns the registrable domain (eTLD+1) of the hostname using the default list.
+//   Arguments:
+//     - host (string): Hostname such as "mx1.mail.example.co.jp".
+//   Returns:
+//     - (string): Registrable domain such as "example.co.jp".
+func RegistrableDomain(host string) string {
+	return Default().RegistrableDomain(host)
+}
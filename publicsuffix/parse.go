@@ -0,0 +1,58 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//              _     _ _                 __  __ _      
+//  _ __  _   _| |__ | (_) ___ ___ _   _ / _|/ _(_)_  __
+// | '_ \| | | | '_ \| | |/ __/ __| | | | |_| |_| \ \/ /
+// | |_) | |_| | |_) | | | (__\__ \ |_| |  _|  _| |>  < 
+// | .__/ \__,_|_.__/|_|_|\___|___/\__,_|_| |_| |_/_/\_\
+// |_|                                                  
+
+package publicsuffix
+import "bufio"
+import "bytes"
+import "errors"
+import "strings"
+
+var ErrNoRules = errors.New("publicsuffix: the list has no rule")
+
+// Parse reads rules from the text formatted as public_suffix_list.dat.
+//   Arguments:
+//     - data ([]byte): Content of public_suffix_list.dat.
+//   Returns:
+//     - (*List): The Public Suffix List, the Rules is empty when the data has no valid rule.
+//   See:
+//     - https://github.com/publicsuffix/list/wiki/Format
+func Parse(data []byte) *List {
+	list := &List{Rules: make(map[string]uint8, 10000)}
+	scan := bufio.NewScanner(bytes.NewReader(data))
+	priv := uint8(0)
+
+	for scan.Scan() {
+		// Each line is only read up to the first whitespace; entire lines can also be commented
+		// using "//". Each line which is not entirely whitespace or begins with a comment contains
+		// a rule.
+		line := strings.TrimSpace(scan.Text()); if line == "" { continue }
+		if strings.HasPrefix(line, "//") {
+			// Comment lines including the section markers and the version string
+			if strings.HasPrefix(line, "// VERSION: ")               { list.Version = line[12:]; continue }
+			if strings.Contains(line, "===BEGIN PRIVATE DOMAINS===") { priv = IsPrivateDomain; continue   }
+			if strings.Contains(line, "===END PRIVATE DOMAINS===")   { priv = 0                            }
+			continue
+		}
+		if p := strings.IndexAny(line, " \t"); p > 0 { line = line[:p] }
+		line  = strings.ToLower(strings.TrimSuffix(line, "."))
+		kind := uint8(IsNormalRule)
+
+		if strings.HasPrefix(line, "!") {
+			// An exception rule such as "!city.kawasaki.jp"
+			line = line[1:]; kind = IsExceptionRule
+
+		} else if strings.HasPrefix(line, "*.") {
+			// A wildcard rule such as "*.kawasaki.jp", keep the rule as "kawasaki.jp"
+			line = line[2:]; kind = IsWildcardRule
+		}
+		if line == "" || strings.Contains(line, "..") || strings.Contains(line, "*") { continue }
+		list.Rules[line] |= kind | priv
+	}
+	return list
+}
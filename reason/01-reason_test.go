@@ -0,0 +1,231 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package reason
+
+//  _____         _      __                              
+// |_   _|__  ___| |_   / / __ ___  __ _ ___  ___  _ __  
+//   | |/ _ \/ __| __| / / '__/ _ \/ _` / __|/ _ \| '_ \ 
+//   | |  __/\__ \ |_ / /| | |  __/ (_| \__ \ (_) | | | |
+//   |_|\___||___/\__/_/ |_|  \___|\__,_|___/\___/|_| |_|
+import "strings"
+import "testing"
+import "libsisimai.org/mailer-goemon/rfc3464"
+import "libsisimai.org/mailer-goemon/rfc5965"
+
+func TestClassify(t *testing.T) {
+	fn := "reason.Classify"
+	cx := 0
+	ae := []struct {testname string; argument Input; expected string; rulename string}{
+		{"Postfix user unknown", Input{Text: "550 5.1.1 <neko@example.jp>: Recipient address rejected: User unknown in local recipient table (in reply to RCPT TO command)"}, UserUnknown, "status:userunknown"},
+		{"Gmail user unknown",   Input{Text: "550-5.1.1 The email account that you tried to reach does not exist. Please try double-checking the recipient's email address"}, UserUnknown, "status:userunknown"},
+		{"User unknown",         Input{Text: "550 <neko@example.jp>... User unknown"}, UserUnknown, "text:userunknown"},
+		{"Status only 5.1.1",    Input{Status: "5.1.1"}, UserUnknown, "status:userunknown"},
+		{"Mailbox full",         Input{Text: "452 4.2.2 The email account that you tried to reach is over quota"}, MailboxFull, "status:mailboxfull"},
+		{"Over quota",           Input{Text: "452 The email account that you tried to reach is over quota"}, MailboxFull, "text:mailboxfull"},
+		{"Status 5.2.2",         Input{Text: "550 5.2.2 Nyaan"}, MailboxFull, "status:mailboxfull"},
+		{"Host unknown",         Input{Text: "Host or domain name not found. Name service error for name=example.jp type=A: Host not found"}, HostUnknown, "text:hostunknown"},
+		{"Spamhaus",             Input{Text: "554 5.7.1 Service unavailable; Client host [192.0.2.1] blocked using zen.spamhaus.org"}, Blocked, "text:blocked"},
+		{"RBL",                  Input{Text: "554 Your IP address 192.0.2.1 is listed in the RBL, see b.barracudacentral.org"}, Blocked, "text:blocked"},
+		{"Not listed directory", Input{Text: "550 Recipient is not listed in our directory"}, UserUnknown, "text:userunknown"},
+		{"Not listed 5.1.0",     Input{Text: "550 5.1.0 <neko@example.jp>: user is not listed in the address book"}, UserUnknown, "text:userunknown"},
+		{"Spam",                 Input{Text: "550 5.7.1 Message rejected as spam by Content Filtering"}, SpamDetected, "text:spamdetected"},
+		{"Gmail DMARC",          Input{Text: "550-5.7.26 Unauthenticated email from example.jp is not accepted due to domain's DMARC policy"}, AuthFailure, "status:authfailure"},
+		{"DMARC policy 5.7.1",   Input{Text: "550 5.7.1 Unauthenticated email from example.jp is not accepted due to domain's DMARC policy"}, AuthFailure, "text:authfailure"},
+		{"Status 5.7.26",        Input{Status: "5.7.26", Reply: "550"}, AuthFailure, "status:authfailure"},
+		{"Policy 5.7.1",         Input{Text: "550 5.7.1 Delivery not authorized, message refused"}, PolicyViolation, "status:policyviolation"},
+		{"Relay",                Input{Text: "554 5.7.1 <neko@example.org>: Relay access denied"}, NoRelaying, "text:norelaying"},
+		{"Too big",              Input{Text: "552 5.3.4 Message size exceeds fixed maximum message size"}, MesgTooBig, "status:mesgtoobig"},
+		{"Reply 552 only",       Input{Text: "552 Nyaan"}, MesgTooBig, "reply:mesgtoobig"},
+		{"Rate limited",         Input{Text: "421 4.7.0 Too many connections from your IP, try again later"}, RateLimited, "text:ratelimited"},
+		{"Expired",              Input{Text: "Delivery time expired"}, Expired, "text:expired"},
+		{"Status 4.4.7",         Input{Status: "4.4.7"}, Expired, "status:expired"},
+		{"Network",              Input{Text: "connect to mx.example.jp[192.0.2.25]:25: Connection refused"}, NetworkError, "text:networkerror"},
+		{"System error",         Input{Text: "451 4.3.0 Internal error"}, SystemError, "text:systemerror"},
+		{"Suspend",              Input{Text: "550 5.2.1 The email account that you tried to reach is disabled. account is disabled"}, Suspend, "text:suspend"},
+		{"Filtered 5.2.1",       Input{Text: "550 5.2.1 Nyaan"}, Filtered, "status:filtered"},
+		{"Rejected sender",      Input{Text: "553 5.1.8 <kijitora@example.org>: Sender address rejected: Domain not found"}, Rejected, "status:rejected"},
+		{"Sender rejected",      Input{Text: "554 <kijitora@example.org>: Sender address rejected: Domain not found"}, Rejected, "text:rejected"},
+		{"MAIL command",         Input{Text: "550 Nyaan (in reply to MAIL FROM command)"}, Rejected, "command:rejected"},
+		{"PTR",                  Input{Text: "550 5.7.25 Client host rejected: cannot find your reverse hostname, [192.0.2.1]"}, RequirePTR, "status:requireptr"},
+		{"PTR 5.7.1",            Input{Text: "550 5.7.1 Client host rejected: cannot find your reverse hostname, [192.0.2.1]"}, RequirePTR, "text:requireptr"},
+		{"Null MX",              Input{Reply: "556", Text: "556 Nyaan"}, NotAccept, "reply:notaccept"},
+		{"Virus",                Input{Text: "550 5.7.0 Message rejected: Virus found (Eicar-Test-Signature)"}, VirusDetected, "text:virusdetected"},
+		{"Delivered",            Input{Status: "2.0.0", Text: "250 2.0.0 Ok: queued as 4TZ8p03CHfz1xyZ"}, Delivered, "status:delivered"},
+		{"5.1.1 and your IP",    Input{Text: "550 5.1.1 Mailbox does not exist. Your IP has been logged"}, UserUnknown, "status:userunknown"},
+		{"5.1.1 and infected",   Input{Text: "550 5.1.1 user unknown; this address was infected by forwarding"}, UserUnknown, "status:userunknown"},
+		{"5.1.1 and policy",     Input{Text: "550 5.1.1 Recipient not allowed by our policy: no such user"}, UserUnknown, "status:userunknown"},
+		{"5.2.2 and spam",       Input{Text: "552 5.2.2 Mailbox full, including the spam folder"}, MailboxFull, "status:mailboxfull"},
+		{"5.1.2 and rDNS",       Input{Text: "550 5.1.2 Bad destination system address; rDNS and PTR record of example.jp, dkim=none"}, HostUnknown, "status:hostunknown"},
+		{"Status and text",      Input{Status: "5.1.1", Text: "Message rejected as spam"}, UserUnknown, "status:userunknown"},
+		{"Spam 5.7.1",           Input{Status: "5.7.1", Text: "Message rejected as spam"}, SpamDetected, "text:spamdetected"},
+		{"Broad words",          Input{Text: "Nyaan: dkim=pass, ptr record ok, rdns ok, not allowed, see our policy, no spam, not infected"}, Undefined, ""},
+		{"On hold",              Input{Text: "550 5.0.0 Nyaan"}, OnHold, ""},
+		{"Undefined",            Input{Text: "Nyaan"}, Undefined, ""},
+	}
+	for _, e := range ae {
+		t.Run(e.testname, func(t *testing.T) {
+			cv := Classify(e.argument)
+			cx++; if cv.Reason != e.expected { t.Errorf("%s(%v) returns %s by %v", fn, e.argument, cv.Reason, cv.Rule) }
+			if e.rulename == "" {
+				cx++; if cv.Rule != nil { t.Errorf("%s(%v).Rule is %v", fn, e.argument, cv.Rule) }
+				return
+			}
+			cx++; if cv.Rule == nil || cv.Rule.Name != e.rulename { t.Errorf("%s(%v).Rule is %v", fn, e.argument, cv.Rule) }
+		})
+	}
+
+	cv := Classify(Input{Text: "550 5.1.1 <neko@example.jp>... User unknown (in reply to RCPT TO command)"})
+	cx++; if cv.Input.Status != "5.1.1" { t.Errorf("%s().Input.Status is %s", fn, cv.Input.Status) }
+	cx++; if cv.Input.Reply != "550" { t.Errorf("%s().Input.Reply is %s", fn, cv.Input.Reply) }
+	cx++; if cv.Input.Command != "RCPT" { t.Errorf("%s().Input.Command is %s", fn, cv.Input.Command) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestClassifier(t *testing.T) {
+	fn := "reason.Classifier.Classify"
+	cx := 0
+
+	rules := Rules()
+	cx++; if len(rules) == 0 || rules[0].Name != "status:delivered" { t.Fatalf("Rules() returns %v", rules) }
+	for _, e := range rules {
+		cx++; if e.Reason == "" || strings.Contains(e.Name, ":") == false { t.Errorf("Rules() returns %v", e) }
+		for _, f := range e.Phrases { cx++; if f != strings.ToLower(f) { t.Errorf("Rules(): %s is not lower-cased", f) } }
+	}
+
+	// Modifying the copy does not change the default rules
+	rules[1].Reason = Vacation
+	cx++; if Rules()[1].Reason == Vacation { t.Errorf("Rules() returns the default rules") }
+
+	// Prefer 5.7.x policy codes of our own MTA to phrases
+	custom := append([]Rule{{Name: "status:nekomta", Reason: PolicyViolation, Status: []string{"5.7.*"}, Reply: []string{"554"}}}, Rules()...)
+	cv := NewClassifier(custom).Classify(Input{Text: "554 5.7.9 Nyaan: message looks like spam"})
+	cx++; if cv.Reason != PolicyViolation || cv.Rule.Name != "status:nekomta" { t.Errorf("%s() returns %s by %v", fn, cv.Reason, cv.Rule) }
+	cv = NewClassifier(custom).Classify(Input{Text: "550 5.7.9 Nyaan: message looks like spam"})
+	cx++; if cv.Reason != SpamDetected { t.Errorf("%s() returns %s by %v", fn, cv.Reason, cv.Rule) }
+
+	cv = NewClassifier([]Rule{{Name: "empty", Reason: Vacation}}).Classify(Input{Text: "Nyaan"})
+	cx++; if cv.Reason != Undefined { t.Errorf("%s() returns %s with an empty rule", fn, cv.Reason) }
+	cv = NewClassifier(nil).Classify(Input{Status: "5.1.1"})
+	cx++; if cv.Reason != UserUnknown { t.Errorf("%s() returns %s", fn, cv.Reason) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestFromRecipient(t *testing.T) {
+	fn := "reason.FromRecipient"
+	cx := 0
+
+	cv, _ := rfc3464.Parse(strings.NewReader("Reporting-MTA: dns; mx.example.jp\r\n\r\n" +
+		"Final-Recipient: rfc822; neko@example.org\r\nAction: failed\r\nStatus: 5.0.0\r\n" +
+		"Diagnostic-Code: smtp; 550 5.2.2 <neko@example.org>: Mailbox is full (in reply to RCPT TO command)\r\n"))
+	ce := FromRecipient(cv.Recipients[0])
+	cx++; if ce.Status != "5.2.2" { t.Errorf("%s().Status is %s", fn, ce.Status) }
+	cx++; if ce.Reply != "550" || ce.Command != "RCPT" { t.Errorf("%s() returns %v", fn, ce) }
+	cx++; if Classify(ce).Reason != MailboxFull { t.Errorf("Classify(%s()) returns %s", fn, Classify(ce).Reason) }
+
+	cv, _ = rfc3464.Parse(strings.NewReader("Reporting-MTA: dns; mx.example.jp\r\n\r\n" +
+		"Final-Recipient: rfc822; neko@example.org\r\nAction: failed\r\nStatus: 5.1.1\r\n"))
+	ce = FromRecipient(cv.Recipients[0])
+	cx++; if ce.Status != "5.1.1" || ce.Text != "" { t.Errorf("%s() returns %v", fn, ce) }
+	cx++; if Classify(ce).Reason != UserUnknown { t.Errorf("Classify(%s()) returns %s", fn, Classify(ce).Reason) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestClassifyFeedback(t *testing.T) {
+	fn := "reason.ClassifyFeedback"
+	cx := 0
+	ae := []struct {feedbacktype string; expected string; rulename string}{
+		{"abuse", Feedback, "feedback:abuse"},
+		{"fraud", Feedback, "feedback:fraud"},
+		{"virus", Feedback, "feedback:virus"},
+		{"not-spam", Undefined, ""},
+		{"auth-failure", Undefined, ""},
+	}
+	for _, e := range ae {
+		cf, nyaan := rfc5965.Parse(strings.NewReader("Feedback-Type: " + e.feedbacktype + "\r\nUser-Agent: SomeGenerator/1.0\r\n" +
+			"Version: 1\r\nOriginal-Rcpt-To: <neko@example.jp>\r\n"))
+		cx++; if nyaan != nil { t.Fatalf("rfc5965.Parse() returns an error: %s", nyaan) }
+
+		cv := ClassifyFeedback(cf)
+		cx++; if cv.Reason != e.expected { t.Errorf("%s(%s) returns %s", fn, e.feedbacktype, cv.Reason) }
+		if e.rulename == "" {
+			cx++; if cv.Rule != nil { t.Errorf("%s(%s).Rule is %v", fn, e.feedbacktype, cv.Rule) }
+			continue
+		}
+		cx++; if cv.Rule == nil || cv.Rule.Name != e.rulename { t.Errorf("%s(%s).Rule is %v", fn, e.feedbacktype, cv.Rule) }
+	}
+	cx++; if cv := ClassifyFeedback(nil); cv.Reason != Undefined { t.Errorf("%s(nil) returns %s", fn, cv.Reason) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestFromFeedback(t *testing.T) {
+	fn := "reason.FromFeedback"
+	cx := 0
+	arf := "From: <abusedesk@example.com>\r\nTo: <abuse@example.jp>\r\nSubject: Abuse report\r\nMIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/report; report-type=feedback-report; boundary=\"arf\"\r\n\r\n" +
+		"--arf\r\nContent-Type: text/plain\r\n\r\nThis is an email abuse report\r\n\r\n" +
+		"--arf\r\nContent-Type: message/feedback-report\r\n\r\n" +
+		"Feedback-Type: abuse\r\nUser-Agent: SomeGenerator/1.0\r\nVersion: 1\r\n" +
+		"Original-Mail-From: <kijitora@example.jp>\r\nArrival-Date: Thu, 8 Mar 2005 14:00:00 EDT\r\n" +
+		"Reporting-MTA: dns; mail.example.com\r\n\r\n" +
+		"--arf\r\nContent-Type: text/rfc822-headers\r\n\r\n" +
+		"From: <kijitora@example.jp>\r\nTo: neko@example.com, <nyaan@example.com>\r\nSubject: Nyaan\r\n\r\n" +
+		"--arf--\r\n"
+
+	cf, nyaan := rfc5965.ParseMessage(strings.NewReader(arf))
+	cx++; if nyaan != nil { t.Fatalf("rfc5965.ParseMessage() returns an error: %s", nyaan) }
+	cv := FromFeedback(cf)
+	cx++; if len(cv) != 2 { t.Fatalf("%s() returns %d recipients", fn, len(cv)) }
+	for j, e := range []string{"neko@example.com", "nyaan@example.com"} {
+		cx++; if cv[j].Recipient.Address() != e           { t.Errorf("%s()[%d].Recipient.Address() is %s", fn, j, cv[j].Recipient.Address()) }
+		cx++; if cv[j].Recipient.FinalRecipient.Type != "rfc822" { t.Errorf("%s()[%d].Recipient.FinalRecipient is %v", fn, j, cv[j].Recipient.FinalRecipient) }
+		cx++; if cv[j].Recipient.Failed() == false         { t.Errorf("%s()[%d].Recipient.Failed() is false", fn, j) }
+		cx++; if cv[j].Recipient.Status != rfc5965.ComplaintStatus { t.Errorf("%s()[%d].Recipient.Status is %s", fn, j, cv[j].Recipient.Status) }
+		cx++; if cv[j].Recipient.LastAttemptDate.IsZero() { t.Errorf("%s()[%d].Recipient.LastAttemptDate is zero", fn, j) }
+		cx++; if cv[j].Result.Reason != Feedback           { t.Errorf("%s()[%d].Result.Reason is %s", fn, j, cv[j].Result.Reason) }
+		cx++; if cv[j].Result.Rule == nil || cv[j].Result.Rule.Name != "feedback:abuse" { t.Errorf("%s()[%d].Result.Rule is %v", fn, j, cv[j].Result.Rule) }
+	}
+	cx++; if cv[0].Result == cv[1].Result { t.Errorf("%s() shares the result", fn) }
+
+	// The recipients are formatted as message/delivery-status without the fields of the ARF report
+	cd := &rfc3464.Report{ReportingMTA: cf.ReportingMTA, Recipients: []*rfc3464.Recipient{cv[0].Recipient, cv[1].Recipient}}
+	ds, nyaan := cd.Format(false)
+	cx++; if nyaan != nil { t.Fatalf("rfc3464.Report.Format() returns an error: %s", nyaan) }
+	cx++; if strings.Count(string(ds), "Status: 5.7.1\r\n") != 2 { t.Errorf("rfc3464.Report.Format() returns %q", ds) }
+	for _, e := range []string{"Feedback-Type", "User-Agent", "Version", "Original-Mail-From"} {
+		cx++; if strings.Contains(string(ds), e) { t.Errorf("rfc3464.Report.Format() has %s: %q", e, ds) }
+	}
+
+	// Not a complaint
+	cf, _ = rfc5965.ParseMessage(strings.NewReader(strings.Replace(arf, "Feedback-Type: abuse", "Feedback-Type: not-spam", 1)))
+	cx++; if cv := FromFeedback(cf); len(cv) != 0 { t.Errorf("%s(not-spam) returns %v", fn, cv) }
+	cx++; if cv := FromFeedback(nil); len(cv) != 0 { t.Errorf("%s(nil) returns %v", fn, cv) }
+
+	// The same shape as the recipients of the DSN
+	cr, _ := rfc3464.Parse(strings.NewReader("Reporting-MTA: dns; mx.example.jp\r\n\r\n" +
+		"Final-Recipient: rfc822; neko@example.org\r\nAction: failed\r\nStatus: 5.1.1\r\n\r\n" +
+		"Final-Recipient: rfc822; nyaan@example.org\r\nAction: delivered\r\nStatus: 2.0.0\r\n"))
+	ce := FromReport(cr)
+	cx++; if len(ce) != 2 { t.Fatalf("reason.FromReport() returns %d recipients", len(ce)) }
+	cx++; if ce[0].Recipient.Address() != "neko@example.org" || ce[0].Result.Reason != UserUnknown { t.Errorf("reason.FromReport()[0] is %v", ce[0]) }
+	cx++; if ce[1].Recipient.Failed() || ce[1].Result.Reason != Delivered { t.Errorf("reason.FromReport()[1] is %v", ce[1]) }
+	cx++; if ce := FromReport(nil); len(ce) != 0 { t.Errorf("reason.FromReport(nil) returns %v", ce) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestMatchCode(t *testing.T) {
+	fn := "reason.matchCode"
+	cx := 0
+	ae := []struct {pattern string; code string; expected bool}{
+		{"5.1.1", "5.1.1", true}, {"5.1.1", "5.1.10", false}, {"5.5.*", "5.5.4", true}, {"2.*.*", "2.1.5", true},
+		{"5.5.*", "4.5.4", false}, {"5.1.1", "", false}, {"5.1", "5.1.1", false}, {"*.1.1", "4.1.1", true},
+	}
+	for _, e := range ae {
+		cx++; if cv := matchCode(e.pattern, e.code); cv != e.expected { t.Errorf("%s(%q, %q) returns %t", fn, e.pattern, e.code, cv) }
+	}
+	t.Logf("The number of tests = %d", cx)
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                                 
+//  _ __ ___  __ _ ___  ___  _ __  
+// | '__/ _ \/ _` / __|/ _ \| '_ \ 
+// | | |  __/ (_| \__ \ (_) | | | |
+// |_|  \___|\__,_|___/\___/|_| |_|
+
+// Package "reason" provides a classifier of bounce reasons such as "userunknown" and "mailboxfull"
+// based on the SMTP status code, the SMTP reply code, the SMTP command, and the error message.
+// Reasons are the same as Sisimai's. https://libsisimai.org/en/reason/
+package reason
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc3464"
+import "libsisimai.org/mailer-goemon/rfc5965"
+import "libsisimai.org/mailer-goemon/smtp/reply"
+import "libsisimai.org/mailer-goemon/smtp/status"
+import "libsisimai.org/mailer-goemon/smtp/command"
+
+const (
+	AuthFailure     = "authfailure"     // SPF, DKIM, or DMARC authentication failed
+	BadReputation   = "badreputation"   // The sender IP address or the domain has a low reputation
+	Blocked         = "blocked"         // The sender IP address is listed in a DNSBL or blocked
+	ContentError    = "contenterror"    // The message content such as headers or attachments is invalid
+	Delivered       = "delivered"       // The message was delivered successfully
+	ExceedLimit     = "exceedlimit"     // The message size exceeds the limit of the recipient's mailbox
+	Expired         = "expired"         // Delivery time expired
+	FailedSTARTTLS  = "failedstarttls"  // STARTTLS failed or was required
+	Feedback        = "feedback"        // The message is a complaint via a feedback loop (ARF)
+	Filtered        = "filtered"        // The message was rejected by the recipient's filter
+	HasMoved        = "hasmoved"        // The recipient's mailbox has moved
+	HostUnknown     = "hostunknown"     // The domain part of the recipient address does not exist
+	MailboxFull     = "mailboxfull"     // The recipient's mailbox is full
+	MailerError     = "mailererror"     // The mailer program on the destination host failed
+	MesgTooBig      = "mesgtoobig"      // The message is too big
+	NetworkError    = "networkerror"    // DNS lookup, connection, or routing failed
+	NoRelaying      = "norelaying"      // Relaying was denied
+	NotAccept       = "notaccept"       // The destination host does not accept email (Null MX, 521, 556)
+	NotCompliantRFC = "notcompliantrfc" // The message is not compliant with RFC5322 or other RFCs
+	OnHold          = "onhold"          // The reason cannot be decided with the ambiguous status code
+	PolicyViolation = "policyviolation" // The message violates the policy of the destination host
+	RateLimited     = "ratelimited"     // Too many connections or too many messages in a short time
+	Rejected        = "rejected"        // The envelope sender address was rejected
+	RequirePTR      = "requireptr"      // The sender IP address does not have a PTR record
+	SecurityError   = "securityerror"   // SMTP authentication or encryption is required or failed
+	SpamDetected    = "spamdetected"    // The message was detected as spam
+	Suspend         = "suspend"         // The recipient's mailbox is suspended or disabled
+	SyntaxError     = "syntaxerror"     // The SMTP command or its argument has a syntax error
+	SystemError     = "systemerror"     // An error occurred on the destination host
+	SystemFull      = "systemfull"      // The disk of the destination host is full
+	Undefined       = "undefined"       // No rule matched
+	UserUnknown     = "userunknown"     // The recipient address does not exist
+	Vacation        = "vacation"        // The message is an auto reply of vacation
+	VirusDetected   = "virusdetected"   // The message includes a virus
+)
+
+// Input is a set of values to classify the bounce reason.
+type Input struct {
+	Status  string // SMTP status code such as "5.1.1", found in Text when it is empty
+	Reply   string // SMTP reply code such as "550", found in Text when it is empty
+	Command string // SMTP command such as "RCPT", found in Text when it is empty
+	Text    string // Error message such as Diagnostic-Code of the DSN
+}
+
+// Rule is a rule of the classification. A rule matches when all the non-empty conditions match:
+// one of the values of each condition matches.
+type Rule struct {
+	Name    string   // Name of the rule such as "text:userunknown"
+	Reason  string   // Reason decided by the rule such as UserUnknown
+	Status  []string // SMTP status codes, "*" matches any digits such as "5.5.*"
+	Reply   []string // SMTP reply codes such as "551"
+	Command []string // SMTP commands such as "MAIL"
+	Phrases []string // Lower-cased phrases included in the error message such as "user unknown"
+}
+
+// Result is the result of the classification.
+type Result struct {
+	Reason string // Reason such as UserUnknown, Undefined or OnHold when no rule matches
+	Rule   *Rule  // The rule which decided the reason, nil when no rule matches
+	Input  Input  // The given input including Status, Reply, and Command found in Text
+}
+
+// Recipient is a classified recipient of a DSN or an ARF report. Complaints are converted to the same
+// per-recipient fields as bounces to flow into the same suppression pipeline.
+type Recipient struct {
+	Recipient *rfc3464.Recipient // Per-recipient fields, rfc5965.Report.DeliveryStatus() for complaints
+	Result    *Result            // Classified reason, Feedback for complaints
+}
+
+// Classifier classifies bounce reasons with the ordered rules: the first matched rule decides the reason.
+type Classifier struct {
+	Rules []Rule // Rules in order of the priority
+}
+
+// NewClassifier returns a Classifier with the rules.
+//   Arguments:
+//     - rules ([]Rule): Rules in order of the priority, the default rules are used when it is nil.
+//   Returns:
+//     - (*Classifier): Classifier.
+func NewClassifier(rules []Rule) *Classifier {
+	if rules == nil { rules = Rules() }
+	return &Classifier{Rules: rules}
+}
+
+// Classify returns the bounce reason decided by the default rules, see Classifier.Classify().
+func Classify(in Input) *Result {
+	return defaultClassifier.Classify(in)
+}
+
+// FromRecipient returns the input of the per-recipient fields of the DSN. Status is the preferred
+// value of Status field and the status code in Diagnostic-Code chosen by status.Prefer().
+//   Arguments:
+//     - recipient (*rfc3464.Recipient): Per-recipient fields.
+//   Returns:
+//     - (Input): Input to Classify().
+func FromRecipient(recipient *rfc3464.Recipient) Input {
+	in := Input{
+		Status:  status.Prefer(recipient.Status, recipient.StatusCode, recipient.ReplyCode),
+		Reply:   recipient.ReplyCode,
+		Command: recipient.Command,
+	}
+	if recipient.DiagnosticCode != nil { in.Text = recipient.DiagnosticCode.Value }
+	return in
+}
+
+// FromReport returns the classified recipients of the DSN with FromRecipient() and Classify().
+//   Arguments:
+//     - report (*rfc3464.Report): Parsed delivery status.
+//   Returns:
+//     - ([]Recipient): Classified recipients in order of the per-recipient fields.
+func FromReport(report *rfc3464.Report) []Recipient {
+	recipients := []Recipient{}
+	if report == nil { return recipients }
+	for _, e := range report.Recipients {
+		recipients = append(recipients, Recipient{Recipient: e, Result: Classify(FromRecipient(e))})
+	}
+	return recipients
+}
+
+// FromFeedback returns the complained recipients of the ARF report converted by
+// rfc5965.Report.DeliveryStatus() with the reason Feedback decided by ClassifyFeedback().
+//   Arguments:
+//     - report (*rfc5965.Report): Parsed message/feedback-report part.
+//   Returns:
+//     - ([]Recipient): Complained recipients, empty if the report is not a complaint such as "not-spam".
+func FromFeedback(report *rfc5965.Report) []Recipient {
+	recipients := []Recipient{}
+	result := ClassifyFeedback(report); if result.Reason != Feedback { return recipients }
+
+	for _, e := range report.DeliveryStatus().Recipients {
+		cv := *result // Each recipient has its own result
+		recipients = append(recipients, Recipient{Recipient: e, Result: &cv})
+	}
+	return recipients
+}
+
+// ClassifyFeedback returns the reason of the ARF report: Feedback when the report is a complaint
+// such as "abuse", Undefined otherwise such as "not-spam" and "auth-failure".
+//   Arguments:
+//     - report (*rfc5965.Report): Parsed message/feedback-report part.
+//   Returns:
+//     - (*Result): Feedback and the rule named "feedback:" + Feedback-Type such as "feedback:abuse".
+func ClassifyFeedback(report *rfc5965.Report) *Result {
+	if report == nil || report.IsComplaint() == false { return &Result{Reason: Undefined} }
+	return &Result{Reason: Feedback, Rule: &Rule{Name: "feedback:" + report.FeedbackType, Reason: Feedback}}
+}
+
+// Classify returns the bounce reason. Status, Reply, and Command are found in Text with status.Find(),
+// reply.Find(), and command.Find() when they are empty.
+//   Arguments:
+//     - in (Input): Input values.
+//   Returns:
+//     - (*Result): The reason and the rule which decided it. The reason is OnHold when no rule
+//                  matches and the status code is ambiguous such as "5.0.0", Undefined otherwise.
+func (this *Classifier) Classify(in Input) *Result {
+	if in.Reply   == "" { in.Reply   = reply.Find(in.Text, in.Status) }
+	if in.Status  == "" { in.Status  = status.Find(in.Text, in.Reply) }
+	if in.Command == "" { in.Command = command.Find(in.Text) }
+
+	text := strings.ToLower(strings.Join(strings.Fields(in.Text), " "))
+	for j := range this.Rules {
+		// The first matched rule decides the reason
+		if this.Rules[j].Match(in, text) { return &Result{Reason: this.Rules[j].Reason, Rule: &this.Rules[j], Input: in} }
+	}
+	if in.Status != "" && status.IsAmbiguous(in.Status) { return &Result{Reason: OnHold, Input: in} }
+	return &Result{Reason: Undefined, Input: in}
+}
+
+// Match returns true if the rule matches the input.
+//   Arguments:
+//     - in (Input):    Input values.
+//     - text (string): Lower-cased error message with collapsed whitespaces.
+//   Returns:
+//     - (bool): true if all the non-empty conditions match.
+func (this *Rule) Match(in Input, text string) bool {
+	if len(this.Status) == 0 && len(this.Reply) == 0 && len(this.Command) == 0 && len(this.Phrases) == 0 { return false }
+
+	if len(this.Status) > 0 {
+		matched := false
+		for _, e := range this.Status { if matchCode(e, in.Status) { matched = true; break } }
+		if matched == false { return false }
+	}
+	if len(this.Reply) > 0 {
+		matched := false
+		for _, e := range this.Reply { if e == in.Reply { matched = true; break } }
+		if matched == false { return false }
+	}
+	if len(this.Command) > 0 {
+		matched := false
+		for _, e := range this.Command { if strings.EqualFold(e, in.Command) { matched = true; break } }
+		if matched == false { return false }
+	}
+	if len(this.Phrases) > 0 {
+		matched := false
+		for _, e := range this.Phrases { if strings.Contains(text, e) { matched = true; break } }
+		if matched == false { return false }
+	}
+	return true
+}
+
+// matchCode returns true if the status code matches the pattern such as "5.1.1" or "5.5.*".
+func matchCode(pattern, code string) bool {
+	if code == "" { return false }
+	p := strings.Split(pattern, "."); c := strings.Split(code, ".")
+	if len(p) != 3 || len(c) != 3 { return false }
+	for j := range p { if p[j] != "*" && p[j] != c[j] { return false } }
+	return true
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                                    ______        _             
+//  _ __ ___  __ _ ___  ___  _ __    / /  _ \ _   _| | ___  ___ _ 
+// | '__/ _ \/ _` / __|/ _ \| '_ \  / /| |_) | | | | |/ _ \/ __(_)
+// | | |  __/ (_| \__ \ (_) | | | |/ / |  _ <| |_| | |  __/\__ \_ 
+// |_|  \___|\__,_|___/\___/|_| |_/_/  |_| \_\\__,_|_|\___||___(_)
+
+package reason
+
+var defaultClassifier = NewClassifier(nil)
+
+// Phrases in error messages for each reason in order of the priority: more specific reasons are
+// checked earlier, for example "relay access denied" is NoRelaying before "access denied" of Blocked and
+// "sender address rejected: domain not found" is Rejected before "domain not found" of HostUnknown.
+var phraseRules = []struct {reason string; phrases []string}{
+	{MailerError, []string{"x-unix;", "command died with status", "command output:", "procmail:", "mailer error"}},
+	{NotCompliantRFC, []string{
+		"not compliant with rfc", "not rfc 5322 compliant", "not rfc5322 compliant", "rfc 5322 compliance",
+		"duplicate header", "multiple from addresses", "multiple addresses in from",
+	}},
+	{NoRelaying, []string{
+		"relay access denied", "relaying denied", "relay not permitted", "we do not relay", "unable to relay",
+		"relaying not allowed", "not permitted to relay", "relay denied", "relaying mail to", "not allowed to relay",
+	}},
+	{RequirePTR, []string{
+		"reverse dns", "no ptr record", "missing ptr record", "ptr lookup failed", "cannot find your hostname", "no rdns",
+		"rdns check failed", "cannot resolve your address", "client host rejected: cannot find your reverse hostname",
+		"does not have a reverse",
+	}},
+	{FailedSTARTTLS, []string{"must issue a starttls command first", "tls is required", "starttls is required", "tls required but not"}},
+	{VirusDetected, []string{
+		"virus found", "virus detected", "contains a virus", "infected with", "malware", "trojan", "worm detected",
+	}},
+	{AuthFailure, []string{
+		"spf check failed", "spf fail", "sender policy framework", "dkim check failed", "dkim verification failed",
+		"dkim signature", "failed dkim", "dmarc policy", "dmarc check failed", "dmarc verification failed", "failed dmarc",
+		"sender is unauthenticated",
+		"unauthenticated email", "does not pass authentication", "authentication checks failed", "spf validation",
+		"spf record", "failed spf", "authentication failure for the sender",
+	}},
+	{BadReputation, []string{"low reputation", "poor reputation", "bad reputation", "sender reputation", "ip reputation", "domain reputation"}},
+	{RateLimited, []string{
+		"too many connections", "rate limit", "ratelimit", "rate-limit", "too many messages", "throttl",
+		"exceeded the rate", "sending rate", "connection rate", "receiving mail at a rate", "too many concurrent",
+		"too many emails",
+	}},
+	{Rejected, []string{
+		"sender rejected", "sender address rejected", "domain of sender address", "sender verify failed", "invalid sender",
+		"sender not allowed", "bad sender", "sender denied", "from address rejected", "sender verification failed",
+	}},
+	{Blocked, []string{
+		"blocked using", "listed in the rbl", "listed in rbl", "barracudacentral", "blacklisted", "blocklisted", "blacklist",
+		"blocklist", "spamhaus", "spamcop", "client host rejected", "access denied", "ip address is blocked",
+		"ip address has been blocked", "rejected because the sending mta", "dnsbl", "poor ip", "banned",
+	}},
+	{SpamDetected, []string{
+		"as spam", "like spam", "spam detected", "spam message", "spam content", "spam score", "junk mail", "unsolicited",
+		"message content rejected", "classified as bulk", "bulk mail", "content filter",
+	}},
+	{Suspend, []string{
+		"mailbox disabled", "account disabled", "account has been disabled", "account suspended", "account has been suspended",
+		"mailbox suspended", "user suspended", "mailbox is inactive", "account is inactive", "inactive account",
+		"mailbox has been disabled", "account is disabled", "account expired",
+	}},
+	{HasMoved, []string{"has moved", "address has changed", "is no longer valid", "no longer in use", "user not local; please try"}},
+	{NotAccept, []string{"does not accept mail", "does not accept email", "accepts no mail", "null mx", "not accepting mail", "no mx record"}},
+	{HostUnknown, []string{
+		"host unknown", "unknown host", "domain not found", "domain does not exist", "no such domain", "host not found",
+		"name or service not known", "unrouteable mail domain", "domain name not found", "nxdomain", "host or domain name not found",
+		"unknown domain", "domain is not valid", "recipient domain not found", "no such host",
+	}},
+	{MailboxFull, []string{
+		"mailbox full", "mailbox is full", "over quota", "overquota", "quota exceeded", "exceeded storage allocation",
+		"mailbox size limit exceeded", "inbox is full", "account is full", "disk quota exceeded", "not enough storage space",
+		"mailbox has exceeded", "exceeds quota", "out of storage", "full mailbox", "over the quota", "storage quota",
+	}},
+	{SystemFull, []string{"insufficient system storage", "disk full", "no space left", "system is full", "insufficient disk space", "out of disk"}},
+	{MesgTooBig, []string{
+		"message too large", "message is too large", "message size exceeds", "message is too big", "message too big",
+		"exceeds the maximum message size", "message size limit", "message length exceeds", "mail size limit exceeded",
+		"exceeded max message size", "message exceeds", "size exceeds the limit", "maximum allowed size",
+	}},
+	{UserUnknown, []string{
+		"user unknown", "unknown user", "no such user", "user not found", "mailbox not found", "no mailbox here by that name",
+		"invalid recipient", "recipient not found", "unknown recipient", "no such mailbox", "account does not exist",
+		"account that you tried to reach does not exist", "user doesn't exist", "user does not exist", "is not a valid mailbox",
+		"unrouteable address", "recipient unknown", "addressee unknown", "mailbox does not exist", "invalid mailbox",
+		"recipient address rejected: undeliverable address", "mailbox unavailable", "no such recipient", "not a valid user",
+		"unknown or illegal alias", "recipient does not exist", "bad destination mailbox address", "user not exist",
+		"not listed in our directory", "not listed in the directory", "not listed in the address book",
+	}},
+	{Filtered, []string{
+		"filtered", "rejected by recipient", "refused by recipient", "not authorized to send to", "blocked by recipient",
+		"recipient has blocked", "sender blocked by recipient", "recipient rejected the message", "rejected by the recipient",
+	}},
+	{SecurityError, []string{
+		"authentication required", "authentication failed", "authentication is required", "encryption required",
+		"must be authenticated", "authentication credentials invalid",
+	}},
+	{SyntaxError, []string{"syntax error", "command unrecognized", "command not recognized", "bad sequence of commands", "unrecognized command"}},
+	{ContentError, []string{"header error", "headers too long", "illegal attachment", "invalid header", "message header", "bad character"}},
+	{Expired, []string{
+		"delivery time expired", "message expired", "retry time exceeded", "queue time expired", "too long in queue",
+		"message timed out", "retry timeout exceeded", "giving up on", "was not delivered for", "could not be delivered for",
+		"exceeded the maximum time", "delivery period expired",
+	}},
+	{NetworkError, []string{
+		"connection refused", "connection timed out", "network is unreachable", "no route to host", "host is unreachable",
+		"lost connection", "connection reset", "temporary dns failure", "dns lookup failure", "mail loops back to myself",
+		"too many hops", "hop count exceeded", "routing loop",
+	}},
+	{SystemError, []string{
+		"internal error", "system error", "server error", "local configuration error", "temporary failure",
+		"mail system error", "i/o error", "temporary local problem", "server configuration",
+	}},
+	{PolicyViolation, []string{
+		"policy violation", "violates our policy", "violation of our policy", "against our policy", "local policy",
+		"due to policy", "by policy", "policy reasons", "prohibited by",
+	}},
+	{Vacation, []string{"out of office", "out of the office", "on vacation", "auto-reply", "autoreply", "automatic reply"}},
+}
+
+// Reasons of SMTP status codes which are definitive: these codes decide the reason before phrases in
+// the error message such as "550 5.1.1 Mailbox does not exist. Your IP has been logged".
+var definitiveRules = []struct {reason string; codes []string}{
+	{UserUnknown,     []string{"5.1.1", "5.1.3", "5.1.4"}},
+	{HostUnknown,     []string{"5.1.2", "4.1.2", "4.4.4", "5.4.4"}},
+	{HasMoved,        []string{"4.1.6", "5.1.6", "5.7.17", "5.7.18"}},
+	{Rejected,        []string{"4.1.7", "4.1.8", "5.1.7", "5.1.8", "5.7.27"}},
+	{NotAccept,       []string{"5.1.10", "4.3.2", "5.3.2"}},
+	{MailboxFull,     []string{"4.2.2", "5.2.2"}},
+	{MesgTooBig,      []string{"4.3.4", "5.3.4", "5.7.16"}},
+	{Expired,         []string{"4.4.7", "5.4.7"}},
+	{FailedSTARTTLS,  []string{"5.7.10", "5.7.30"}},
+	{RequirePTR,      []string{"5.7.25"}},
+	{RateLimited,     []string{"4.7.28", "5.7.28"}},
+	{AuthFailure,     []string{"5.7.20", "5.7.21", "5.7.22", "5.7.23", "5.7.24", "5.7.26", "4.7.26", "5.7.29"}},
+}
+
+// Reasons of SMTP status codes used by various hosts for various reasons: phrases in the error message
+// are checked before these codes. X.0.0 codes such as "5.0.0" are not included.
+var statusRules = []struct {reason string; codes []string}{
+	{Filtered,        []string{"5.2.1"}},
+	{Suspend,         []string{"4.2.1", "5.7.13"}},
+	{ExceedLimit,     []string{"4.2.3", "5.2.3"}},
+	{SyntaxError,     []string{"4.2.4", "5.2.4", "5.5.*"}},
+	{SystemFull,      []string{"4.3.1", "5.3.1", "4.4.5"}},
+	{SystemError,     []string{"4.3.3", "4.3.5", "5.3.3", "5.3.5", "4.4.3", "5.4.3", "4.5.*", "4.1.9", "5.1.9"}},
+	{NetworkError,    []string{"4.4.1", "4.4.2", "5.4.1", "5.4.2", "4.4.6", "5.4.6"}},
+	{ContentError,    []string{"4.6.*", "5.6.*"}},
+	{PolicyViolation, []string{"5.7.1"}},
+	{SecurityError,   []string{"4.7.5", "5.7.5", "5.7.7", "5.7.8", "5.7.9", "5.7.11", "5.7.12"}},
+}
+
+// Reasons of SMTP reply codes used when the status code is missing or ambiguous
+var replyRules = []struct {reason string; codes []string}{
+	{HasMoved,      []string{"551"}},
+	{NotAccept,     []string{"521", "556"}},
+	{SystemFull,    []string{"452"}},
+	{MesgTooBig,    []string{"552"}},
+	{SecurityError, []string{"530", "535", "538"}},
+	{SyntaxError,   []string{"500", "501", "502", "503", "504", "555"}},
+}
+
+// Reasons of SMTP commands used when no other rule matches
+var commandRules = []struct {reason string; commands []string}{
+	{Blocked,        []string{"HELO", "EHLO"}},
+	{Rejected,       []string{"MAIL"}},
+	{FailedSTARTTLS, []string{"STARTTLS"}},
+}
+
+// Rules returns a copy of the default rules in order of the priority:
+//   1. 2.X.X status codes: Delivered
+//   2. Definitive SMTP status codes such as "5.1.1" and "5.2.2"
+//   3. Phrases in the error message
+//   4. Other SMTP status codes except ambiguous codes such as "5.0.0"
+//   5. SMTP reply codes
+//   6. SMTP commands
+// The copy can be modified and passed to NewClassifier().
+//   Returns:
+//     - ([]Rule): The default rules.
+func Rules() []Rule {
+	rules := []Rule{{Name: "status:delivered", Reason: Delivered, Status: []string{"2.*.*"}}}
+	for _, e := range definitiveRules { rules = append(rules, Rule{Name: "status:" + e.reason,  Reason: e.reason, Status:  append([]string{}, e.codes...)}) }
+	for _, e := range phraseRules     { rules = append(rules, Rule{Name: "text:" + e.reason,    Reason: e.reason, Phrases: append([]string{}, e.phrases...)}) }
+	for _, e := range statusRules     { rules = append(rules, Rule{Name: "status:" + e.reason,  Reason: e.reason, Status:  append([]string{}, e.codes...)}) }
+	for _, e := range replyRules      { rules = append(rules, Rule{Name: "reply:" + e.reason,   Reason: e.reason, Reply:   append([]string{}, e.codes...)}) }
+	for _, e := range commandRules    { rules = append(rules, Rule{Name: "command:" + e.reason, Reason: e.reason, Command: append([]string{}, e.commands...)}) }
+	return rules
+}
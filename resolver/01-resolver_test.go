@@ -0,0 +1,139 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package resolver
+
+//  _____         _      __                   _                
+// |_   _|__  ___| |_   / / __ ___  ___  ___ | |_   _____ _ __ 
+//   | |/ _ \/ __| __| / / '__/ _ \/ __|/ _ \| \ \ / / _ \ '__|
+//   | |  __/\__ \ |_ / /| | |  __/\__ \ (_) | |\ V /  __/ |   
+//   |_|\___||___/\__/_/ |_|  \___||___/\___/|_| \_/ \___|_|   
+import "fmt"
+import "net"
+import "errors"
+import "testing"
+import "context"
+
+func newTestFake() *Fake {
+	cv := NewFake()
+	cv.MX["example.jp"]         = []*net.MX{{Host: "mx2.example.jp.", Pref: 20}, {Host: "mx1.example.jp.", Pref: 10}}
+	cv.MX["nullmx.example"]     = []*net.MX{{Host: ".", Pref: 0}}
+	cv.MX["badmx.example"]      = []*net.MX{{Host: "192.0.2.1", Pref: 10}, {Host: "mx", Pref: 20}}
+	cv.MX["halfmx.example"]     = []*net.MX{{Host: "192.0.2.1", Pref: 10}, {Host: "mx1.halfmx.example.", Pref: 20}}
+	cv.Host["example.org"]      = []string{"192.0.2.25"}
+	cv.Host["mx1.example.jp"]   = []string{"192.0.2.1", "2001:db8::1"}
+	cv.TXT["txtonly.example"]   = []string{"v=spf1 -all"}
+	cv.TXT["nodata.example"]    = []string{}
+	cv.PTR["192.0.2.1"]         = []string{"mx1.example.jp."}
+	cv.Fail["servfail.example"] = &net.DNSError{Err: "server misbehaving", Name: "servfail.example", IsTemporary: true}
+	return cv
+}
+
+func TestFake(t *testing.T) {
+	fn := "resolver.Fake"
+	cx := 0
+	cf := newTestFake()
+	ct := context.Background()
+
+	var _ Resolver = cf
+	var _ Resolver = System()
+
+	cv, ce := cf.LookupMX(ct, "EXAMPLE.JP.")
+	cx++; if ce != nil || len(cv) != 2 { t.Fatalf("%s.LookupMX() returns %v, %v", fn, cv, ce) }
+	cx++; if cv[0].Host != "mx1.example.jp." { t.Errorf("%s.LookupMX()[0] is %s", fn, cv[0].Host) }
+
+	cw, ce := cf.LookupHost(ct, "mx1.example.jp")
+	cx++; if ce != nil || len(cw) != 2 { t.Errorf("%s.LookupHost() returns %v, %v", fn, cw, ce) }
+
+	cw, ce = cf.LookupAddr(ct, "192.0.2.1")
+	cx++; if ce != nil || len(cw) != 1 { t.Errorf("%s.LookupAddr() returns %v, %v", fn, cw, ce) }
+
+	cw, ce = cf.LookupTXT(ct, "nyaan.example")
+	cx++; if IsNotFound(ce) == false { t.Errorf("%s.LookupTXT() returns %v", fn, ce) }
+	cx++; if IsNXDomain(ce) == false { t.Errorf("%s.LookupTXT() returns %v", fn, ce) }
+
+	_, ce = cf.LookupHost(ct, "servfail.example")
+	cx++; if ce == nil || IsNotFound(ce) { t.Errorf("%s.LookupHost() returns %v", fn, ce) }
+
+	cx++; if IsNXDomain(ce) == true  { t.Errorf("%s.LookupHost() returns %v", fn, ce) }
+
+	_, ce = cf.LookupMX(ct, "nodata.example")
+	cx++; if IsNotFound(ce) == false || IsNXDomain(ce) { t.Errorf("%s.LookupMX() returns %v", fn, ce) }
+	_, ce = cf.LookupMX(ct, "txtonly.example")
+	cx++; if IsNotFound(ce) == false || IsNXDomain(ce) { t.Errorf("%s.LookupMX() returns %v", fn, ce) }
+
+	cx++; if IsNotFound(nil)                 { t.Errorf("IsNotFound(nil) returns true") }
+	cx++; if IsNotFound(errors.New("neko")) { t.Errorf("IsNotFound(neko) returns true") }
+	cx++; if IsNXDomain(nil)                 { t.Errorf("IsNXDomain(nil) returns true") }
+
+	// Wrapped *net.DNSError
+	ce  = fmt.Errorf("lookup: %w", &net.DNSError{Err: "no such host", Name: "nyaan.example", IsNotFound: true})
+	cx++; if IsNotFound(ce) == false { t.Errorf("IsNotFound(%v) returns false", ce) }
+	cx++; if IsNXDomain(ce) == true  { t.Errorf("IsNXDomain(%v) returns true", ce)  }
+	ce  = fmt.Errorf("lookup: %w", &net.DNSError{Err: "no such host", Name: "nyaan.example", IsNotFound: true, UnwrapErr: ErrNXDomain})
+	cx++; if IsNotFound(ce) == false || IsNXDomain(ce) == false { t.Errorf("IsNXDomain(%v) returns false", ce) }
+	ce  = fmt.Errorf("lookup: %w", &net.DNSError{Err: "server misbehaving", Name: "nyaan.example", IsTemporary: true})
+	cx++; if IsNotFound(ce) == true { t.Errorf("IsNotFound(%v) returns true", ce) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestCheck(t *testing.T) {
+	fn := "resolver.Check"
+	cx := 0
+	cf := newTestFake()
+	ct := context.Background()
+	ae := []struct {domain string; verdict Verdict; recipient string; sender string; exchanges int}{
+		{"example.jp", DomainDeliverable, "", "", 2},
+		{"example.org", DomainImplicitMX, "", "", 1},
+		{"nullmx.example", DomainNullMX, "5.1.10", "5.7.27", 0},
+		{"nyaan.example", DomainNotFound, "5.1.2", "5.1.8", 0},
+		{"txtonly.example", DomainNoMailHost, "5.4.4", "5.1.8", 0},
+		{"nodata.example", DomainNoMailHost, "5.4.4", "5.1.8", 0},
+		{"badmx.example", DomainInvalidMX, "5.4.4", "5.1.8", 0},
+		{"halfmx.example", DomainDeliverable, "", "", 1},
+		{"servfail.example", DomainTemporaryFail, "4.4.3", "4.4.3", 0},
+		{"", DomainNotFound, "5.1.2", "5.1.8", 0},
+	}
+
+	for _, e := range ae {
+		cv := Check(ct, cf, e.domain)
+		cx++; if cv.Verdict != e.verdict              { t.Errorf("%s(%s).Verdict is %s", fn, e.domain, cv.Verdict)                 }
+		cx++; if cv.RecipientStatus() != e.recipient  { t.Errorf("%s(%s).RecipientStatus() is %s", fn, e.domain, cv.RecipientStatus()) }
+		cx++; if cv.SenderStatus() != e.sender        { t.Errorf("%s(%s).SenderStatus() is %s", fn, e.domain, cv.SenderStatus())       }
+		cx++; if len(cv.Exchanges) != e.exchanges     { t.Errorf("%s(%s).Exchanges is %v", fn, e.domain, cv.Exchanges)             }
+		cx++; if cv.IsDeliverable() != (e.recipient == "") { t.Errorf("%s(%s).IsDeliverable() is %t", fn, e.domain, cv.IsDeliverable()) }
+	}
+	cx++; if cv := Check(ct, cf, "example.jp"); cv.Exchanges[0] != "mx1.example.jp" { t.Errorf("%s(): %v", fn, cv.Exchanges) }
+	cx++; if cv := Check(ct, cf, "servfail.example"); cv.Error == nil { t.Errorf("%s(): Error is nil", fn) }
+	cx++; if cv := Check(ct, cf, "badmx.example"); len(cv.InvalidMX) != 2 { t.Errorf("%s(): %v", fn, cv.InvalidMX) }
+	cx++; if DomainNullMX.String() != "nullmx" { t.Errorf("DomainNullMX.String() is %s", DomainNullMX) }
+
+	// *net.Resolver reports NXDOMAIN and NODATA as IsNotFound without ErrNXDomain
+	cg := NewFake()
+	cg.Fail["unknown.example"] = &net.DNSError{Err: "no such host", Name: "unknown.example", IsNotFound: true}
+	cx++; if cv := Check(ct, cg, "unknown.example"); cv.Verdict != DomainNoMailHost { t.Errorf("%s() returns %s", fn, cv.Verdict) }
+	cg.Fail["unknown.example"] = &net.DNSError{Err: "no such host", Name: "unknown.example", IsNotFound: true, UnwrapErr: ErrNXDomain}
+	cx++; if cv := Check(ct, cg, "unknown.example"); cv.Verdict != DomainNotFound { t.Errorf("%s() returns %s", fn, cv.Verdict) }
+
+	// MX targets are checked with rfc1123.ValidateHost(PolicyHostname)
+	cg.MX["policy.example"] = []*net.MX{{Host: "mx--1.policy.example.", Pref: 10}, {Host: "_mx.policy.example.", Pref: 20}, {Host: "mx1.policy.example.", Pref: 30}}
+	cv := Check(ct, cg, "policy.example")
+	cx++; if cv.Verdict != DomainDeliverable || len(cv.Exchanges) != 1 || len(cv.InvalidMX) != 2 { t.Errorf("%s() returns %v", fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestExists(t *testing.T) {
+	fn := "resolver.Exists"
+	cx := 0
+	cf := newTestFake()
+	ct := context.Background()
+
+	for _, e := range []string{"example.jp", "example.org", "txtonly.example", "nullmx.example"} {
+		cx++; if cv, ce := Exists(ct, cf, e); cv == false || ce != nil { t.Errorf("%s(%s) returns %t, %v", fn, e, cv, ce) }
+	}
+	cx++; if cv, ce := Exists(ct, cf, "nyaan.example");    cv == true || ce != nil { t.Errorf("%s() returns %t, %v", fn, cv, ce) }
+	cx++; if cv, ce := Exists(ct, cf, "servfail.example"); cv == true || ce == nil { t.Errorf("%s() returns %t, %v", fn, cv, ce) }
+
+	t.Logf("The number of tests = %d", cx)
+}
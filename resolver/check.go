@@ -0,0 +1,159 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                      _                
+//  _ __ ___  ___  ___ | |_   _____ _ __ 
+// | '__/ _ \/ __|/ _ \| \ \ / / _ \ '__|
+// | | |  __/\__ \ (_) | |\ V /  __/ |   
+// |_|  \___||___/\___/|_| \_/ \___|_|   
+
+package resolver
+import "context"
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc1123"
+
+// Verdict is a result of the check of the domain part of an email address.
+type Verdict uint8
+const (
+	DomainDeliverable   Verdict = iota // The domain has one or more valid MX records
+	DomainImplicitMX                   // The domain has no MX record but has A or AAAA records (RFC5321 5.1)
+	DomainNullMX                       // The domain has a null MX record "0 ." (RFC7505)
+	DomainNotFound                     // The domain does not exist (NXDOMAIN)
+	DomainNoMailHost                   // Neither MX records nor A/AAAA records, or the resolver cannot tell NXDOMAIN
+	DomainInvalidMX                    // Every MX target is not a valid internet host
+	DomainTemporaryFail                // DNS lookup failed temporarily
+)
+
+var verdictname = []string{
+	"deliverable", "implicitmx", "nullmx", "notfound", "nomailhost", "invalidmx", "temporaryfail",
+}
+
+func (this Verdict) String() string {
+	if int(this) < len(verdictname) { return verdictname[this] }
+	return ""
+}
+
+// Result is the result of Check().
+type Result struct {
+	Domain    string   // The domain checked such as "example.jp"
+	Verdict   Verdict  // The result of the check
+	Exchanges []string // Valid mail exchangers ordered by the preference, the domain itself for an implicit MX
+	InvalidMX []string // MX targets which are not valid internet hosts
+	Error     error    // The error returned from the resolver which caused DomainTemporaryFail
+}
+
+// IsDeliverable returns true if the domain can accept email.
+func (this *Result) IsDeliverable() bool {
+	return this.Verdict == DomainDeliverable || this.Verdict == DomainImplicitMX
+}
+
+// RecipientStatus returns an SMTP status code for the domain of a recipient address.
+//   Returns:
+//     - (string): SMTP status code such as "5.1.10", empty if the domain is deliverable.
+func (this *Result) RecipientStatus() string {
+	switch this.Verdict {
+		case DomainNullMX:        return "5.1.10" // Recipient address has null MX (RFC7505)
+		case DomainNotFound:      return "5.1.2"  // Bad destination system address
+		case DomainNoMailHost:    return "5.4.4"  // Unable to route
+		case DomainInvalidMX:     return "5.4.4"  // Unable to route
+		case DomainTemporaryFail: return "4.4.3"  // Directory server failure
+	}
+	return ""
+}
+
+// SenderStatus returns an SMTP status code for the domain of an envelope sender address.
+//   Returns:
+//     - (string): SMTP status code such as "5.7.27", empty if the domain is deliverable.
+func (this *Result) SenderStatus() string {
+	switch this.Verdict {
+		case DomainNullMX:        return "5.7.27" // Sender address has null MX (RFC7505)
+		case DomainNotFound:      return "5.1.8"  // Bad sender's system address
+		case DomainNoMailHost:    return "5.1.8"  // Bad sender's system address
+		case DomainInvalidMX:     return "5.1.8"  // Bad sender's system address
+		case DomainTemporaryFail: return "4.4.3"  // Directory server failure
+	}
+	return ""
+}
+
+// Check looks up MX and A/AAAA records of the domain and checks that the domain can accept email.
+//   Arguments:
+//     - ctx (context.Context): Context for the DNS lookups.
+//     - dns (Resolver):        Resolver such as System() or *Fake.
+//     - domain (string):       Domain part of an email address such as "example.jp".
+//   Returns:
+//     - (*Result): The result of the check.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5321#section-5.1
+//     - https://datatracker.ietf.org/doc/html/rfc7505
+func Check(ctx context.Context, dns Resolver, domain string) *Result {
+	domain = tidy(domain)
+	result := &Result{Domain: domain, Exchanges: []string{}, InvalidMX: []string{}}
+	if domain == "" { result.Verdict = DomainNotFound; return result }
+
+	mxrecords, nyaan := dns.LookupMX(ctx, domain)
+	if nyaan != nil && IsNotFound(nyaan) == false {
+		// SERVFAIL, timeout, or other errors except NXDOMAIN and NODATA
+		result.Verdict = DomainTemporaryFail; result.Error = nyaan; return result
+	}
+	if IsNXDomain(nyaan) { result.Verdict = DomainNotFound; return result }
+
+	if len(mxrecords) > 0 {
+		// The domain has MX records: check the null MX and each MX target
+		nullmx := 0; for _, e := range mxrecords {
+			// RFC7505 3. A domain that advertises a null MX MUST NOT advertise any other MX RR.
+			cv := strings.ToLower(strings.TrimSuffix(e.Host, "."))
+			if cv == "" { nullmx++; continue }
+
+			if rfc1123.ValidateHost(cv, rfc1123.PolicyHostname) == nil { result.Exchanges = append(result.Exchanges, cv) } else {
+				result.InvalidMX = append(result.InvalidMX, cv)
+			}
+		}
+		if nullmx == len(mxrecords)   { result.Verdict = DomainNullMX;    return result }
+		if len(result.Exchanges) == 0 { result.Verdict = DomainInvalidMX; return result }
+		result.Verdict = DomainDeliverable
+		return result
+	}
+
+	// RFC5321 5.1. If an empty list of MXs is returned, the address is treated as if it was
+	// associated with an implicit MX RR, with a preference of 0, pointing to that host.
+	addresses, nyaan := dns.LookupHost(ctx, domain)
+	if nyaan != nil && IsNotFound(nyaan) == false {
+		result.Verdict = DomainTemporaryFail; result.Error = nyaan; return result
+	}
+	if IsNXDomain(nyaan) { result.Verdict = DomainNotFound; return result }
+	if len(addresses) > 0 {
+		// The domain itself is the mail exchanger
+		result.Verdict   = DomainImplicitMX
+		result.Exchanges = append(result.Exchanges, domain)
+		return result
+	}
+
+	// Neither MX nor A/AAAA: the domain exists (NODATA) unless the resolver reported NXDOMAIN, and
+	// *net.Resolver reports both NXDOMAIN and NODATA as IsNotFound without the distinction.
+	if _, nyaan := dns.LookupTXT(ctx, domain); IsNXDomain(nyaan) { result.Verdict = DomainNotFound; return result }
+	result.Verdict = DomainNoMailHost
+	return result
+}
+
+// Exists returns true if the domain has any of MX, A/AAAA, or TXT records.
+//   Arguments:
+//     - ctx (context.Context): Context for the DNS lookups.
+//     - dns (Resolver):        Resolver such as System() or *Fake.
+//     - domain (string):       Domain name such as "example.jp".
+//   Returns:
+//     - (bool):  true if the domain has one or more records.
+//     - (error): An error except NXDOMAIN and NODATA returned from the resolver.
+func Exists(ctx context.Context, dns Resolver, domain string) (bool, error) {
+	domain = tidy(domain); if domain == "" { return false, nil }
+
+	for _, e := range []func(context.Context, string) ([]string, error){
+		func(c context.Context, d string) ([]string, error) {
+			cv, nyaan := dns.LookupMX(c, d); return make([]string, len(cv)), nyaan
+		},
+		dns.LookupHost, dns.LookupTXT,
+	} {
+		// Look up MX, A/AAAA, and TXT records in this order
+		cv, nyaan := e(ctx, domain); if len(cv) > 0 { return true, nil }
+		if nyaan != nil && IsNotFound(nyaan) == false { return false, nyaan }
+	}
+	return false, nil
+}
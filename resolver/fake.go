@@ -0,0 +1,83 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                      _                
+//  _ __ ___  ___  ___ | |_   _____ _ __ 
+// | '__/ _ \/ __|/ _ \| \ \ / / _ \ '__|
+// | | |  __/\__ \ (_) | |\ V /  __/ |   
+// |_|  \___||___/\___/|_| \_/ \___|_|   
+
+package resolver
+import "net"
+import "sort"
+import "strings"
+import "context"
+
+// Fake is an in-memory resolver for testing, each map is keyed by a lower-cased name without the
+// trailing dot such as "example.jp" or an IP address for PTR records such as "192.0.2.25". A name in
+// none of the maps is NXDOMAIN, and a name with an empty list such as TXT["example.jp"] = []string{}
+// exists without records (NODATA).
+type Fake struct {
+	MX   map[string][]*net.MX // MX records: "example.jp" => []*net.MX{{Host: "mx1.example.jp.", Pref: 10}}
+	Host map[string][]string  // A and AAAA records: "mx1.example.jp" => []string{"192.0.2.25"}
+	TXT  map[string][]string  // TXT records: "example.jp" => []string{"v=spf1 -all"}
+	PTR  map[string][]string  // PTR records: "192.0.2.25" => []string{"mx1.example.jp."}
+	Fail map[string]error     // Errors returned for the name regardless of the record type
+}
+
+// NewFake is a constructor of Fake.
+//   Returns:
+//     - (*Fake): Empty in-memory resolver.
+func NewFake() *Fake {
+	return &Fake{
+		MX:   map[string][]*net.MX{},
+		Host: map[string][]string{},
+		TXT:  map[string][]string{},
+		PTR:  map[string][]string{},
+		Fail: map[string]error{},
+	}
+}
+
+func (this *Fake) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	name = tidy(name); if nyaan := this.lookup(name, len(this.MX[name])); nyaan != nil { return nil, nyaan }
+	cv := make([]*net.MX, 0, len(this.MX[name]))
+	for _, e := range this.MX[name] { cv = append(cv, &net.MX{Host: e.Host, Pref: e.Pref}) }
+	sort.SliceStable(cv, func(a, b int) bool { return cv[a].Pref < cv[b].Pref })
+	return cv, nil
+}
+
+func (this *Fake) LookupHost(ctx context.Context, host string) ([]string, error) {
+	host = tidy(host); if nyaan := this.lookup(host, len(this.Host[host])); nyaan != nil { return nil, nyaan }
+	return append([]string{}, this.Host[host]...), nil
+}
+
+func (this *Fake) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	name = tidy(name); if nyaan := this.lookup(name, len(this.TXT[name])); nyaan != nil { return nil, nyaan }
+	return append([]string{}, this.TXT[name]...), nil
+}
+
+func (this *Fake) LookupAddr(ctx context.Context, addr string) ([]string, error) {
+	addr = tidy(addr); if nyaan := this.lookup(addr, len(this.PTR[addr])); nyaan != nil { return nil, nyaan }
+	return append([]string{}, this.PTR[addr]...), nil
+}
+
+// lookup returns an error for the name in the same manner as *net.Resolver.
+//   Arguments:
+//     - name (string): Tidied name.
+//     - size (int):    The number of records of the type looked up.
+//   Returns:
+//     - (error): The error in Fail, *net.DNSError when no record exists, or nil.
+func (this *Fake) lookup(name string, size int) error {
+	if nyaan, ok := this.Fail[name]; ok { return nyaan }
+	if size > 0 { return nil }
+
+	for _, e := range []bool{this.MX[name] != nil, this.Host[name] != nil, this.TXT[name] != nil, this.PTR[name] != nil} {
+		// The name exists with other types of records or an empty list: NODATA
+		if e { return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true} }
+	}
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true, UnwrapErr: ErrNXDomain}
+}
+
+// tidy returns the lower-cased name without the trailing dot.
+func tidy(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                      _                
+//  _ __ ___  ___  ___ | |_   _____ _ __ 
+// | '__/ _ \/ __|/ _ \| \ \ / / _ \ '__|
+// | | |  __/\__ \ (_) | |\ V /  __/ |   
+// |_|  \___||___/\___/|_| \_/ \___|_|   
+
+// Package "resolver" provides an abstraction of DNS lookups and checks of the domain part of email
+// addresses such as the existence of the domain, a null MX (RFC7505), and an implicit MX (RFC5321).
+package resolver
+import "net"
+import "errors"
+import "context"
+
+// ErrNXDomain means that the domain name itself does not exist (NXDOMAIN). *net.Resolver does not
+// distinguish NXDOMAIN from NODATA, a Resolver which can tell NXDOMAIN returns an error wrapping it.
+var ErrNXDomain = errors.New("resolver: no such domain")
+
+// Resolver is a set of DNS lookups used in this package, *net.Resolver satisfies this interface.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)  // MX records
+	LookupHost(ctx context.Context, host string) ([]string, error) // A and AAAA records
+	LookupTXT(ctx context.Context, name string) ([]string, error)  // TXT records
+	LookupAddr(ctx context.Context, addr string) ([]string, error) // PTR records
+}
+
+// System returns the resolver of the operating system.
+//   Returns:
+//     - (Resolver): net.DefaultResolver.
+func System() Resolver {
+	return net.DefaultResolver
+}
+
+// IsNotFound returns true if the error means that the name or the record does not exist.
+//   Arguments:
+//     - nyaan (error): An error returned from Lookup* functions.
+//   Returns:
+//     - (bool): true if the error is an NXDOMAIN or NODATA error.
+func IsNotFound(nyaan error) bool {
+	if nyaan == nil                  { return false }
+	if errors.Is(nyaan, ErrNXDomain) { return true  }
+
+	var cv *net.DNSError; if errors.As(nyaan, &cv) { return cv.IsNotFound }
+	return false
+}
+
+// IsNXDomain returns true if the error means that the domain name itself does not exist.
+//   Arguments:
+//     - nyaan (error): An error returned from Lookup* functions.
+//   Returns:
+//     - (bool): true if the error wraps ErrNXDomain such as &net.DNSError{UnwrapErr: ErrNXDomain}.
+func IsNXDomain(nyaan error) bool {
+	if nyaan == nil { return false }
+	return errors.Is(nyaan, ErrNXDomain)
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc1123
+
+//  _____         _      ______  _____ ____ _ _ ____  _____ 
+// |_   _|__  ___| |_   / /  _ \|  ___/ ___/ / |___ \|___ / 
+//   | |/ _ \/ __| __| / /| |_) | |_ | |   | | | __) | |_ \ 
+//   | |  __/\__ \ |_ / / |  _ <|  _|| |___| | |/ __/ ___) |
+//   |_|\___||___/\__/_/  |_| \_\_|   \____|_|_|_____|____/ 
+import "testing"
+import "errors"
+import "strings"
+
+func TestValidateHost(t *testing.T) {
+	fn := "rfc1123.ValidateHost"
+	cx := 0
+	l6 := strings.Repeat("a", 64)
+	ae := []struct {host string; rule Policy; expected error}{
+		{"mx1.example.jp", PolicyHostname, nil},
+		{"xn--cesupi09d.jp", PolicyHostname, nil},
+		{"mx-1.example.jp", PolicyHostname, nil},
+		{"mx1--a.example.jp", PolicyHostname, nil},
+		{"", PolicyHostname, ErrEmptyHost},
+		{"mx1.example.jp.", PolicyHostname, ErrTrailingDot},
+		{"192.0.2.25", PolicyHostname, ErrIPAddress},
+		{"localhost", PolicyHostname, ErrSingleLabel},
+		{"mx1." + l6 + ".jp", PolicyHostname, ErrLabelTooLong},
+		{strings.Repeat("neko.", 51) + "jp", PolicyHostname, ErrHostTooLong},
+		{"mx1..example.jp", PolicyHostname, ErrEmptyLabel},
+		{"-mx1.example.jp", PolicyHostname, ErrLeadingHyphen},
+		{"mx1-.example.jp", PolicyHostname, ErrTrailingHyphen},
+		{"mx--0.example.jp", PolicyHostname, ErrReservedHyphens},
+		{"mx_0.example.jp", PolicyHostname, ErrInvalidCharacter},
+		{"mx0.example/jp", PolicyHostname, ErrInvalidCharacter},
+		{"mx0.example.22", PolicyHostname, ErrNumericTLD},
+
+		{"_dmarc.example.jp", PolicyDNSName, nil},
+		{"selector1._domainkey.example.jp.", PolicyDNSName, nil},
+		{"_25._tcp.mx1.example.jp", PolicyDNSName, nil},
+		{"jp", PolicyDNSName, nil},
+		{"_dmarc.example.jp", PolicyHostname, ErrInvalidCharacter},
+		{"_dmarc..example.jp", PolicyDNSName, ErrEmptyLabel},
+		{"-dmarc.example.jp", PolicyDNSName, ErrLeadingHyphen},
+
+		{"mx1.example.jp.", PolicyFQDN, nil},
+		{"mx1.example.jp", PolicyFQDN, ErrNoTrailingDot},
+		{".", PolicyFQDN, ErrEmptyHost},
+		{"_dmarc.example.jp.", PolicyFQDN, ErrInvalidCharacter},
+
+		{"localhost", PolicyLegacy, nil},
+		{"mx--0.example.jp", PolicyLegacy, nil},
+		{"win_host.example.jp.", PolicyLegacy, nil},
+		{"-mx1.example.jp", PolicyLegacy, nil},
+		{"mx1", PolicyLegacy, ErrSingleLabel},
+		{"mx1." + l6 + ".jp", PolicyLegacy, ErrLabelTooLong},
+	}
+
+	for _, e := range ae {
+		cv := ValidateHost(e.host, e.rule)
+		cx++; if errors.Is(cv, e.expected) == false || (cv == nil) != (e.expected == nil) {
+			t.Errorf("%s(%s, %d) returns %v, not %v", fn, e.host, e.rule, cv, e.expected)
+		}
+	}
+
+	ce := &HostError{}
+	cv := ValidateHost("mx1.example-.jp", PolicyHostname)
+	cx++; if errors.As(cv, &ce) == false { t.Fatalf("%s() does not return *HostError", fn) }
+	cx++; if ce.Label != "example-"      { t.Errorf("%s().Label is %s", fn, ce.Label)     }
+	cx++; if ce.Index != 1               { t.Errorf("%s().Index is %d", fn, ce.Index)     }
+	cx++; if strings.Contains(cv.Error(), "example-") == false { t.Errorf("%s().Error() is %s", fn, cv.Error()) }
+
+	t.Logf("The number of tests = %d", cx)
+}
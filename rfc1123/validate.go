@@ -0,0 +1,130 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//  ____  _____ ____ _ _ ____  _____ 
+// |  _ \|  ___/ ___/ / |___ \|___ / 
+// | |_) | |_ | |   | | | __) | |_ \ 
+// |  _ <|  _|| |___| | |/ __/ ___) |
+// |_| \_\_|   \____|_|_|_____|____/ 
+
+package rfc1123
+import "errors"
+import "strings"
+import "libsisimai.org/mailer-goemon/moji"
+import "libsisimai.org/mailer-goemon/rfc791"
+
+// Policy is a set of rules used by ValidateHost()
+type Policy uint8
+const (
+	PolicyHostname Policy = iota // Strict hostname: "mx1.example.jp"
+	PolicyDNSName                // DNS name including "_dmarc"-style labels: "_dmarc.example.jp"
+	PolicyFQDN                   // Strict hostname with the trailing dot: "mx1.example.jp."
+	PolicyLegacy                 // Lenient legacy hostname: "mx--1.example.jp", "win_host.example.jp"
+)
+
+var (
+	ErrEmptyHost        = errors.New("hostname is empty")
+	ErrHostTooLong      = errors.New("hostname is longer than 253 octets")
+	ErrIPAddress        = errors.New("hostname is an IP address")
+	ErrSingleLabel      = errors.New("hostname has only one label")
+	ErrEmptyLabel       = errors.New("label is empty")
+	ErrLabelTooLong     = errors.New("label is longer than 63 octets")
+	ErrInvalidCharacter = errors.New("label includes a character other than letters, digits, and hyphens")
+	ErrLeadingHyphen    = errors.New("label begins with a hyphen")
+	ErrTrailingHyphen   = errors.New("label ends with a hyphen")
+	ErrReservedHyphens  = errors.New("label has hyphens in the 3rd and 4th positions but is not an A-label")
+	ErrNumericTLD       = errors.New("top level domain consists of digits only")
+	ErrTrailingDot      = errors.New("hostname ends with a dot")
+	ErrNoTrailingDot    = errors.New("hostname does not end with a dot")
+)
+
+// HostError is an error returned from ValidateHost(), Err is one of the Err* errors above.
+type HostError struct {
+	Host  string // The hostname given to ValidateHost()
+	Label string // The label which breaks the rule, empty if the error is not related to a label
+	Index int    // The index of the label, -1 if the error is not related to a label
+	Err   error  // The rule broken such as ErrLabelTooLong
+}
+
+func (this *HostError) Error() string {
+	if this.Index < 0 { return "rfc1123: " + this.Err.Error() + ": " + this.Host }
+	return "rfc1123: " + this.Err.Error() + ": label " + this.Label + " in " + this.Host
+}
+func (this *HostError) Unwrap() error { return this.Err }
+
+// ValidateHost checks the hostname with the rules of the given policy and returns the rule broken.
+//   Arguments:
+//     - host (string): Hostname such as "mx1.example.jp".
+//     - rule (Policy): PolicyHostname, PolicyDNSName, PolicyFQDN, or PolicyLegacy.
+//   Returns:
+//     - (error): *HostError which wraps one of the Err* errors, nil if the hostname is valid.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc1123#section-2.1
+//     - https://datatracker.ietf.org/doc/html/rfc2181#section-11
+//     - https://datatracker.ietf.org/doc/html/rfc5891#section-4.2.3.1
+//     - https://datatracker.ietf.org/doc/html/rfc8552
+func ValidateHost(host string, rule Policy) error {
+	if host == "" { return &HostError{Host: host, Index: -1, Err: ErrEmptyHost} }
+
+	hostname := host
+	if strings.HasSuffix(hostname, ".") {
+		// The hostname is an absolute domain name such as "mx1.example.jp."
+		if rule == PolicyHostname { return &HostError{Host: host, Index: -1, Err: ErrTrailingDot} }
+		hostname = hostname[:len(hostname) - 1]
+
+	} else if rule == PolicyFQDN {
+		// PolicyFQDN requires the trailing dot
+		return &HostError{Host: host, Index: -1, Err: ErrNoTrailingDot}
+	}
+	if hostname == ""                 { return &HostError{Host: host, Index: -1, Err: ErrEmptyHost}   }
+	if len(hostname) > 253            { return &HostError{Host: host, Index: -1, Err: ErrHostTooLong} }
+	if rfc791.IsIPv4Address(hostname) { return &HostError{Host: host, Index: -1, Err: ErrIPAddress}   }
+
+	labels := strings.Split(hostname, ".")
+	if len(labels) == 1 && rule != PolicyDNSName {
+		// A hostname without a domain name such as "mx1" is not an internet hostname, "localhost"
+		// is allowed only in the lenient legacy policy.
+		if rule != PolicyLegacy || (hostname != "localhost" && hostname != "localhost6") {
+			return &HostError{Host: host, Index: -1, Err: ErrSingleLabel}
+		}
+	}
+
+	for j, e := range labels {
+		// Check each label of the hostname
+		if cv := validateLabel(e, rule); cv != nil { return &HostError{Host: host, Label: e, Index: j, Err: cv} }
+	}
+
+	// The top level domain should not consist of digits only
+	if len(labels) > 1 && moji.ContainsOnlyNumbers(labels[len(labels) - 1]) {
+		return &HostError{Host: host, Label: labels[len(labels) - 1], Index: len(labels) - 1, Err: ErrNumericTLD}
+	}
+	return nil
+}
+
+// validateLabel checks the label with the rules of the given policy.
+//   Arguments:
+//     - label (string): Label of the hostname such as "mx1".
+//     - rule  (Policy): Validation policy.
+//   Returns:
+//     - (error): One of the Err* errors, nil if the label is valid.
+func validateLabel(label string, rule Policy) error {
+	if label      == "" { return ErrEmptyLabel   }
+	if len(label)  > 63 { return ErrLabelTooLong }
+
+	for _, e := range []byte(label) {
+		// Letters, digits, and hyphens; underscores are allowed in DNS names and legacy hostnames
+		if e >= 'a' && e <= 'z' || e >= 'A' && e <= 'Z' || e >= '0' && e <= '9' || e == '-' { continue }
+		if e == '_' && (rule == PolicyDNSName || rule == PolicyLegacy)                       { continue }
+		return ErrInvalidCharacter
+	}
+	if rule == PolicyLegacy || rule == PolicyDNSName && strings.HasPrefix(label, "_") { return nil }
+
+	if strings.HasPrefix(label, "-") { return ErrLeadingHyphen  }
+	if strings.HasSuffix(label, "-") { return ErrTrailingHyphen }
+
+	// Labels with "--" in the 3rd and 4th positions are reserved for IDNA, "xn--" is an A-label
+	if len(label) > 3 && label[2:4] == "--" && strings.EqualFold(label[0:2], "xn") == false {
+		if rule != PolicyDNSName { return ErrReservedHyphens }
+	}
+	return nil
+}
+
@@ -0,0 +1,234 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc2045
+
+//  _____         _      __     __      ____   ___  _  _  ____  
+// |_   _|__  ___| |_   / / __ / _| ___|___ \ / _ \| || || ___| 
+//   | |/ _ \/ __| __| / / '__| |_ / __| __) | | | | || ||___ \ 
+//   | |  __/\__ \ |_ / /| |  |  _| (__ / __/| |_| |__   _|__) |
+//   |_|\___||___/\__/_/ |_|  |_|  \___|_____|\___/   |_||____/ 
+import "io"
+import "strings"
+import "testing"
+import "testing/iotest"
+import "encoding/base64"
+
+var bounce = strings.Join([]string{
+	"From: Mail Delivery System <MAILER-DAEMON@example.jp>",
+	"To: neko@example.org",
+	"Subject: Undelivered Mail Returned to Sender",
+	"MIME-Version: 1.0",
+	"Content-Type: multipart/report; report-type=delivery-status;",
+	"\tboundary=\"AA.1/BB\" (comment)",
+	"",
+	"This is a MIME-encapsulated message.",
+	"",
+	"--AA.1/BB",
+	"Content-Description: Notification",
+	"Content-Type: text/plain; charset=us-ascii",
+	"",
+	"I'm sorry to have to inform you that your message could not",
+	"be delivered to one or more recipients. Nyaan \xe2\x9c\x8c",
+	"",
+	"--AA.1/BB   ",
+	"Content-Type: message/delivery-status",
+	"",
+	"Reporting-MTA: dns; mx.example.jp",
+	"",
+	"Final-Recipient: rfc822; kijitora@example.jp",
+	"Action: failed",
+	"Status: 5.1.1",
+	"",
+	"--AA.1/BB",
+	"Content-Type: message/rfc822",
+	"",
+	"From: neko@example.org",
+	"Message-ID: <1234.abcd@example.org>",
+	"Content-Type: multipart/alternative; boundary=CC",
+	"",
+	"--CC",
+	"Content-Type: text/plain; charset=\"iso-8859-1\"",
+	"Content-Transfer-Encoding: quoted-printable",
+	"",
+	"Caf=E9 =",
+	"au lait",
+	"--CC",
+	"Content-Type: text/html; charset=utf-8",
+	"Content-Transfer-Encoding: BASE64",
+	"",
+	"PGI+TnlhYW48L2I+Cg",
+	"--AA.1/BB--",
+	"Epilogue",
+	"",
+}, "\r\n")
+
+func TestNextPart(t *testing.T) {
+	fn := "rfc2045.NextPart"
+	cx := 0
+	ae := []struct {
+		path string; mediatype string; depth int; body string; flags uint8
+	}{
+		{"",      "multipart/report",        0, "This is a MIME-encapsulated message.\r\n", 0},
+		{"1",     "text/plain",              1, "I'm sorry to have to inform you that your message could not\r\nbe delivered to one or more recipients. Nyaan \xe2\x9c\x8c\r\n", PartEightBit},
+		{"2",     "message/delivery-status", 1, "Reporting-MTA: dns; mx.example.jp\r\n\r\nFinal-Recipient: rfc822; kijitora@example.jp\r\nAction: failed\r\nStatus: 5.1.1\r\n", 0},
+		{"3",     "message/rfc822",          1, "", 0},
+		{"3.1",   "multipart/alternative",   2, "", PartMissingBoundary},
+		{"3.1.1", "text/plain",              3, "Caf\xe9 au lait", 0},
+		{"3.1.2", "text/html",               3, "<b>Nyaan</b>\n", 0},
+	}
+
+	cr := NewReader(strings.NewReader(bounce))
+	ps := []*Part{}
+	for j, e := range ae {
+		cv, ce := cr.NextPart()
+		cx++; if ce != nil { t.Fatalf("%s() returns error %v", fn, ce) }
+		cb, ce := io.ReadAll(cv.Body())
+		cx++; if ce != nil             { t.Errorf("[%d] %s().Body() returns error %v", j, fn, ce) }
+		cx++; if cv.Path  != e.path    { t.Errorf("[%d] %s().Path is %s, expected %s", j, fn, cv.Path, e.path) }
+		cx++; if cv.Depth != e.depth   { t.Errorf("[%d] %s().Depth is %d, expected %d", j, fn, cv.Depth, e.depth) }
+		cx++; if string(cb) != e.body  { t.Errorf("[%d] %s().Body() is %q, expected %q", j, fn, cb, e.body) }
+		cx++; if cv.MediaType != e.mediatype { t.Errorf("[%d] %s().MediaType is %s, expected %s", j, fn, cv.MediaType, e.mediatype) }
+		ps = append(ps, cv)
+	}
+	cv, ce := cr.NextPart()
+	cx++; if cv != nil || ce != io.EOF { t.Errorf("%s() returns %v, %v", fn, cv, ce) }
+	cv, ce  = cr.NextPart()
+	cx++; if cv != nil || ce != io.EOF { t.Errorf("%s() returns %v, %v", fn, cv, ce) }
+
+	for j, e := range ae {
+		// Flags are set after reading the body or the end of the multipart
+		cx++; if ps[j].Flags != e.flags { t.Errorf("[%d] %s().Flags is %d, expected %d", j, fn, ps[j].Flags, e.flags) }
+	}
+	cx++; if ps[0].Params["boundary"]    != "AA.1/BB"         { t.Errorf("%s().Params is %v", fn, ps[0].Params) }
+	cx++; if ps[0].Params["report-type"] != "delivery-status" { t.Errorf("%s().Params is %v", fn, ps[0].Params) }
+	cx++; if ps[0].Header.Get("To")      != "neko@example.org" { t.Errorf("%s().Header is %v", fn, ps[0].Header) }
+	cx++; if ps[4].Parent != ps[3] || ps[3].Parent != ps[0]    { t.Errorf("%s().Parent is wrong", fn) }
+	cx++; if ps[4].Header.Get("Message-ID") != "<1234.abcd@example.org>" { t.Errorf("%s().Header is %v", fn, ps[4].Header) }
+	cx++; if ps[0].IsMultipart() == false || ps[1].IsMultipart() { t.Errorf("%s().IsMultipart() is wrong", fn) }
+	cx++; if ps[3].IsMessage()   == false || ps[4].IsMessage()   { t.Errorf("%s().IsMessage() is wrong", fn) }
+	cx++; if ps[1].Charset() != "us-ascii" || ps[5].Charset() != "iso-8859-1" || ps[2].Charset() != "" {
+		t.Errorf("%s().Charset() is wrong", fn)
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestWalk(t *testing.T) {
+	fn := "rfc2045.Walk"
+	cx := 0
+	cv := []string{}
+	ce := NewReader(strings.NewReader(bounce)).Walk(func(p *Part) error {
+		// Skip reading the body of some parts
+		if p.MediaType == "message/delivery-status" { cv = append(cv, p.MediaType) }
+		return nil
+	})
+	cx++; if ce != nil     { t.Errorf("%s() returns %v", fn, ce) }
+	cx++; if len(cv) != 1  { t.Errorf("%s() returns %v", fn, cv) }
+
+	ce = NewReader(strings.NewReader(bounce)).Walk(func(p *Part) error { return io.ErrUnexpectedEOF })
+	cx++; if ce != io.ErrUnexpectedEOF { t.Errorf("%s() returns %v", fn, ce) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestBrokenMessages(t *testing.T) {
+	fn := "rfc2045.NextPart"
+	cx := 0
+	ae := []struct {
+		testname string; message string; mediatype []string; bodies []string; flags []uint8
+	}{
+		{"Single part", "Subject: neko\n\nNyaan\n", []string{"text/plain"}, []string{"Nyaan\n"}, []uint8{0}},
+		{"No header", "\nNyaan", []string{"text/plain"}, []string{"Nyaan"}, []uint8{0}},
+		{"No boundary parameter", "Content-Type: multipart/mixed\n\n--x\n\nNyaan\n",
+			[]string{"multipart/mixed"}, []string{"--x\n\nNyaan\n"}, []uint8{PartNoBoundary}},
+		{"No close-delimiter", "Content-Type: multipart/mixed; boundary=x\n\n--x\n\nNyaan\n",
+			[]string{"multipart/mixed", "text/plain"}, []string{"", "Nyaan\n"}, []uint8{PartMissingBoundary, 0}},
+		{"Digest", "Content-Type: multipart/digest; boundary=x\n\n--x\n\nSubject: neko\n\nNyaan\n--x--\n",
+			[]string{"multipart/digest", "message/rfc822", "text/plain"}, []string{"", "", "Nyaan"}, []uint8{0, 0, 0}},
+		{"Truncated message/rfc822", "Content-Type: multipart/mixed; boundary=x\n\n--x\nContent-Type: message/rfc822\n\nSubject: neko\n--x--\n",
+			[]string{"multipart/mixed", "message/rfc822", "text/plain"}, []string{"", "", ""}, []uint8{0, 0, 0}},
+		{"Broken base64", "Content-Transfer-Encoding: base64\n\nTnlh!!YW4\n",
+			[]string{"text/plain"}, []string{"Nyaan"}, []uint8{0}},
+		{"Broken quoted-printable", "Content-Transfer-Encoding: quoted-printable\n\nNyaan=ZZ\n",
+			[]string{"text/plain"}, []string{"Nyaan=ZZ\n"}, []uint8{0}},
+		{"Unknown encoding", "Content-Transfer-Encoding: x-uuencode\n\nNyaan",
+			[]string{"text/plain"}, []string{"Nyaan"}, []uint8{PartInvalidEncoding}},
+		{"Encoded message/rfc822", "Content-Type: message/rfc822\nContent-Transfer-Encoding: base64\n\nU3ViamVjdDogbmVrbw==\n",
+			[]string{"message/rfc822"}, []string{"Subject: neko"}, []uint8{0}},
+	}
+
+	for _, e := range ae {
+		cr := NewReader(strings.NewReader(e.message))
+		ps := []*Part{}
+		for j := range e.mediatype {
+			cv, ce := cr.NextPart()
+			cx++; if ce != nil { t.Errorf("[%s] %s() returns %v", e.testname, fn, ce); break }
+			cb, _ := io.ReadAll(cv.Body())
+			cx++; if cv.MediaType != e.mediatype[j] { t.Errorf("[%s][%d] %s().MediaType is %s", e.testname, j, fn, cv.MediaType) }
+			cx++; if string(cb)   != e.bodies[j]    { t.Errorf("[%s][%d] %s().Body() is %q", e.testname, j, fn, cb) }
+			ps = append(ps, cv)
+		}
+		_, ce := cr.NextPart()
+		cx++; if ce != io.EOF { t.Errorf("[%s] %s() returns %v, expected io.EOF", e.testname, fn, ce) }
+		for j, f := range ps {
+			cx++; if f.Flags != e.flags[j] { t.Errorf("[%s][%d] %s().Flags is %d, expected %d", e.testname, j, fn, f.Flags, e.flags[j]) }
+		}
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestBase64Filter(t *testing.T) {
+	fn := "rfc2045.base64Filter"
+	cx := 0
+	ae := []struct {testname string; chunks []string; expected string}{
+		{"Padded", []string{"TnlhYW4="}, "Nyaan"},
+		{"No padding", []string{"TnlhYW4"}, "Nyaan"},
+		{"Stray character", []string{"TnlhX"}, "Nya"},
+		{"Stray character in the last Read", []string{"Tnlh", "X"}, "Nya"},
+		{"Stray character before the end", []string{"TnlhX", "\n\n"}, "Nya"},
+		{"Stray character at a Read boundary", []string{"TnlhYW5l", "X", "!!\n", "\n"}, "Nyaane"},
+		{"Split padding", []string{"TnlhYQ", "=", "=\n"}, "Nyaa"},
+		{"Only a stray character", []string{"\n", "X", "\n"}, ""},
+	}
+
+	for _, e := range ae {
+		for j := 0; j < 2; j++ {
+			rs := []io.Reader{}
+			for _, f := range e.chunks { rs = append(rs, strings.NewReader(f)) }
+			var cr io.Reader = io.MultiReader(rs...)
+			if j == 1 { cr = iotest.OneByteReader(io.MultiReader(rs...)) }
+
+			cv, ce := io.ReadAll(base64.NewDecoder(base64.StdEncoding, &base64Filter{reader: cr}))
+			cx++; if ce != nil            { t.Errorf("[%s][%d] %s returns %v", e.testname, j, fn, ce)       }
+			cx++; if string(cv) != e.expected { t.Errorf("[%s][%d] %s returns %q", e.testname, j, fn, cv) }
+		}
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestText(t *testing.T) {
+	fn := "rfc2045.Text"
+	cx := 0
+	ae := []struct {
+		testname string; message string; expected string; flags uint8
+	}{
+		{"UTF-8", "Content-Type: text/plain; charset=utf-8\n\nNyaan \xe2\x9c\x8c", "Nyaan ✌", PartEightBit},
+		{"ISO-8859-1", "Content-Type: text/plain; charset=ISO-8859-1\n\nCaf\xe9", "Café", PartEightBit},
+		{"Windows-1252", "Content-Type: text/plain; charset=windows-1252\n\n\x93Caf\xe9\x94", "“Café”", PartEightBit},
+		{"Wrong US-ASCII", "Content-Type: text/plain; charset=us-ascii\nContent-Transfer-Encoding: 8bit\n\nNyaan \xe2\x9c\x8c", "Nyaan ✌", PartWrongCharset},
+		{"Wrong UTF-8", "Content-Type: text/plain; charset=utf-8\nContent-Transfer-Encoding: 8bit\n\nCaf\xe9", "Café", PartWrongCharset},
+		{"ISO-2022-JP", "Content-Type: text/plain; charset=iso-2022-jp\n\n\x1b$B$M$3\x1b(B", "\x1b$B$M$3\x1b(B", 0},
+	}
+	for _, e := range ae {
+		cp, _  := NewReader(strings.NewReader(e.message)).NextPart()
+		cv, ce := cp.Text()
+		cx++; if ce != nil           { t.Errorf("[%s] %s() returns error %v", e.testname, fn, ce) }
+		cx++; if cv != e.expected    { t.Errorf("[%s] %s() returns %q, expected %q", e.testname, fn, cv, e.expected) }
+		cx++; if cp.Flags != e.flags { t.Errorf("[%s] %s().Flags is %d, expected %d", e.testname, fn, cp.Flags, e.flags) }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
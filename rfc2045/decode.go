@@ -0,0 +1,144 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ____   ___  _  _  ____    ______                     _        
+//  _ __ / _| ___|___ \ / _ \| || || ___|  / /  _ \  ___  ___ ___   __| | ___ _ 
+// | '__| |_ / __| __) | | | | || ||___ \ / /| | | |/ _ \/ __/ _ \ / _` |/ _ (_)
+// | |  |  _| (__ / __/| |_| |__   _|__) / / | |_| |  __/ (_| (_) | (_| |  __/_ 
+// |_|  |_|  \___|_____|\___/   |_||____/_/  |____/ \___|\___\___/ \__,_|\___(_)
+
+package rfc2045
+import "io"
+import "strings"
+import "unicode/utf8"
+import "encoding/base64"
+import "mime/quotedprintable"
+
+// decodeBody returns the reader decoding the body with Content-Transfer-Encoding.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc2045#section-6
+func decodeBody(part *Part, raw io.Reader) io.Reader {
+	switch part.Encoding {
+		case "base64":
+			return &tolerant{part: part, reader: base64.NewDecoder(base64.StdEncoding, &base64Filter{reader: raw})}
+		case "quoted-printable":
+			return &tolerant{part: part, reader: quotedprintable.NewReader(raw)}
+		case "7bit":
+			return &eightBit{part: part, reader: raw}
+		case "8bit", "binary":
+			return raw
+	}
+	part.Flags |= PartInvalidEncoding // x-uuencode or unknown encoding
+	return raw
+}
+
+// decodeCharset converts the decoded body to UTF-8.
+func decodeCharset(part *Part, data []byte) string {
+	charset := part.Charset()
+	if utf8.Valid(data) {
+		// UTF-8 or US-ASCII, the 8-bit data labeled as US-ASCII is dealt as UTF-8
+		if charset == "us-ascii" && isASCII(data) == false { part.Flags |= PartWrongCharset }
+		return string(data)
+	}
+
+	switch charset {
+		case "iso-8859-1", "latin1", "windows-1252", "cp1252", "us-ascii", "utf-8", "":
+			// The body which is not valid as UTF-8 is dealt as ISO-8859-1
+			if charset == "us-ascii" || charset == "utf-8" || charset == "" { part.Flags |= PartWrongCharset }
+			cv := strings.Builder{}
+			for _, e := range data {
+				// Each octet of ISO-8859-1 is the code point of Unicode
+				if e >= 0x80 && e <= 0x9f && charset != "iso-8859-1" && charset != "latin1" {
+					if r := cp1252[e - 0x80]; r != 0 { cv.WriteRune(r); continue }
+				}
+				cv.WriteRune(rune(e))
+			}
+			return cv.String()
+	}
+	return string(data)
+}
+
+// Characters of 0x80-0x9F in Windows-1252, 0 means undefined
+var cp1252 = [32]rune{
+	0x20ac, 0, 0x201a, 0x0192, 0x201e, 0x2026, 0x2020, 0x2021, 0x02c6, 0x2030, 0x0160, 0x2039, 0x0152, 0, 0x017d, 0,
+	0, 0x2018, 0x2019, 0x201c, 0x201d, 0x2022, 0x2013, 0x2014, 0x02dc, 0x2122, 0x0161, 0x203a, 0x0153, 0, 0x017e, 0x0178,
+}
+
+// isASCII returns true if the data consists of 7-bit octets only.
+func isASCII(data []byte) bool {
+	for _, e := range data { if e > 127 { return false } }
+	return true
+}
+
+// base64Filter removes characters out of the base64 alphabet and pads "=" at the end.
+type base64Filter struct {
+	reader io.Reader
+	count  int  // The number of characters in the alphabet
+	ended  bool // "=" or the end of the reader
+	held   bool // The last character is held back until the end of the reader is known
+	last   byte
+	pad    []byte
+}
+
+func (this *base64Filter) Read(p []byte) (int, error) {
+	if len(p) == 0 { return 0, nil }
+	for {
+		if this.ended {
+			// Pad "=" to the multiple of 4, the last character which is not decodable has been removed
+			if len(this.pad) == 0 { return 0, io.EOF }
+			n := copy(p, this.pad); this.pad = this.pad[n:]
+			return n, nil
+		}
+
+		n, nyaan := this.reader.Read(p)
+		k := 0
+		for _, e := range p[:n] {
+			// Keep only characters of the base64 alphabet
+			if e == '=' { this.ended = true; break }
+			if e >= 'A' && e <= 'Z' || e >= 'a' && e <= 'z' || e >= '0' && e <= '9' || e == '+' || e == '/' {
+				p[k] = e; k++; this.count++
+			}
+		}
+		if k > 0 {
+			// Hold back the last character: it is not decodable when the total is 4n+1 characters,
+			// which is unknown until the end of the reader even if it came in an earlier Read()
+			cv := p[k-1]
+			if this.held { copy(p[1:k], p[:k-1]); p[0] = this.last } else { k-- }
+			this.last = cv; this.held = true
+		}
+		if nyaan != nil && nyaan != io.EOF { return k, nyaan }
+		if nyaan == io.EOF || this.ended {
+			this.ended = true
+			if this.held && this.count % 4 != 1 { this.pad = append(this.pad, this.last) }
+			switch this.count % 4 {
+				case 2: this.pad = append(this.pad, '=', '=')
+				case 3: this.pad = append(this.pad, '=')
+			}
+			this.held = false
+		}
+		if k > 0 { return k, nil }
+	}
+}
+
+// tolerant returns io.EOF instead of a decoding error and sets PartInvalidEncoding.
+type tolerant struct {
+	part   *Part
+	reader io.Reader
+}
+
+func (this *tolerant) Read(p []byte) (int, error) {
+	n, nyaan := this.reader.Read(p)
+	if nyaan != nil && nyaan != io.EOF { this.part.Flags |= PartInvalidEncoding; return n, io.EOF }
+	return n, nyaan
+}
+
+// eightBit sets PartEightBit when the 7bit part includes 8-bit octets.
+type eightBit struct {
+	part   *Part
+	reader io.Reader
+}
+
+func (this *eightBit) Read(p []byte) (int, error) {
+	n, nyaan := this.reader.Read(p)
+	if isASCII(p[:n]) == false { this.part.Flags |= PartEightBit }
+	return n, nyaan
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ____   ___  _  _  ____  
+//  _ __ / _| ___|___ \ / _ \| || || ___| 
+// | '__| |_ / __| __) | | | | || ||___ \ 
+// | |  |  _| (__ / __/| |_| |__   _|__) |
+// |_|  |_|  \___|_____|\___/   |_||____/ 
+
+// Package "rfc2045" provides a MIME message reader walking nested multipart bodies with decoding
+// of Content-Transfer-Encoding, tolerant of broken bounce messages. https://datatracker.ietf.org/doc/html/rfc2045
+package rfc2045
+import "io"
+import "bytes"
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+const (
+	PartEightBit        = 1 << iota // 8-bit octets in the part of "Content-Transfer-Encoding: 7bit"
+	PartMissingBoundary             // The close-delimiter of the multipart is missing
+	PartNoBoundary                  // The multipart has no "boundary" parameter, dealt as a single part
+	PartInvalidEncoding             // Unknown Content-Transfer-Encoding or broken encoded data
+	PartWrongCharset                // The body is not encoded in the charset of "charset" parameter
+)
+
+// Part is a MIME entity: the message itself, a body part of the multipart, or an encapsulated message.
+type Part struct {
+	Header    *rfc5322.Header   // Header fields of the part
+	MediaType string            // Media type in lower case such as "message/delivery-status"
+	Params    map[string]string // Parameters of Content-Type, keys are in lower case
+	Encoding  string            // Content-Transfer-Encoding in lower case such as "base64"
+	Path      string            // Position in the message such as "2.1", "" is the message itself
+	Depth     int               // Depth of the nesting, 0 is the message itself
+	Parent    *Part             // The multipart or the message/rfc822 part including this part
+	Flags     uint8             // Flags such as PartMissingBoundary found in the part
+	boundary  string            // "boundary" parameter of the multipart
+	children  int               // The number of parts in this part
+	raw       *rawBody          // Body before decoding, the preamble of the multipart
+	body      io.Reader         // Decoded body returned from Body()
+}
+
+// IsMultipart returns true if the part is a multipart having the boundary.
+func (this *Part) IsMultipart() bool {
+	return this.boundary != ""
+}
+
+// IsMessage returns true if the part is an encapsulated message such as "message/rfc822". The header
+// of the encapsulated message is returned as the next part.
+func (this *Part) IsMessage() bool {
+	return this.raw == nil
+}
+
+// Charset returns the value of "charset" parameter in lower case, "us-ascii" for "text/*" without
+// the parameter.
+func (this *Part) Charset() string {
+	if cv := strings.ToLower(strings.TrimSpace(this.Params["charset"])); cv != "" { return cv }
+	if strings.HasPrefix(this.MediaType, "text/") { return "us-ascii" }
+	return ""
+}
+
+// Filename returns "filename" parameter of Content-Disposition or "name" parameter of Content-Type.
+func (this *Part) Filename() string {
+	_, params := rfc5322.ParseContentDisposition(this.Header.Get("Content-Disposition"))
+	if cv := params["filename"]; cv != "" { return cv }
+	return this.Params["name"]
+}
+
+// Body returns the reader of the body decoded with Content-Transfer-Encoding. The body of the
+// multipart is the preamble, the body of an encapsulated message is empty. The reader can be read
+// until the next call of NextPart().
+//   Returns:
+//     - (io.Reader): Decoded body.
+func (this *Part) Body() io.Reader {
+	if this.body != nil { return this.body }
+	switch {
+		case this.raw == nil:
+			this.body = bytes.NewReader(nil)
+		case this.IsMultipart():
+			this.body = this.raw
+		default:
+			this.body = decodeBody(this, this.raw)
+	}
+	return this.body
+}
+
+// Text returns the decoded body converted to UTF-8. The body in UTF-8, US-ASCII, ISO-8859-1 and
+// Windows-1252 is converted, the body in other charsets is returned as is.
+//   Returns:
+//     - (string): The body as a string.
+//     - (error):  An error occurred while reading the body.
+func (this *Part) Text() (string, error) {
+	data, nyaan := io.ReadAll(this.Body())
+	return decodeCharset(this, data), nyaan
+}
@@ -0,0 +1,225 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ____   ___  _  _  ____    ______                _             
+//  _ __ / _| ___|___ \ / _ \| || || ___|  / /  _ \ ___  __ _  __| | ___ _ __ _ 
+// | '__| |_ / __| __) | | | | || ||___ \ / /| |_) / _ \/ _` |/ _` |/ _ \ '__(_)
+// | |  |  _| (__ / __/| |_| |__   _|__) / / |  _ <  __/ (_| | (_| |  __/ |   _ 
+// |_|  |_|  \___|_____|\___/   |_||____/_/  |_| \_\___|\__,_|\__,_|\___|_|  (_)
+
+package rfc2045
+import "io"
+import "bufio"
+import "bytes"
+import "strconv"
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// Reader reads each part of a MIME message in order of appearance, depth-first.
+type Reader struct {
+	reader  *bufio.Reader
+	stack   []*Part   // Multiparts and encapsulated messages which are not closed
+	current *Part     // The part returned from NextPart() at the last time
+	opened  *Part     // The message/rfc822 part whose encapsulated message is not read yet
+	hit     *boundary // The delimiter line found at the last time
+	started bool      // The header of the message has been read
+	eof     bool      // Reached the end of the reader
+	nyaan   error     // The error returned from NextPart()
+}
+
+type boundary struct {
+	index   int  // Index of the multipart in Reader.stack
+	closing bool // The line is close-delimiter such as "--boundary--"
+}
+
+// NewReader returns a Reader reading the MIME message from r.
+//   Arguments:
+//     - r (io.Reader): The whole message including the header.
+//   Returns:
+//     - (*Reader): MIME message reader.
+func NewReader(r io.Reader) *Reader {
+	reader, ok := r.(*bufio.Reader); if ok == false { reader = bufio.NewReader(r) }
+	return &Reader{reader: reader, stack: []*Part{}}
+}
+
+// NextPart returns the next part. The first part is the message itself, each body part of multipart
+// and each encapsulated message in message/rfc822 part follow. The unread body of the previous part
+// is discarded.
+//   Returns:
+//     - (*Part): The next part.
+//     - (error): io.EOF when there is no more part, or an error returned from the reader.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc2046#section-5.1.1
+func (this *Reader) NextPart() (*Part, error) {
+	if this.nyaan != nil { return nil, this.nyaan }
+	if this.current != nil && this.current.raw != nil { io.Copy(io.Discard, this.current.raw) }
+	if this.nyaan != nil { return nil, this.nyaan }
+
+	for {
+		var parent *Part
+		switch {
+			case this.started == false:
+				// The header of the message
+				this.started = true
+
+			case this.opened != nil:
+				// The encapsulated message in the message/rfc822 part
+				parent = this.opened; this.opened = nil
+				if this.hit != nil || this.eof { continue }
+
+			case this.hit == nil:
+				// The end of the message: close-delimiters of the remaining multiparts are missing
+				for _, e := range this.stack { if e.IsMultipart() { e.Flags |= PartMissingBoundary } }
+				this.stack, this.current, this.nyaan = nil, nil, io.EOF
+				return nil, io.EOF
+
+			default:
+				// The delimiter line of the multipart, multiparts and messages in the multipart are
+				// closed even if their close-delimiters are missing
+				cv := *this.hit; this.hit = nil
+				for _, e := range this.stack[cv.index + 1:] { if e.IsMultipart() { e.Flags |= PartMissingBoundary } }
+				this.stack = this.stack[:cv.index + 1]
+
+				if cv.closing {
+					// Discard the epilogue after "--boundary--"
+					this.stack = this.stack[:cv.index]
+					io.Copy(io.Discard, &rawBody{reader: this})
+					if this.nyaan != nil { return nil, this.nyaan }
+					continue
+				}
+				parent = this.stack[cv.index]
+		}
+		this.current = this.readPart(parent)
+		if this.nyaan != nil && this.nyaan != io.EOF { return nil, this.nyaan }
+		return this.current, nil
+	}
+}
+
+// Walk calls the function for each part until NextPart() returns io.EOF or the function returns an error.
+//   Arguments:
+//     - fn (func(*Part) error): The function called with each part.
+//   Returns:
+//     - (error): An error returned from NextPart() except io.EOF or the function.
+func (this *Reader) Walk(fn func(*Part) error) error {
+	for {
+		part, nyaan := this.NextPart()
+		if nyaan == io.EOF { return nil }
+		if nyaan != nil    { return nyaan }
+		if nyaan = fn(part); nyaan != nil { return nyaan }
+	}
+}
+
+// readPart reads the header of the next part and prepares the body.
+func (this *Reader) readPart(parent *Part) *Part {
+	buffer := []byte{}
+	for {
+		// Read the header until the empty line, the delimiter line, or the end of the message
+		line, ok := this.readLine(); if ok == false { break }
+		if len(bytes.TrimRight(line, "\r\n")) == 0 { break }
+		buffer = append(buffer, line...)
+	}
+	header, _ := rfc5322.ReadHeader(bytes.NewReader(buffer))
+	thepart := &Part{Header: header, Parent: parent}
+
+	if parent != nil {
+		// Body part of the multipart or the encapsulated message
+		parent.children++
+		thepart.Depth = parent.Depth + 1
+		thepart.Path  = strconv.Itoa(parent.children); if parent.Path != "" { thepart.Path = parent.Path + "." + thepart.Path }
+	}
+
+	thepart.MediaType, thepart.Params = rfc5322.ParseContentType(header.Get("Content-Type"))
+	if thepart.MediaType == "" {
+		// RFC2046 5.1.5: the default Content-Type of the body part in multipart/digest is message/rfc822
+		thepart.MediaType = "text/plain"
+		if parent != nil && parent.MediaType == "multipart/digest" { thepart.MediaType = "message/rfc822" }
+	}
+	thepart.Encoding = "7bit"
+	for _, e := range rfc5322.Tokenize(header.Get("Content-Transfer-Encoding"), rfc5322.TSpecials) {
+		// The first token except comments such as "base64" of "BASE64 (comment)"
+		if e.Kind == rfc5322.TokenComment { continue }
+		thepart.Encoding = strings.ToLower(e.Value); break
+	}
+
+	switch {
+		case strings.HasPrefix(thepart.MediaType, "multipart/"):
+			// The body of the multipart is the preamble
+			thepart.raw = &rawBody{reader: this}
+			if cv := thepart.Params["boundary"]; cv != "" {
+				thepart.boundary = cv; this.stack = append(this.stack, thepart)
+
+			} else {
+				// There is no boundary: deal the body as a single part
+				thepart.Flags |= PartNoBoundary
+			}
+
+		case isEncapsulated(thepart):
+			// The header of the encapsulated message is returned as the next part
+			this.stack  = append(this.stack, thepart)
+			this.opened = thepart
+
+		default:
+			thepart.raw = &rawBody{reader: this}
+	}
+	return thepart
+}
+
+// readLine returns the next line including the line terminator. It returns false at a delimiter line
+// of the multipart being read or at the end of the message.
+func (this *Reader) readLine() ([]byte, bool) {
+	if this.hit != nil || this.eof || this.nyaan != nil { return nil, false }
+
+	line, nyaan := this.reader.ReadBytes('\n')
+	if len(line) == 0 {
+		// The end of the reader
+		this.eof = true; if nyaan != nil && nyaan != io.EOF { this.nyaan = nyaan }
+		return nil, false
+	}
+	if len(line) > 2 && line[0] == '-' && line[1] == '-' {
+		// Compare with the boundaries from the innermost multipart
+		for j := len(this.stack) - 1; j > -1; j-- {
+			cv := this.stack[j].boundary
+			if cv == "" || bytes.HasPrefix(line[2:], []byte(cv)) == false { continue }
+
+			// Transport padding (white spaces) after the boundary is allowed
+			rest := bytes.TrimRight(line[2 + len(cv):], " \t\r\n")
+			if len(rest) == 0          { this.hit = &boundary{index: j};                return nil, false }
+			if string(rest) == "--"    { this.hit = &boundary{index: j, closing: true}; return nil, false }
+		}
+	}
+	return line, true
+}
+
+// rawBody reads the body until the delimiter line. The line terminator just before the delimiter
+// line belongs to the delimiter.
+type rawBody struct {
+	reader *Reader
+	buffer []byte // Data to be returned from Read()
+	held   []byte // The line terminator of the last line
+	done   bool
+}
+
+func (this *rawBody) Read(p []byte) (int, error) {
+	for len(this.buffer) == 0 {
+		if this.done { return 0, io.EOF }
+
+		line, ok := this.reader.readLine()
+		if ok == false {
+			// Keep the line terminator of the last line only at the end of the message
+			this.done = true
+			if this.reader.nyaan != nil { return 0, this.reader.nyaan }
+			if this.reader.hit == nil   { this.buffer = this.held }
+			continue
+		}
+		content := bytes.TrimRight(line, "\r\n")
+		this.buffer = append(append([]byte{}, this.held...), content...)
+		this.held   = line[len(content):]
+	}
+	n := copy(p, this.buffer); this.buffer = this.buffer[n:]
+	return n, nil
+}
+
+// isEncapsulated returns true if the part is message/rfc822 or message/global which is not encoded.
+func isEncapsulated(part *Part) bool {
+	if part.MediaType != "message/rfc822" && part.MediaType != "message/global" { return false }
+	return part.Encoding == "7bit" || part.Encoding == "8bit" || part.Encoding == "binary"
+}
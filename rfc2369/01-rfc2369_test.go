@@ -0,0 +1,152 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc2369
+
+//  _____         _      __     __      ____  _____  __   ___  
+// |_   _|__  ___| |_   / / __ / _| ___|___ \|___ / / /_ / _ \ 
+//   | |/ _ \/ __| __| / / '__| |_ / __| __) | |_ \| '_ \ (_) |
+//   | |  __/\__ \ |_ / /| |  |  _| (__ / __/ ___) | (_) \__, |
+//   |_|\___||___/\__/_/ |_|  |_|  \___|_____|____/ \___/  /_/ 
+import "errors"
+import "strings"
+import "testing"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+func TestParseListID(t *testing.T) {
+	fn := "rfc2369.ParseListID"
+	cx := 0
+	ae := []struct {argument string; description string; id string}{
+		{"<neko.example.jp>", "", "neko.example.jp"},
+		{`"Neko list" <neko.example.jp>`, "Neko list", "neko.example.jp"},
+		{"Neko (comment) list <neko.example.jp>", "Neko list", "neko.example.jp"},
+		{"=?UTF-8?B?44Gt44GT?= <neko.example.jp>", "ねこ", "neko.example.jp"},
+		{"neko.example.jp", "", "neko.example.jp"},
+	}
+	for _, e := range ae {
+		cv := ParseListID(e.argument)
+		cx++; if cv == nil { t.Errorf("%s(%s) returns nil", fn, e.argument); continue }
+		cx++; if cv.Description != e.description || cv.ID != e.id { t.Errorf("%s(%s) returns %v", fn, e.argument, cv) }
+	}
+	for _, e := range []string{"", "<>", "Neko list", "neko"} {
+		cx++; if cv := ParseListID(e); cv != nil { t.Errorf("%s(%s) returns %v", fn, e, cv) }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParseURIList(t *testing.T) {
+	fn := "rfc2369.ParseURIList"
+	cx := 0
+
+	cv := ParseURIList("<mailto:neko-request@example.jp?subject=unsubscribe&body=Nyaan%20%2B1> (Unsubscribe),\r\n" +
+		" <https://example.jp/u?id=12&t=ab> (<http://example.jp/in-comment>), <mailto:a@example.jp,%20b@example.jp?To=c@example.jp>")
+	cx++; if len(cv) != 3 { t.Fatalf("%s() returns %d URIs", fn, len(cv)) }
+	cx++; if cv[0].IsMailto() == false || cv[0].URL != nil { t.Errorf("%s()[0] is %v", fn, cv[0]) }
+	cx++; if len(cv[0].Recipients) != 1 || cv[0].Recipients[0].Address != "neko-request@example.jp" { t.Errorf("%s()[0] is %v", fn, cv[0].Recipients) }
+	cx++; if cv[0].Headers["subject"] != "unsubscribe" || cv[0].Headers["body"] != "Nyaan +1" { t.Errorf("%s()[0] is %v", fn, cv[0].Headers) }
+	cx++; if cv[1].IsHTTPS() == false || cv[1].URL.Host != "example.jp" || cv[1].URL.Query().Get("t") != "ab" { t.Errorf("%s()[1] is %v", fn, cv[1]) }
+	cx++; if len(cv[2].Recipients) != 3 || cv[2].Recipients[2].Address != "c@example.jp" { t.Errorf("%s()[2] is %v", fn, cv[2].Recipients) }
+
+	cv = ParseURIList("<https://example.jp/u/\r\n 1234>")
+	cx++; if len(cv) != 1 || cv[0].Raw != "https://example.jp/u/1234" { t.Errorf("%s() returns %v", fn, cv) }
+	cv = ParseURIList("mailto:neko-request@example.jp, https://example.jp/u")
+	cx++; if len(cv) != 2 { t.Errorf("%s() returns %v", fn, cv) }
+	cv = ParseURIList("NO (posting is not allowed)")
+	cx++; if cv == nil || len(cv) != 0 { t.Errorf("%s() returns %v", fn, cv) }
+
+	for _, e := range []string{"<mailto:unsub@b+>", "<mailto:a@+>", "<mailto:unsub@b+,%20@b+,neko@example.jp>", "<mailto:+@+>", "<mailto:@>"} {
+		// Malformed addresses in the untrusted header
+		cv = ParseURIList(e)
+		cx++; if len(cv) != 1 || cv[0].IsMailto() == false { t.Errorf("%s(%s) returns %v", fn, e, cv); continue }
+		for _, f := range cv[0].Recipients { cx++; if f.Address != "neko@example.jp" { t.Errorf("%s(%s) returns %v", fn, e, f) } }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParse(t *testing.T) {
+	fn := "rfc2369.Parse"
+	cx := 0
+	ch, _ := rfc5322.ReadHeader(strings.NewReader(strings.Join([]string{
+		"Subject: Nyaan\r\n",
+		"List-Id: Neko list <neko.example.jp>\r\n",
+		"List-Help: <mailto:neko-request@example.jp?subject=help>\r\n",
+		"List-Unsubscribe: <https://example.jp/u/1>, <mailto:neko-request@example.jp?subject=unsubscribe>\r\n",
+		"List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n",
+		"List-Post: NO\r\n",
+		"List-Owner: <mailto:kijitora@example.jp>\r\n",
+		"\r\n",
+	}, "")))
+	cv := Parse(ch)
+	cx++; if cv == nil { t.Fatalf("%s() returns nil", fn) }
+	cx++; if cv.ID.ID != "neko.example.jp" { t.Errorf("%s().ID is %v", fn, cv.ID) }
+	cx++; if len(cv.Help) != 1 || len(cv.Unsubscribe) != 2 || len(cv.Owner) != 1 { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv.Post == nil || len(cv.Post) != 0 || cv.Subscribe != nil || cv.Archive != nil { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv.OneClick == false { t.Errorf("%s().OneClick is false", fn) }
+
+	// Round trip with AddHeaders()
+	cw := rfc5322.NewHeaderWriter()
+	cx++; if nyaan := cv.AddHeaders(cw); nyaan != nil { t.Fatalf("%s().AddHeaders() returns %s", fn, nyaan) }
+	ae := strings.Join([]string{
+		"List-Id: Neko list <neko.example.jp>\r\n",
+		"List-Help: <mailto:neko-request@example.jp?subject=help>\r\n",
+		"List-Unsubscribe: <https://example.jp/u/1>,\r\n <mailto:neko-request@example.jp?subject=unsubscribe>\r\n",
+		"List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n",
+		"List-Post: NO\r\n",
+		"List-Owner: <mailto:kijitora@example.jp>\r\n",
+	}, "")
+	cx++; if ce := string(cw.Bytes()); ce != ae { t.Errorf("%s().AddHeaders() writes\n%s, expected\n%s", fn, ce, ae) }
+
+	ch, _ = rfc5322.ReadHeader(strings.NewReader("Subject: Nyaan\r\n\r\n"))
+	cx++; if cv := Parse(ch); cv != nil { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv := Parse(nil); cv != nil { t.Errorf("%s(nil) returns %v", fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestFormat(t *testing.T) {
+	fn := "rfc2369.Format"
+	cx := 0
+	cx++; if cv := FormatListID("", "<neko.example.jp>"); cv != "<neko.example.jp>" { t.Errorf("%sListID() returns %s", fn, cv) }
+	cx++; if cv := FormatListID("Neko, Nyaan", "neko.example.jp"); cv != `"Neko, Nyaan" <neko.example.jp>` { t.Errorf("%sListID() returns %s", fn, cv) }
+	cx++; if cv := FormatURIList("https://example.jp/u", "", "<mailto:neko@example.jp>"); cv != "<https://example.jp/u>, <mailto:neko@example.jp>" {
+		t.Errorf("%sURIList() returns %s", fn, cv)
+	}
+
+	cv := MailtoURI("neko-request@example.jp", map[string]string{"Subject": "unsubscribe me", "body": "a&b=c?"})
+	cx++; if cv != "mailto:neko-request@example.jp?body=a%26b%3Dc%3F&subject=unsubscribe%20me" { t.Errorf("MailtoURI() returns %s", cv) }
+	cw := ParseURI(cv)
+	cx++; if cw.Headers["subject"] != "unsubscribe me" || cw.Headers["body"] != "a&b=c?" { t.Errorf("ParseURI(%s) returns %v", cv, cw.Headers) }
+	cx++; if cv := MailtoURI("neko@example.jp", nil); cv != "mailto:neko@example.jp" { t.Errorf("MailtoURI() returns %s", cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestCheckBulkSender(t *testing.T) {
+	fn := "rfc2369.CheckBulkSender"
+	cx := 0
+	signature := "DKIM-Signature: v=1; a=rsa-sha256; d=example.jp; s=neko; h=From:To:Subject:List-Unsubscribe:List-Unsubscribe-Post; bh=AAA=; b=BBB=\r\n"
+	ae := []struct {testname string; fields []string; expected []error}{
+		{"Valid", []string{signature, "List-Unsubscribe: <https://example.jp/u/1>, <mailto:u@example.jp>\r\n", "List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n"}, []error{}},
+		{"No List-Unsubscribe", []string{signature}, []error{ErrNoListUnsubscribe}},
+		{"mailto only", []string{signature, "List-Unsubscribe: <mailto:u@example.jp>\r\n", "List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n"}, []error{ErrNoHTTPSURI}},
+		{"No Post", []string{signature, "List-Unsubscribe: <http://example.jp/u/1>\r\n"}, []error{ErrNoHTTPSURI, ErrNoOneClick}},
+		{"Not signed", []string{"List-Unsubscribe: <https://example.jp/u/1>\r\n", "List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n",
+			"DKIM-Signature: v=1; a=rsa-sha256; d=example.jp; s=neko; h=From:List-Unsubscribe; bh=AAA=; b=BBB=\r\n"}, []error{ErrNotSigned}},
+		{"Duplicated", []string{signature, "List-Unsubscribe: <https://example.jp/u/1>\r\n", "List-Unsubscribe: <https://example.jp/u/2>\r\n",
+			"List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n"}, []error{ErrDuplicatedField}},
+		{"Case-insensitive", []string{signature, "List-Unsubscribe: <https://example.jp/u/1>\r\n", "List-Unsubscribe-Post: list-unsubscribe=one-click\r\n"}, []error{}},
+		{"Malformed mailto", []string{signature, "List-Unsubscribe: <mailto:unsub@b+>, <https://example.jp/u/1>\r\n", "List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n"}, []error{}},
+	}
+	for _, e := range ae {
+		ch, _ := rfc5322.ReadHeader(strings.NewReader("From: neko@example.jp\r\n" + strings.Join(e.fields, "") + "\r\n"))
+		cv := CheckBulkSender(ch)
+		cx++; if len(cv) != len(e.expected) { t.Errorf("[%s] %s() returns %v, expected %v", e.testname, fn, cv, e.expected); continue }
+		for j := range cv {
+			cx++; if errors.Is(cv[j], e.expected[j]) == false { t.Errorf("[%s] %s() returns %v, expected %v", e.testname, fn, cv, e.expected) }
+		}
+	}
+	cx++; if cv := CheckBulkSender(nil); len(cv) != 1 { t.Errorf("%s(nil) returns %v", fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ____  _____  __   ___    ______ _               _      
+//  _ __ / _| ___|___ \|___ / / /_ / _ \  / / ___| |__   ___  ___| | ___ 
+// | '__| |_ / __| __) | |_ \| '_ \ (_) |/ / |   | '_ \ / _ \/ __| |/ (_)
+// | |  |  _| (__ / __/ ___) | (_) \__, / /| |___| | | |  __/ (__|   < _ 
+// |_|  |_|  \___|_____|____/ \___/  /_/_/  \____|_| |_|\___|\___|_|\_(_)
+
+package rfc2369
+import "errors"
+import "strings"
+import "libsisimai.org/mailer-goemon/dkim"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+var (
+	ErrNoListUnsubscribe = errors.New("rfc2369: List-Unsubscribe does not exist")
+	ErrDuplicatedField   = errors.New("rfc2369: List-Unsubscribe or List-Unsubscribe-Post appears more than once")
+	ErrNoHTTPSURI        = errors.New("rfc2369: List-Unsubscribe does not have an HTTPS URI")
+	ErrNoOneClick        = errors.New("rfc2369: List-Unsubscribe-Post is not List-Unsubscribe=One-Click")
+	ErrNotSigned         = errors.New("rfc2369: no DKIM-Signature signs List-Unsubscribe and List-Unsubscribe-Post")
+)
+
+// CheckBulkSender checks the list header fields required for bulk senders by Gmail and Yahoo: the
+// one-click unsubscription of RFC8058 with an HTTPS URI, and a DKIM signature which covers both
+// List-Unsubscribe and List-Unsubscribe-Post. DKIM signatures are not verified cryptographically.
+//   Arguments:
+//     - header (*rfc5322.Header): Header section of the message to be sent.
+//   Returns:
+//     - ([]error): The requirements not satisfied, empty if the message satisfies all of them.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8058#section-3.1
+//     - https://support.google.com/a/answer/81126
+//     - https://senders.yahooinc.com/best-practices/
+func CheckBulkSender(header *rfc5322.Header) []error {
+	if header == nil { return []error{ErrNoListUnsubscribe} }
+
+	unsubscribe := header.FieldsOf("List-Unsubscribe")
+	postfields  := header.FieldsOf("List-Unsubscribe-Post")
+	if len(unsubscribe) == 0 { return []error{ErrNoListUnsubscribe} }
+
+	problems := []error{}
+	if len(unsubscribe) > 1 || len(postfields) > 1 { problems = append(problems, ErrDuplicatedField) }
+
+	https := false
+	for _, e := range ParseURIList(unsubscribe[0].Value) { if e.IsHTTPS() { https = true; break } }
+	if https == false { problems = append(problems, ErrNoHTTPSURI) }
+	if len(postfields) == 0 || strings.EqualFold(strings.TrimSpace(postfields[0].Value), OneClick) == false { problems = append(problems, ErrNoOneClick) }
+
+	signed := false
+	for _, e := range header.Values("DKIM-Signature") {
+		// Any DKIM-Signature which signs both fields
+		cv, nyaan := dkim.ParseSignature(e); if nyaan != nil { continue }
+		if cv.Signs("List-Unsubscribe") && cv.Signs("List-Unsubscribe-Post") { signed = true; break }
+	}
+	if signed == false { problems = append(problems, ErrNotSigned) }
+	return problems
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ____  _____  __   ___    _______                          _     
+//  _ __ / _| ___|___ \|___ / / /_ / _ \  / /  ___|__  _ __ _ __ ___   __ _| |_ _ 
+// | '__| |_ / __| __) | |_ \| '_ \ (_) |/ /| |_ / _ \| '__| '_ ` _ \ / _` | __(_)
+// | |  |  _| (__ / __/ ___) | (_) \__, / / |  _| (_) | |  | | | | | | (_| | |_ _ 
+// |_|  |_|  \___|_____|____/ \___/  /_/_/  |_|  \___/|_|  |_| |_| |_|\__,_|\__(_)
+
+package rfc2369
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// FormatListID returns the field body of List-Id header.
+//   Arguments:
+//     - description (string): Description of the list such as "Neko list", may be empty.
+//     - id (string):          List identifier such as "neko.example.jp".
+//   Returns:
+//     - (string): Field body such as `"Neko list" <neko.example.jp>`.
+func FormatListID(description, id string) string {
+	id = strings.Trim(strings.TrimSpace(id), "<>")
+	if strings.TrimSpace(description) == "" { return "<" + id + ">" }
+	return rfc5322.FormatAddress(id, description)
+}
+
+// FormatURIList returns the field body of List-Unsubscribe and other RFC2369 fields.
+//   Arguments:
+//     - uris (...string): URIs such as "https://example.jp/u/1" and MailtoURI().
+//   Returns:
+//     - (string): Field body such as "<https://example.jp/u/1>, <mailto:neko-request@example.jp>".
+func FormatURIList(uris ...string) string {
+	list := []string{}
+	for _, e := range uris {
+		if e = strings.Trim(strings.TrimSpace(e), "<>"); e != "" { list = append(list, "<" + e + ">") }
+	}
+	return strings.Join(list, ", ")
+}
+
+// AddHeaders adds the list header fields to the header writer. List-Post is "NO" when Post is
+// not nil and is empty.
+//   Arguments:
+//     - writer (*rfc5322.HeaderWriter): Header writer.
+//   Returns:
+//     - (error): An error returned from HeaderWriter.Add().
+func (this *List) AddHeaders(writer *rfc5322.HeaderWriter) error {
+	if this.ID != nil {
+		if nyaan := writer.Add("List-Id", FormatListID(this.ID.Description, this.ID.ID)); nyaan != nil { return nyaan }
+	}
+
+	fields := []struct {name string; uris []*URI}{
+		{"List-Help", this.Help}, {"List-Unsubscribe", this.Unsubscribe}, {"List-Subscribe", this.Subscribe},
+		{"List-Post", this.Post}, {"List-Owner", this.Owner}, {"List-Archive", this.Archive},
+	}
+	for _, e := range fields {
+		// Each field of the URI list
+		if e.uris == nil { continue }
+		texts := []string{}
+		for _, f := range e.uris { texts = append(texts, f.Raw) }
+
+		value := FormatURIList(texts...)
+		if value == "" && e.name == "List-Post" { value = "NO" }
+		if value == "" { continue }
+		if nyaan := writer.Add(e.name, value); nyaan != nil { return nyaan }
+
+		if e.name == "List-Unsubscribe" && this.OneClick {
+			if nyaan := writer.Add("List-Unsubscribe-Post", OneClick); nyaan != nil { return nyaan }
+		}
+	}
+	return nil
+}
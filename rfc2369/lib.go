@@ -0,0 +1,134 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ____  _____  __   ___  
+//  _ __ / _| ___|___ \|___ / / /_ / _ \ 
+// | '__| |_ / __| __) | |_ \| '_ \ (_) |
+// | |  |  _| (__ / __/ ___) | (_) \__, |
+// |_|  |_|  \___|_____|____/ \___/  /_/ 
+
+// Package "rfc2369" provides parsers and generators of the list header fields: List-Help,
+// List-Unsubscribe, List-Subscribe, List-Post, List-Owner, List-Archive (RFC2369), List-Id (RFC2919),
+// and List-Unsubscribe-Post (RFC8058). https://datatracker.ietf.org/doc/html/rfc2369
+package rfc2369
+import "mime"
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// OneClick is the only value of List-Unsubscribe-Post defined in RFC8058
+const OneClick = "List-Unsubscribe=One-Click"
+
+// List is the list header fields of a message.
+type List struct {
+	ID          *ListID // List-Id, nil when the field does not exist
+	Help        []*URI  // List-Help
+	Unsubscribe []*URI  // List-Unsubscribe
+	Subscribe   []*URI  // List-Subscribe
+	Post        []*URI  // List-Post, empty when the value is "NO"
+	Owner       []*URI  // List-Owner
+	Archive     []*URI  // List-Archive
+	OneClick    bool    // true if List-Unsubscribe-Post is "List-Unsubscribe=One-Click"
+}
+
+// ListID is a parsed List-Id field such as `"Neko list" <neko.example.jp>`.
+type ListID struct {
+	Description string // The phrase before the list identifier, RFC2047 encoded-words are decoded
+	ID          string // The list identifier such as "neko.example.jp"
+}
+
+// Parse reads the list header fields from the header section.
+//   Arguments:
+//     - header (*rfc5322.Header): Header section read by rfc5322.ReadHeader().
+//   Returns:
+//     - (*List): List header fields, nil if the header has no list header field.
+func Parse(header *rfc5322.Header) *List {
+	if header == nil { return nil }
+
+	list, found := &List{}, false
+	for _, e := range header.Fields {
+		// Each list header field, the first one wins when duplicated
+		switch strings.ToLower(e.Name) {
+			case "list-id":               if list.ID == nil          { list.ID = ParseListID(e.Value) }
+			case "list-help":             if list.Help == nil        { list.Help = ParseURIList(e.Value) }
+			case "list-unsubscribe":      if list.Unsubscribe == nil { list.Unsubscribe = ParseURIList(e.Value) }
+			case "list-subscribe":        if list.Subscribe == nil   { list.Subscribe = ParseURIList(e.Value) }
+			case "list-post":             if list.Post == nil        { list.Post = ParseURIList(e.Value) }
+			case "list-owner":            if list.Owner == nil       { list.Owner = ParseURIList(e.Value) }
+			case "list-archive":          if list.Archive == nil     { list.Archive = ParseURIList(e.Value) }
+			case "list-unsubscribe-post": list.OneClick = strings.EqualFold(strings.TrimSpace(e.Value), OneClick)
+			default: continue
+		}
+		found = true
+	}
+	if found == false { return nil }
+	return list
+}
+
+// ParseListID parses the field body of List-Id header.
+//   Arguments:
+//     - value (string): Field body such as `"Neko list" <neko.example.jp>`.
+//   Returns:
+//     - (*ListID): List identifier and the description, nil if the value has no list identifier.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc2919#section-3
+func ParseListID(value string) *ListID {
+	//   list-id-header = "List-ID:" [phrase] "<" list-id ">" CRLF
+	//   list-id        = list-label "." list-id-namespace
+	p1 := strings.LastIndexByte(value, '<')
+	p2 := strings.LastIndexByte(value, '>')
+	if p1 < 0 || p2 < p1 {
+		// Broken List-Id without angle brackets such as "neko.example.jp"
+		cv := strings.TrimSpace(value)
+		if cv == "" || strings.IndexByte(cv, '.') < 1 || strings.ContainsAny(cv, " \t\"()<>") { return nil }
+		return &ListID{ID: cv}
+	}
+
+	listid := &ListID{ID: strings.TrimSpace(value[p1 + 1:p2])}
+	if listid.ID == "" { return nil }
+
+	phrase := []string{}
+	for _, e := range rfc5322.Tokenize(value[:p1], rfc5322.Specials) {
+		// The phrase: atoms and quoted strings, comments are removed
+		if e.Kind == rfc5322.TokenComment { continue }
+		phrase = append(phrase, e.Value)
+	}
+	listid.Description = strings.Join(phrase, " ")
+	if cv, nyaan := new(mime.WordDecoder).DecodeHeader(listid.Description); nyaan == nil { listid.Description = cv }
+	return listid
+}
+
+// ParseURIList parses the field body of List-Help, List-Unsubscribe, and other RFC2369 fields.
+//   Arguments:
+//     - value (string): Field body such as "<mailto:neko-request@example.jp?subject=unsubscribe>, <https://example.jp/u>".
+//   Returns:
+//     - ([]*URI): URIs in order of appearance, empty for "NO" of List-Post.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc2369#section-2
+func ParseURIList(value string) []*URI {
+	uris  := []*URI{}
+	texts := []string{}
+	depth := 0
+	inuri := false
+	cv    := strings.Builder{}
+
+	for j := 0; j < len(value); j++ {
+		// Pick URIs enclosed in angle brackets out of comments, white spaces in a URI are removed
+		c := value[j]
+		switch {
+			case inuri && c == '>':  texts = append(texts, cv.String()); cv.Reset(); inuri = false
+			case inuri:              if c != ' ' && c != '\t' && c != '\r' && c != '\n' { cv.WriteByte(c) }
+			case c == '\\' && depth > 0: j++
+			case c == '(':           depth++
+			case c == ')':           if depth > 0 { depth-- }
+			case c == '<' && depth == 0: inuri = true
+		}
+	}
+
+	if len(texts) == 0 {
+		// Broken field without angle brackets such as "mailto:neko-request@example.jp, https://example.jp/u"
+		for _, e := range strings.Split(value, ",") {
+			if e = strings.TrimSpace(e); strings.IndexByte(e, ':') > 0 && strings.ContainsAny(e, " \t()") == false { texts = append(texts, e) }
+		}
+	}
+	for _, e := range texts { if uri := ParseURI(e); uri != nil { uris = append(uris, uri) } }
+	return uris
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ____  _____  __   ___    ___   _ ____  ___   
+//  _ __ / _| ___|___ \|___ / / /_ / _ \  / / | | |  _ \|_ _|_ 
+// | '__| |_ / __| __) | |_ \| '_ \ (_) |/ /| | | | |_) || |(_)
+// | |  |  _| (__ / __/ ___) | (_) \__, / / | |_| |  _ < | | _ 
+// |_|  |_|  \___|_____|____/ \___/  /_/_/   \___/|_| \_\___(_)
+
+package rfc2369
+import "fmt"
+import "sort"
+import "strings"
+import "net/url"
+import "libsisimai.org/mailer-goemon/address"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// URI is a URI in the list header fields.
+type URI struct {
+	Raw        string                  // The URI as it appears such as "mailto:neko-request@example.jp?subject=unsubscribe"
+	Scheme     string                  // Scheme in lower case such as "mailto" or "https"
+	Recipients []*address.EmailAddress // Recipients of the mailto URI including "to" hfields
+	Headers    map[string]string       // hfields of the mailto URI such as {"subject": "unsubscribe"}, keys are in lower case
+	URL        *url.URL                // Parsed URI of the scheme other than mailto, nil for mailto
+}
+
+// IsMailto returns true if the scheme of the URI is "mailto".
+func (this *URI) IsMailto() bool { return this.Scheme == "mailto" }
+
+// IsHTTPS returns true if the scheme of the URI is "https".
+func (this *URI) IsHTTPS() bool { return this.Scheme == "https" }
+
+// ParseURI parses a URI of the list header fields. A mailto URI is parsed as RFC6068, the others
+// are parsed by net/url.
+//   Arguments:
+//     - text (string): URI without angle brackets such as "mailto:neko-request@example.jp?subject=help".
+//   Returns:
+//     - (*URI): Parsed URI, nil if the text is not a URI.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc6068
+func ParseURI(text string) *URI {
+	p := strings.IndexByte(text, ':'); if p < 1 { return nil }
+	uri := &URI{Raw: text, Scheme: strings.ToLower(text[:p]), Recipients: []*address.EmailAddress{}, Headers: map[string]string{}}
+
+	if uri.Scheme != "mailto" {
+		// https, http, ftp, and other schemes
+		cv, nyaan := url.Parse(text); if nyaan != nil { return nil }
+		uri.URL = cv
+		return uri
+	}
+
+	//   mailtoURI = "mailto:" [ to ] [ hfields ]
+	//   to        = addr-spec *("%2C" addr-spec )
+	//   hfields   = "?" hfield *( "&" hfield )
+	to, hfields, _ := strings.Cut(text[p + 1:], "?")
+	uri.addRecipients(to)
+	for _, e := range strings.Split(hfields, "&") {
+		// Each hfield such as "subject=unsubscribe", the first one wins except "to"
+		name, value, _ := strings.Cut(e, "=")
+		if name = strings.ToLower(unescape(name)); name == "" { continue }
+		if name == "to" { uri.addRecipients(value); continue }
+		if _, ok := uri.Headers[name]; ok == false { uri.Headers[name] = unescape(value) }
+	}
+	return uri
+}
+
+// MailtoURI returns a mailto URI with the hfields in order of the name.
+//   Arguments:
+//     - email (string):              Email address such as "neko-request@example.jp".
+//     - hfields (map[string]string): hfields such as {"subject": "unsubscribe"}.
+//   Returns:
+//     - (string): mailto URI such as "mailto:neko-request@example.jp?subject=unsubscribe".
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc6068#section-2
+func MailtoURI(email string, hfields map[string]string) string {
+	names := make([]string, 0, len(hfields))
+	for e := range hfields { names = append(names, e) }
+	sort.Slice(names, func(a, b int) bool { return strings.ToLower(names[a]) < strings.ToLower(names[b]) })
+
+	pairs := []string{}
+	for _, e := range names { pairs = append(pairs, escape(strings.ToLower(e)) + "=" + escape(hfields[e])) }
+	if len(pairs) == 0 { return "mailto:" + escape(email) }
+	return "mailto:" + escape(email) + "?" + strings.Join(pairs, "&")
+}
+
+// addRecipients appends the email addresses separated by "," to Recipients.
+func (this *URI) addRecipients(to string) {
+	for _, e := range strings.Split(unescape(to), ",") {
+		// Do not pass a malformed address in the untrusted header to address.Rise()
+		e = strings.TrimSpace(e); if rfc5322.IsEmailAddress(e) == false { continue }
+		if cv := address.Rise([3]string{e, "", ""}); cv != nil { this.Recipients = append(this.Recipients, cv) }
+	}
+}
+
+// unescape decodes "%XX" of the mailto URI, "+" is not a white space.
+func unescape(text string) string {
+	if cv, nyaan := url.PathUnescape(text); nyaan == nil { return cv }
+	return text
+}
+
+// escape encodes characters other than unreserved and some-delims of RFC6068 except ",".
+func escape(text string) string {
+	cv := strings.Builder{}
+	for j := 0; j < len(text); j++ {
+		c := text[j]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("-._~!$'()*+;:@", c) > -1 {
+			cv.WriteByte(c); continue
+		}
+		cv.WriteString(fmt.Sprintf("%%%02X", c))
+	}
+	return cv.String()
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc3464
+
+//  _____         _      __     __      _____ _  _    __   _  _   
+// |_   _|__  ___| |_   / / __ / _| ___|___ /| || |  / /_ | || |  
+//   | |/ _ \/ __| __| / / '__| |_ / __| |_ \| || |_| '_ \| || |_ 
+//   | |  __/\__ \ |_ / /| |  |  _| (__ ___) |__   _| (_) |__   _|
+//   |_|\___||___/\__/_/ |_|  |_|  \___|____/   |_|  \___/   |_|  
+import "strings"
+import "testing"
+
+func TestParse(t *testing.T) {
+	fn := "rfc3464.Parse"
+	cx := 0
+
+	// Postfix
+	cv, nyaan := Parse(strings.NewReader("Reporting-MTA: dns; mx.example.jp\r\n" +
+		"X-Postfix-Queue-ID: 4TZ8p03CHfz1xyZ\r\n" +
+		"X-Postfix-Sender: rfc822; sironeko@example.jp\r\n" +
+		"Arrival-Date: Thu, 29 Apr 2010 23:34:45 +0900 (JST)\r\n\r\n" +
+		"Final-Recipient: rfc822; neko@example.org\r\n" +
+		"Original-Recipient: rfc822;neko@example.org\r\n" +
+		"Action: failed\r\n" +
+		"Status: 5.1.1\r\n" +
+		"Remote-MTA: dns; mx.example.org\r\n" +
+		"Diagnostic-Code: smtp; 550 5.1.1 <neko@example.org>: Recipient address rejected:\r\n" +
+		"    User unknown in local recipient table (in reply to RCPT TO command)\r\n\r\n" +
+		"Final-Recipient: rfc822; kijitora@example.org\r\n" +
+		"Action: delayed\r\n" +
+		"Status: 4.2.2\r\n" +
+		"Diagnostic-Code: smtp; 452 4.2.2 Mailbox full\r\n" +
+		"Will-Retry-Until: Sat, 1 May 2010 23:34:45 +0900\r\n"))
+	cx++; if nyaan != nil { t.Fatalf("%s() returns an error: %s", fn, nyaan) }
+	cx++; if cv.ReportingMTA.Type != "dns" || cv.ReportingMTA.Value != "mx.example.jp" { t.Errorf("%s().ReportingMTA is %v", fn, cv.ReportingMTA) }
+	cx++; if cv.ReportingMTA.String() != "dns; mx.example.jp" { t.Errorf("%s().ReportingMTA.String() is %s", fn, cv.ReportingMTA) }
+	cx++; if cv.ArrivalDate.Unix() != 1272551685 { t.Errorf("%s().ArrivalDate is %v", fn, cv.ArrivalDate) }
+	cx++; if cv.Fields.Get("X-Postfix-Queue-ID") != "4TZ8p03CHfz1xyZ" { t.Errorf("%s().Fields does not have X-Postfix-Queue-ID", fn) }
+	cx++; if cv.DSNGateway != nil { t.Errorf("%s().DSNGateway is %v", fn, cv.DSNGateway) }
+	cx++; if len(cv.Recipients) != 2 { t.Fatalf("%s() returns %d recipients", fn, len(cv.Recipients)) }
+
+	ce := cv.Recipients[0]
+	cx++; if ce.Address() != "neko@example.org" { t.Errorf("%s().Recipients[0].Address() is %s", fn, ce.Address()) }
+	cx++; if ce.OriginalRecipient.Value != "neko@example.org" { t.Errorf("%s().Recipients[0].OriginalRecipient is %v", fn, ce.OriginalRecipient) }
+	cx++; if ce.Action != ActionFailed || ce.Failed() == false { t.Errorf("%s().Recipients[0].Action is %s", fn, ce.Action) }
+	cx++; if ce.Status != "5.1.1" { t.Errorf("%s().Recipients[0].Status is %s", fn, ce.Status) }
+	cx++; if ce.RemoteMTA.Value != "mx.example.org" { t.Errorf("%s().Recipients[0].RemoteMTA is %v", fn, ce.RemoteMTA) }
+	cx++; if ce.DiagnosticCode.Type != "smtp" { t.Errorf("%s().Recipients[0].DiagnosticCode is %v", fn, ce.DiagnosticCode) }
+	cx++; if strings.HasSuffix(ce.DiagnosticCode.Value, "(in reply to RCPT TO command)") == false { t.Errorf("%s().Recipients[0].DiagnosticCode is %v", fn, ce.DiagnosticCode) }
+	cx++; if ce.ReplyCode != "550" { t.Errorf("%s().Recipients[0].ReplyCode is %s", fn, ce.ReplyCode) }
+	cx++; if ce.StatusCode != "5.1.1" { t.Errorf("%s().Recipients[0].StatusCode is %s", fn, ce.StatusCode) }
+	cx++; if ce.Command != "RCPT" { t.Errorf("%s().Recipients[0].Command is %s", fn, ce.Command) }
+
+	ce = cv.Recipients[1]
+	cx++; if ce.Address() != "kijitora@example.org" { t.Errorf("%s().Recipients[1].Address() is %s", fn, ce.Address()) }
+	cx++; if ce.Action != ActionDelayed || ce.Failed() { t.Errorf("%s().Recipients[1].Action is %s", fn, ce.Action) }
+	cx++; if ce.ReplyCode != "452" || ce.StatusCode != "4.2.2" { t.Errorf("%s().Recipients[1] codes are %s %s", fn, ce.ReplyCode, ce.StatusCode) }
+	cx++; if ce.Command != "" { t.Errorf("%s().Recipients[1].Command is %s", fn, ce.Command) }
+	cx++; if ce.WillRetryUntil.Unix() != 1272724485 { t.Errorf("%s().Recipients[1].WillRetryUntil is %v", fn, ce.WillRetryUntil) }
+	cx++; if ce.LastAttemptDate.IsZero() == false { t.Errorf("%s().Recipients[1].LastAttemptDate is %v", fn, ce.LastAttemptDate) }
+
+	// Without empty lines between blocks
+	cv, nyaan = Parse(strings.NewReader("Reporting-MTA: dns;mx.example.jp\n" +
+		"Original-Envelope-Id: NEKO-22\n" +
+		"Final-Recipient: RFC822; <neko@example.org>\n" +
+		"Action: Failed (bad destination mailbox address)\n" +
+		"Status: 5.1.1\n" +
+		"Original-Recipient: rfc822;kijitora@example.org\n" +
+		"Final-Recipient: rfc822;kijitora@example.org\n" +
+		"Action: failed\n" +
+		"Status: 5.2.1\n" +
+		"Last-Attempt-Date: Thu, 29 Apr 2010 23:34:45 +0900\n\n\n"))
+	cx++; if nyaan != nil { t.Fatalf("%s() returns an error: %s", fn, nyaan) }
+	cx++; if cv.OriginalEnvelopeID != "NEKO-22" { t.Errorf("%s().OriginalEnvelopeID is %s", fn, cv.OriginalEnvelopeID) }
+	cx++; if len(cv.Fields.Fields) != 2 { t.Errorf("%s().Fields has %d fields", fn, len(cv.Fields.Fields)) }
+	cx++; if len(cv.Recipients) != 2 { t.Fatalf("%s() returns %d recipients", fn, len(cv.Recipients)) }
+	cx++; if cv.Recipients[0].FinalRecipient.Type != "rfc822" { t.Errorf("%s().Recipients[0].FinalRecipient is %v", fn, cv.Recipients[0].FinalRecipient) }
+	cx++; if cv.Recipients[0].Address() != "neko@example.org" { t.Errorf("%s().Recipients[0].Address() is %s", fn, cv.Recipients[0].Address()) }
+	cx++; if cv.Recipients[0].Action != ActionFailed { t.Errorf("%s().Recipients[0].Action is %s", fn, cv.Recipients[0].Action) }
+	cx++; if cv.Recipients[0].DiagnosticCode != nil { t.Errorf("%s().Recipients[0].DiagnosticCode is %v", fn, cv.Recipients[0].DiagnosticCode) }
+	cx++; if cv.Recipients[1].Address() != "kijitora@example.org" { t.Errorf("%s().Recipients[1].Address() is %s", fn, cv.Recipients[1].Address()) }
+	cx++; if cv.Recipients[1].Status != "5.2.1" { t.Errorf("%s().Recipients[1].Status is %s", fn, cv.Recipients[1].Status) }
+	cx++; if cv.Recipients[1].LastAttemptDate.IsZero() { t.Errorf("%s().Recipients[1].LastAttemptDate is zero", fn) }
+
+	// message/global-delivery-status
+	cv, nyaan = Parse(strings.NewReader("Reporting-MTA: dns; mx.example.jp\r\n\r\n" +
+		"Original-Recipient: utf-8; \\x{732B}@example.jp\r\n" +
+		"Final-Recipient: utf-8; 猫@例え.jp\r\n" +
+		"Action: failed\r\n" +
+		"Status: 5.1.1\r\n" +
+		"Diagnostic-Code: smtp; 550 5.1.1 ユーザが存在しません\r\n"))
+	cx++; if nyaan != nil { t.Fatalf("%s() returns an error: %s", fn, nyaan) }
+	cx++; if len(cv.Recipients) != 1 { t.Fatalf("%s() returns %d recipients", fn, len(cv.Recipients)) }
+	cx++; if cv.Recipients[0].OriginalRecipient.Value != "猫@example.jp" { t.Errorf("%s().Recipients[0].OriginalRecipient is %v", fn, cv.Recipients[0].OriginalRecipient) }
+	cx++; if cv.Recipients[0].Address() != "猫@例え.jp" { t.Errorf("%s().Recipients[0].Address() is %s", fn, cv.Recipients[0].Address()) }
+	cx++; if cv.Recipients[0].StatusCode != "5.1.1" { t.Errorf("%s().Recipients[0].StatusCode is %s", fn, cv.Recipients[0].StatusCode) }
+
+	// No per-recipient fields
+	cv, nyaan = Parse(strings.NewReader("Reporting-MTA: dns; mx.example.jp\r\n\r\n"))
+	cx++; if nyaan != ErrNoRecipient { t.Errorf("%s() returns %v", fn, nyaan) }
+	cx++; if cv.ReportingMTA == nil { t.Errorf("%s().ReportingMTA is nil", fn) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestDecodeEmbeddedUnicode(t *testing.T) {
+	fn := "rfc3464.decodeEmbeddedUnicode"
+	cx := 0
+	ae := []struct {testname string; argument string; expected string}{
+		{"ASCII",    "neko@example.jp",                      "neko@example.jp"},
+		{"Kanji",    `\x{732B}@example.jp`,                  "猫@example.jp"},
+		{"Emoji",    `\x{1F408}\x{1F408}@example.jp`,        "🐈🐈@example.jp"},
+		{"Broken",   `\x{ZZ}@example.jp`,                    `\x{ZZ}@example.jp`},
+		{"Unclosed", `\x{732B@example.jp`,                   `\x{732B@example.jp`},
+	}
+	for _, e := range ae {
+		t.Run(e.testname, func(t *testing.T) {
+			cv := decodeEmbeddedUnicode(e.argument)
+			cx++; if cv != e.expected { t.Errorf("%s(%q) returns %q", fn, e.argument, cv) }
+		})
+	}
+	t.Logf("The number of tests = %d", cx)
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc3464
+
+//  _____         _      ____  __                                
+// |_   _|__  ___| |_   / /  \/  | ___  ___ ___  __ _  __ _  ___ 
+//   | |/ _ \/ __| __| / /| |\/| |/ _ \/ __/ __|/ _` |/ _` |/ _ \
+//   | |  __/\__ \ |_ / / | |  | |  __/\__ \__ \ (_| | (_| |  __/
+//   |_|\___||___/\__/_/  |_|  |_|\___||___/___/\__,_|\__, |\___|
+//                                                    |___/      
+import "io"
+import "time"
+import "bytes"
+import "errors"
+import "strings"
+import "testing"
+import "libsisimai.org/mailer-goemon/rfc2045"
+
+func sampleReport() *Report {
+	return &Report{
+		ReportingMTA: &TypedValue{"dns", "mx.example.jp"},
+		ArrivalDate:  time.Date(2010, 4, 29, 23, 34, 45, 0, time.FixedZone("JST", 9 * 3600)),
+		Recipients:   []*Recipient{
+			{
+				FinalRecipient: &TypedValue{"rfc822", "neko@example.org"},
+				Action:         ActionFailed,
+				Status:         "5.1.1",
+				RemoteMTA:      &TypedValue{"dns", "mx.example.org"},
+				DiagnosticCode: &TypedValue{"smtp", "550 5.1.1 <neko@example.org>: Recipient address rejected: User unknown in local recipient table"},
+			},
+			{
+				FinalRecipient: &TypedValue{"rfc822", "kijitora@example.org"},
+				Action:         ActionDelayed,
+				Status:         "4.2.2",
+				WillRetryUntil: time.Date(2010, 5, 1, 23, 34, 45, 0, time.FixedZone("JST", 9 * 3600)),
+			},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	fn := "rfc3464.Validate"
+	cx := 0
+
+	cx++; if nyaan := sampleReport().Validate(); nyaan != nil { t.Errorf("%s() returns %s", fn, nyaan) }
+	for _, e := range []Action{ActionDelivered, ActionRelayed, ActionExpanded} {
+		cv := sampleReport(); cv.Recipients = cv.Recipients[:1]; cv.Recipients[0].Action = e; cv.Recipients[0].Status = "2.0.0"
+		cx++; if nyaan := cv.Validate(); nyaan != nil { t.Errorf("%s() returns %s for %s", fn, nyaan, e) }
+	}
+
+	ae := []struct {testname string; modify func(*Report); expected error}{
+		{"No Reporting-MTA",   func(r *Report) { r.ReportingMTA = nil }, ErrNoReportingMTA},
+		{"No recipient",       func(r *Report) { r.Recipients = nil }, ErrNoRecipient},
+		{"No Final-Recipient", func(r *Report) { r.Recipients[1].FinalRecipient = nil }, ErrNoFinalRecipient},
+		{"Invalid Action",     func(r *Report) { r.Recipients[0].Action = "failure" }, ErrInvalidAction},
+		{"Invalid Status",     func(r *Report) { r.Recipients[0].Status = "5.1" }, ErrInvalidStatus},
+		{"Invalid Status 3",   func(r *Report) { r.Recipients[0].Status = "3.1.1" }, ErrInvalidStatus},
+		{"Failed 4.X.X",       func(r *Report) { r.Recipients[0].Status = "4.4.7" }, ErrActionMismatch},
+		{"Delayed 5.X.X",      func(r *Report) { r.Recipients[1].Status = "5.2.2" }, ErrActionMismatch},
+		{"Delivered 5.X.X",    func(r *Report) { r.Recipients[1].Action = ActionDelivered }, ErrActionMismatch},
+	}
+	for _, e := range ae {
+		t.Run(e.testname, func(t *testing.T) {
+			cv := sampleReport(); e.modify(cv)
+			nyaan := cv.Validate()
+			cx++; if errors.Is(nyaan, e.expected) == false { t.Errorf("%s() returns %v", fn, nyaan) }
+		})
+	}
+
+	cv := sampleReport(); cv.Recipients[1].Status = "5.2.2"
+	ce := &RecipientError{}
+	cx++; if errors.As(cv.Validate(), &ce) == false || ce.Index != 1 { t.Errorf("%s() does not return *RecipientError of #1", fn) }
+	cx++; if ce.Error() != "rfc3464: the class of Status does not match Action (recipient #1)" { t.Errorf("%s(): %s", fn, ce) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestFormat(t *testing.T) {
+	fn := "rfc3464.Format"
+	cx := 0
+
+	cv := sampleReport()
+	body, nyaan := cv.Format(false)
+	cx++; if nyaan != nil { t.Fatalf("%s() returns %s", fn, nyaan) }
+	cx++; if bytes.HasPrefix(body, []byte("Reporting-MTA: dns; mx.example.jp\r\nArrival-Date: Thu, 29 Apr 2010 23:34:45 +0900\r\n\r\n")) == false { t.Errorf("%s() returns %q", fn, body) }
+	cx++; if bytes.Contains(body, []byte("\r\nDiagnostic-Code: smtp; 550 5.1.1 <neko@example.org>: Recipient address\r\n rejected: User unknown")) == false { t.Errorf("%s() does not fold Diagnostic-Code: %q", fn, body) }
+	for _, e := range bytes.Split(body, []byte("\r\n")) {
+		cx++; if len(e) > 78 { t.Errorf("%s() returns a long line: %q", fn, e) }
+	}
+
+	// Round trip
+	ce, nyaan := Parse(bytes.NewReader(body))
+	cx++; if nyaan != nil { t.Fatalf("Parse() returns %s", nyaan) }
+	cx++; if len(ce.Recipients) != 2 { t.Fatalf("Parse() returns %d recipients", len(ce.Recipients)) }
+	cx++; if ce.ArrivalDate.Equal(cv.ArrivalDate) == false { t.Errorf("Parse().ArrivalDate is %v", ce.ArrivalDate) }
+	cx++; if ce.Recipients[0].DiagnosticCode.Value != cv.Recipients[0].DiagnosticCode.Value { t.Errorf("Parse().Recipients[0].DiagnosticCode is %v", ce.Recipients[0].DiagnosticCode) }
+	cx++; if ce.Recipients[0].StatusCode != "5.1.1" || ce.Recipients[0].ReplyCode != "550" { t.Errorf("Parse().Recipients[0] codes are wrong") }
+	cx++; if ce.Recipients[1].Action != ActionDelayed || ce.Recipients[1].WillRetryUntil.Equal(cv.Recipients[1].WillRetryUntil) == false { t.Errorf("Parse().Recipients[1] is wrong") }
+
+	// Extension fields of a parsed report are written after the standard fields
+	body, nyaan = ce.Format(false)
+	cx++; if nyaan != nil { t.Fatalf("%s() returns %s", fn, nyaan) }
+	cx++; if bytes.Count(body, []byte("Status: ")) != 2 { t.Errorf("%s() writes duplicated fields: %q", fn, body) }
+
+	ce, _ = Parse(strings.NewReader("Reporting-MTA: dns; mx.example.jp\r\nX-Postfix-Queue-ID: 4TZ8p03CHfz1xyZ\r\n\r\n" +
+		"Final-Recipient: rfc822; neko@example.org\r\nAction: failed\r\nStatus: 5.1.1\r\nX-Display-Name: Neko\r\n"))
+	body, _ = ce.Format(false)
+	cx++; if string(body) != "Reporting-MTA: dns; mx.example.jp\r\nX-Postfix-Queue-ID: 4TZ8p03CHfz1xyZ\r\n\r\n" +
+		"Final-Recipient: rfc822; neko@example.org\r\nAction: failed\r\nStatus: 5.1.1\r\nX-Display-Name: Neko\r\n" { t.Errorf("%s() returns %q", fn, body) }
+
+	// Non-ASCII address
+	cv = sampleReport(); cv.Recipients[0].FinalRecipient = &TypedValue{"rfc822", "猫@example.org"}
+	body, nyaan = cv.Format(false)
+	cx++; if nyaan != nil { t.Fatalf("%s() returns %s", fn, nyaan) }
+	cx++; if bytes.Contains(body, []byte("Final-Recipient: utf-8; \\x{732B}@example.org\r\n")) == false { t.Errorf("%s() returns %q", fn, body) }
+	body, _ = cv.Format(true)
+	cx++; if bytes.Contains(body, []byte("Final-Recipient: rfc822; 猫@example.org\r\n")) == false { t.Errorf("%s() returns %q", fn, body) }
+
+	cv = sampleReport(); cv.Recipients[0].DiagnosticCode.Value = "550 5.1.1 ユーザが存在しません"
+	_, nyaan = cv.Format(false)
+	cx++; if errors.Is(nyaan, ErrEightBit) == false { t.Errorf("%s() returns %v", fn, nyaan) }
+	_, nyaan = cv.Format(true)
+	cx++; if nyaan != nil { t.Errorf("%s(true) returns %v", fn, nyaan) }
+
+	cv = sampleReport(); cv.OriginalEnvelopeID = "NEKO\r\nBcc: neko@example.jp"
+	_, nyaan = cv.Format(false)
+	cx++; if nyaan == nil { t.Errorf("%s() does not return an error for CRLF", fn) }
+
+	// WriteFields() folds at the whitespace in the values without collapsing it
+	cb := bytes.Buffer{}
+	cw := strings.Repeat("Nyaan  ", 12) + "<neko@example.org>   Recipient\taddress rejected"
+	nyaan = WriteFields(&cb, [][2]string{{"X-Blank", "   "}, {"X-Empty", ""}, {"X-Spaces", "neko   nyaan "}, {"X-Long", cw}}, false)
+	cx++; if nyaan != nil { t.Fatalf("WriteFields() returns %s", nyaan) }
+	cx++; if strings.Contains(cb.String(), "X-Blank") || strings.Contains(cb.String(), "X-Empty") { t.Errorf("WriteFields() writes a blank field: %q", cb.String()) }
+	cx++; if strings.HasPrefix(cb.String(), "X-Spaces: neko   nyaan \r\n") == false { t.Errorf("WriteFields() returns %q", cb.String()) }
+	cx++; if strings.Contains(cb.String(), "X-Long: " + cw) { t.Errorf("WriteFields() does not fold X-Long: %q", cb.String()) }
+	cx++; if strings.HasSuffix(strings.ReplaceAll(cb.String(), "\r\n", ""), "X-Long: " + cw) == false { t.Errorf("WriteFields() changes X-Long: %q", cb.String()) }
+	for _, e := range strings.Split(strings.TrimSuffix(cb.String(), "\r\n"), "\r\n") {
+		cx++; if len(e) > 78 || strings.TrimSpace(e) == "" { t.Errorf("WriteFields() returns a wrong line: %q", e) }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestMessage(t *testing.T) {
+	fn := "rfc3464.Message.Bytes"
+	cx := 0
+	original := "From: kijitora@example.jp\nTo: neko@example.org\nSubject: Nyaan\n\nHello\n"
+
+	cv := NewMessage(sampleReport(), []byte(original))
+	cv.Boundary = "NEKO/DSN"
+	cv.Header.AddAddress("From", "MAILER-DAEMON@mx.example.jp")
+	cv.Header.AddAddress("To", "kijitora@example.jp")
+	cv.Header.Add("Subject", "Undelivered Mail Returned to Sender")
+
+	message, nyaan := cv.Bytes()
+	cx++; if nyaan != nil { t.Fatalf("%s() returns %s", fn, nyaan) }
+	cx++; if bytes.Contains(message, []byte("\r\nContent-Type: multipart/report; boundary=\"NEKO/DSN\";\r\n report-type=delivery-status\r\n")) == false { t.Errorf("%s() returns %q", fn, message) }
+	cx++; if bytes.Contains(message, []byte("\r\nAuto-Submitted: auto-replied\r\n")) == false { t.Errorf("%s() does not have Auto-Submitted", fn) }
+	cx++; if bytes.Contains(message, []byte("\r\nMIME-Version: 1.0\r\n")) == false { t.Errorf("%s() does not have MIME-Version", fn) }
+	cx++; if bytes.HasSuffix(message, []byte("\r\n--NEKO/DSN--\r\n")) == false { t.Errorf("%s() does not end with the boundary", fn) }
+	cx++; if len(cv.Header.Fields) != 3 { t.Errorf("%s() modifies Header", fn) }
+
+	parts := []*rfc2045.Part{}; bodies := []string{}
+	rfc2045.NewReader(bytes.NewReader(message)).Walk(func(p *rfc2045.Part) error {
+		data, _ := io.ReadAll(p.Body())
+		parts = append(parts, p); bodies = append(bodies, string(data))
+		return nil
+	})
+	cx++; if len(parts) != 4 { t.Fatalf("%s() has %d parts", fn, len(parts)) }
+	cx++; if parts[1].MediaType != "text/plain" || parts[1].Charset() != "us-ascii" { t.Errorf("%s() part 1 is %s", fn, parts[1].MediaType) }
+	cx++; if strings.Contains(bodies[1], "The message to <neko@example.org> could not be delivered: 5.1.1") == false { t.Errorf("%s() part 1 is %q", fn, bodies[1]) }
+	cx++; if parts[2].MediaType != "message/delivery-status" { t.Errorf("%s() part 2 is %s", fn, parts[2].MediaType) }
+	cx++; if parts[3].MediaType != "text/rfc822-headers" { t.Errorf("%s() part 3 is %s", fn, parts[3].MediaType) }
+	cx++; if bodies[3] != "From: kijitora@example.jp\r\nTo: neko@example.org\r\nSubject: Nyaan\r\n" { t.Errorf("%s() part 3 is %q", fn, bodies[3]) }
+
+	ce, nyaan := Parse(strings.NewReader(bodies[2]))
+	cx++; if nyaan != nil || len(ce.Recipients) != 2 { t.Errorf("Parse() returns %v", nyaan) }
+
+	// message/rfc822 and message/global-delivery-status
+	cv.Content = true; cv.Global = true; cv.Text = "猫"
+	message, _ = cv.Bytes()
+	cx++; if bytes.Contains(message, []byte("Content-Type: message/global-delivery-status\r\n")) == false { t.Errorf("%s() returns %q", fn, message) }
+	cx++; if bytes.Contains(message, []byte("Content-Type: message/global\r\n")) == false { t.Errorf("%s() returns %q", fn, message) }
+	cx++; if bytes.Contains(message, []byte("Content-Type: text/plain; charset=utf-8\r\nContent-Transfer-Encoding: 8bit\r\n\r\n猫\r\n")) == false { t.Errorf("%s() returns %q", fn, message) }
+	cx++; if bytes.Contains(message, []byte("\r\nHello\r\n")) == false { t.Errorf("%s() does not have the original body", fn) }
+
+	cv.Content = false; cv.Global = false
+	message, _ = cv.Bytes()
+	cx++; if bytes.Contains(message, []byte("\r\nHello\r\n")) { t.Errorf("%s() has the original body", fn) }
+
+	// Invalid report
+	cv.Report.Recipients[0].Status = "4.1.1"
+	_, nyaan = cv.Bytes()
+	cx++; if errors.Is(nyaan, ErrActionMismatch) == false { t.Errorf("%s() returns %v", fn, nyaan) }
+
+	// Random boundary
+	cv = NewMessage(sampleReport(), nil)
+	message, _ = cv.Bytes()
+	cx++; if bytes.Contains(message, []byte("/DSN\";\r\n report-type=delivery-status")) == false { t.Errorf("%s() returns %q", fn, message) }
+	cx++; if bytes.Contains(message, []byte("rfc822-headers")) { t.Errorf("%s() has the third part", fn) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestMultipartReport(t *testing.T) {
+	fn := "rfc3464.MultipartReport.Bytes"
+	cx := 0
+
+	cv := &MultipartReport{ReportType: "feedback-report", Text: "Nyaan", MediaType: "message/feedback-report", Report: []byte("Feedback-Type: abuse\n")}
+	message, nyaan := cv.Bytes()
+	cx++; if nyaan != nil { t.Fatalf("%s() returns %s", fn, nyaan) }
+	cx++; if bytes.Contains(message, []byte("/REPORT\";\r\n report-type=feedback-report\r\n")) == false { t.Errorf("%s() returns %q", fn, message) }
+	cx++; if bytes.Contains(message, []byte("Content-Type: message/feedback-report\r\n\r\nFeedback-Type: abuse\r\n")) == false { t.Errorf("%s() returns %q", fn, message) }
+	cx++; if bytes.Contains(message, []byte("rfc822-headers")) { t.Errorf("%s() has the third part", fn) }
+
+	// Existing MIME-Version is not added again
+	cv.Header = NewMessage(nil, nil).Header
+	cv.Header.Add("Mime-Version", "1.0")
+	message, _ = cv.Bytes()
+	cx++; if bytes.Count(message, []byte("1.0\r\n")) != 1 { t.Errorf("%s() returns %q", fn, message) }
+
+	cx++; if ce := GenerateBoundary("MDN"); strings.HasSuffix(ce, "/MDN") == false || len(ce) < 20 { t.Errorf("GenerateBoundary() returns %s", ce) }
+	cx++; if GenerateBoundary("DSN") == GenerateBoundary("DSN") { t.Errorf("GenerateBoundary() returns the same boundary") }
+
+	t.Logf("The number of tests = %d", cx)
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      _____ _  _    __   _  _     _______                          _     
+//  _ __ / _| ___|___ /| || |  / /_ | || |   / /  ___|__  _ __ _ __ ___   __ _| |_ _ 
+// | '__| |_ / __| |_ \| || |_| '_ \| || |_ / /| |_ / _ \| '__| '_ ` _ \ / _` | __(_)
+// | |  |  _| (__ ___) |__   _| (_) |__   _/ / |  _| (_) | |  | | | | | | (_| | |_ _ 
+// |_|  |_|  \___|____/   |_|  \___/   |_|/_/  |_|  \___/|_|  |_| |_| |_|\__,_|\__(_)
+
+package rfc3464
+import "time"
+import "bytes"
+import "slices"
+import "errors"
+import "strconv"
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc5322"
+import "libsisimai.org/mailer-goemon/smtp/status"
+
+var (
+	ErrNoReportingMTA   = errors.New("rfc3464: Reporting-MTA is missing")
+	ErrNoFinalRecipient = errors.New("rfc3464: Final-Recipient is missing")
+	ErrInvalidAction    = errors.New("rfc3464: Action is not failed, delayed, delivered, relayed, or expanded")
+	ErrInvalidStatus    = errors.New("rfc3464: Status is not a valid SMTP status code")
+	ErrActionMismatch   = errors.New("rfc3464: the class of Status does not match Action")
+	ErrEightBit         = errors.New("rfc3464: non-ASCII characters are not allowed in message/delivery-status")
+)
+
+// RecipientError is an error of the per-recipient fields returned from Validate() and Format().
+type RecipientError struct {
+	Index int   // Index of the recipient in Report.Recipients
+	Err   error // The error such as ErrInvalidStatus
+}
+
+func (this *RecipientError) Error() string {
+	return this.Err.Error() + " (recipient #" + strconv.Itoa(this.Index) + ")"
+}
+func (this *RecipientError) Unwrap() error { return this.Err }
+
+// The class of Status for each Action
+var actionClass = map[Action]byte{
+	ActionFailed: '5', ActionDelayed: '4', ActionDelivered: '2', ActionRelayed: '2', ActionExpanded: '2',
+}
+
+// Per-message fields and per-recipient fields written by Format() in this order, other fields in
+// Report.Fields and Recipient.Fields are written after them as extension fields.
+var messageFields   = []string{"original-envelope-id", "reporting-mta", "dsn-gateway", "received-from-mta", "arrival-date"}
+var recipientFields = []string{
+	"original-recipient", "final-recipient", "action", "status", "remote-mta", "diagnostic-code",
+	"last-attempt-date", "final-log-id", "will-retry-until",
+}
+
+// Validate checks the required fields, Status values, and the consistency of Action and the class
+// of Status: "failed" requires 5.X.X, "delayed" requires 4.X.X, and others require 2.X.X.
+//   Returns:
+//     - (error): ErrNoReportingMTA, ErrNoRecipient, or *RecipientError.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc3464#section-2.3.3
+//     - https://datatracker.ietf.org/doc/html/rfc3464#section-2.3.4
+func (this *Report) Validate() error {
+	if this.ReportingMTA == nil || this.ReportingMTA.Value == "" { return ErrNoReportingMTA }
+	if len(this.Recipients) == 0 { return ErrNoRecipient }
+
+	for j, e := range this.Recipients {
+		// Check each recipient
+		if e.FinalRecipient == nil || e.FinalRecipient.Value == "" { return &RecipientError{j, ErrNoFinalRecipient} }
+		class, ok := actionClass[e.Action]
+		if ok == false                    { return &RecipientError{j, ErrInvalidAction}  }
+		if status.Test(e.Status) == false { return &RecipientError{j, ErrInvalidStatus}  }
+		if e.Status[0] != class           { return &RecipientError{j, ErrActionMismatch} }
+	}
+	return nil
+}
+
+// Format returns the body of message/delivery-status or message/global-delivery-status part after
+// Validate(). Addresses including non-ASCII characters are written as the "utf-8" address type and
+// encoded as "\x{HHHH}" for message/delivery-status.
+//   Arguments:
+//     - global (bool): true for message/global-delivery-status which allows UTF-8 characters.
+//   Returns:
+//     - ([]byte): The body with CRLF line terminators.
+//     - (error):  An error returned from Validate(), rfc5322.ErrHeaderInjection, or ErrEightBit.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc3464#section-2.1
+//     - https://datatracker.ietf.org/doc/html/rfc6533#section-3
+func (this *Report) Format(global bool) ([]byte, error) {
+	if nyaan := this.Validate(); nyaan != nil { return nil, nyaan }
+
+	body := bytes.Buffer{}
+	list := [][2]string{
+		{"Original-Envelope-Id", this.OriginalEnvelopeID},
+		{"Reporting-MTA",        formatTypedValue(this.ReportingMTA, global)},
+		{"DSN-Gateway",          formatTypedValue(this.DSNGateway, global)},
+		{"Received-From-MTA",    formatTypedValue(this.ReceivedFromMTA, global)},
+		{"Arrival-Date",         formatDate(this.ArrivalDate)},
+	}
+	if nyaan := WriteFields(&body, append(list, ExtensionFields(this.Fields, messageFields)...), global); nyaan != nil { return nil, nyaan }
+
+	for j, e := range this.Recipients {
+		// Each per-recipient fields preceded by an empty line
+		list = [][2]string{
+			{"Original-Recipient", formatTypedValue(e.OriginalRecipient, global)},
+			{"Final-Recipient",    formatTypedValue(e.FinalRecipient, global)},
+			{"Action",             string(e.Action)},
+			{"Status",             e.Status},
+			{"Remote-MTA",         formatTypedValue(e.RemoteMTA, global)},
+			{"Diagnostic-Code",    formatTypedValue(e.DiagnosticCode, global)},
+			{"Last-Attempt-Date",  formatDate(e.LastAttemptDate)},
+			{"Final-Log-ID",       e.FinalLogID},
+			{"Will-Retry-Until",   formatDate(e.WillRetryUntil)},
+		}
+		body.WriteString("\r\n")
+		if nyaan := WriteFields(&body, append(list, ExtensionFields(e.Fields, recipientFields)...), global); nyaan != nil {
+			if nyaan == ErrEightBit || nyaan == rfc5322.ErrHeaderInjection { nyaan = &RecipientError{j, nyaan} }
+			return nil, nyaan
+		}
+	}
+	return body.Bytes(), nil
+}
+
+// WriteFields writes the fields which are not blank with folding long values at the whitespace in
+// the values, the values are written as is. It is used for message/delivery-status and
+// message/disposition-notification.
+//   Arguments:
+//     - body (*bytes.Buffer): Destination.
+//     - fields ([][2]string): Field names and values in order.
+//     - global (bool):        true for the global media types which allow UTF-8 characters.
+//   Returns:
+//     - (error): rfc5322.ErrHeaderInjection or ErrEightBit.
+func WriteFields(body *bytes.Buffer, fields [][2]string, global bool) error {
+	for _, e := range fields {
+		if strings.TrimSpace(e[1]) == "" { continue }
+		if strings.ContainsAny(e[1], "\r\n") { return rfc5322.ErrHeaderInjection }
+		if global == false && isASCII(e[1]) == false { return ErrEightBit }
+
+		line := e[0] + ":"; rest := " " + e[1]
+		for len(line) + len(rest) > 78 {
+			// Fold the line by inserting CRLF before the last whitespace within 78 characters, neither
+			// the folded line nor the continuation line consists only of whitespace
+			cut := -1; for j := 1; j < len(rest); j++ {
+				if rest[j] != ' ' && rest[j] != '\t' { continue }
+				if strings.TrimSpace(rest[:j]) == "" || strings.TrimSpace(rest[j:]) == "" { continue }
+				if cut > 0 && len(line) + j > 78 { break }
+				cut = j
+			}
+			if cut < 0 { break }
+			body.WriteString(line + rest[:cut] + "\r\n"); line = ""; rest = rest[cut:]
+		}
+		body.WriteString(line + rest + "\r\n")
+	}
+	return nil
+}
+
+// ExtensionFields returns fields in the header except the given field names.
+//   Arguments:
+//     - header (*rfc5322.Header): Header fields, nil returns an empty list.
+//     - names ([]string):         Lower-cased field names to be excluded such as "final-recipient".
+//   Returns:
+//     - ([][2]string): Field names and values in order.
+func ExtensionFields(header *rfc5322.Header, names []string) [][2]string {
+	fields := [][2]string{}
+	if header == nil { return fields }
+	for _, e := range header.Fields {
+		if e.Name == "" { continue }
+		if slices.Contains(names, strings.ToLower(e.Name)) { continue }
+		fields = append(fields, [2]string{e.Name, e.Value})
+	}
+	return fields
+}
+
+// formatTypedValue returns the field body such as "rfc822; neko@example.jp".
+func formatTypedValue(value *TypedValue, global bool) string {
+	if value == nil || value.Value == "" { return "" }
+
+	typedvalue := *value
+	if global == false && isASCII(typedvalue.Value) == false && (typedvalue.Type == "rfc822" || typedvalue.Type == "utf-8") {
+		// Non-ASCII address in message/delivery-status: "utf-8; \x{732B}@example.jp"
+		typedvalue.Type  = "utf-8"
+		typedvalue.Value = encodeEmbeddedUnicode(typedvalue.Value)
+	}
+	return typedvalue.String()
+}
+
+// encodeEmbeddedUnicode encodes non-ASCII characters as "\x{HHHH}" of utf-8-addr-xtext.
+//   Arguments:
+//     - text (string): Address such as "猫@example.jp".
+//   Returns:
+//     - (string): Encoded address such as "\x{732B}@example.jp".
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc6533#section-3
+func encodeEmbeddedUnicode(text string) string {
+	encoded := strings.Builder{}
+	for _, e := range text {
+		if e < 0x80 { encoded.WriteRune(e); continue }
+		cv := strings.ToUpper(strconv.FormatInt(int64(e), 16))
+		if len(cv) < 4 { cv = strings.Repeat("0", 4 - len(cv)) + cv }
+		encoded.WriteString(`\x{` + cv + "}")
+	}
+	return encoded.String()
+}
+
+// formatDate returns the date-time string, empty for the zero time.
+func formatDate(date1 time.Time) string {
+	if date1.IsZero() { return "" }
+	return rfc5322.FormatDate(date1)
+}
+
+// isASCII returns true if the text consists of US-ASCII characters.
+func isASCII(text string) bool {
+	for j := 0; j < len(text); j++ { if text[j] > 127 { return false } }
+	return true
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      _____ _  _    __   _  _   
+//  _ __ / _| ___|___ /| || |  / /_ | || |  
+// | '__| |_ / __| |_ \| || |_| '_ \| || |_ 
+// | |  |  _| (__ ___) |__   _| (_) |__   _|
+// |_|  |_|  \___|____/   |_|  \___/   |_|  
+
+// Package "rfc3464" provides a parser of message/delivery-status (RFC3464) and message/global-delivery-status
+// (RFC6533) bodies of bounce messages. https://datatracker.ietf.org/doc/html/rfc3464
+package rfc3464
+import "time"
+import "errors"
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+var ErrNoRecipient = errors.New("rfc3464: no per-recipient fields in the delivery status")
+
+// Action is the value of Action field of the per-recipient fields in lower case.
+type Action string
+const (
+	ActionFailed    Action = "failed"    // The message could not be delivered to the recipient
+	ActionDelayed   Action = "delayed"   // The reporting MTA has not been able to deliver the message yet
+	ActionDelivered Action = "delivered" // The message was delivered to the recipient
+	ActionRelayed   Action = "relayed"   // The message was relayed to the environment not accepting DSN
+	ActionExpanded  Action = "expanded"  // The message was delivered to the recipient and forwarded to other addresses
+)
+
+// TypedValue is a field body consisting of a type and a value such as "rfc822; neko@example.jp".
+type TypedValue struct {
+	Type  string // Address type, MTA name type, or diagnostic type in lower case such as "rfc822", "dns", or "smtp"
+	Value string // The value after ";", "\x{HHHH}" of the "utf-8" address type is decoded
+}
+
+// Report is a parsed delivery status: the per-message fields and the per-recipient fields.
+type Report struct {
+	ReportingMTA       *TypedValue     // Reporting-MTA such as {"dns", "mx.example.jp"}
+	DSNGateway         *TypedValue     // DSN-Gateway, nil if the field does not exist
+	ReceivedFromMTA    *TypedValue     // Received-From-MTA, nil if the field does not exist
+	OriginalEnvelopeID string          // Original-Envelope-Id given as ENVID parameter of MAIL command
+	ArrivalDate        time.Time       // Arrival-Date, zero time if the field does not exist or is broken
+	Fields             *rfc5322.Header // All the per-message fields including extension fields such as X-Postfix-Queue-ID
+	Recipients         []*Recipient    // Per-recipient fields in order of appearance
+}
+
+// Recipient is a set of the per-recipient fields.
+type Recipient struct {
+	OriginalRecipient *TypedValue     // Original-Recipient given as ORCPT parameter of RCPT command
+	FinalRecipient    *TypedValue     // Final-Recipient such as {"rfc822", "neko@example.jp"}
+	Action            Action          // Action in lower case such as "failed"
+	Status            string          // Status such as "5.1.1"
+	RemoteMTA         *TypedValue     // Remote-MTA such as {"dns", "mx.example.org"}
+	DiagnosticCode    *TypedValue     // Diagnostic-Code such as {"smtp", "550 5.1.1 User unknown"}
+	LastAttemptDate   time.Time       // Last-Attempt-Date, zero time if the field does not exist or is broken
+	FinalLogID        string          // Final-Log-ID such as a queue id of the reporting MTA
+	WillRetryUntil    time.Time       // Will-Retry-Until of the delayed DSN, zero time if the field does not exist
+	ReplyCode         string          // SMTP reply code found in Diagnostic-Code by reply.Find() such as "550"
+	StatusCode        string          // SMTP status code found in Diagnostic-Code by status.Find() such as "5.1.1"
+	Command           string          // SMTP command found in Diagnostic-Code by command.Find() such as "RCPT"
+	Fields            *rfc5322.Header // All the per-recipient fields including extension fields
+}
+
+// String returns the field body such as "rfc822; neko@example.jp".
+func (this *TypedValue) String() string {
+	if this == nil     { return "" }
+	if this.Type == "" { return this.Value }
+	return this.Type + "; " + this.Value
+}
+
+// Address returns the recipient address: the value of Final-Recipient or Original-Recipient.
+//   Returns:
+//     - (string): Email address such as "neko@example.jp", empty if both fields do not exist.
+func (this *Recipient) Address() string {
+	if this.FinalRecipient    != nil && this.FinalRecipient.Value    != "" { return this.FinalRecipient.Value }
+	if this.OriginalRecipient != nil && this.OriginalRecipient.Value != "" { return this.OriginalRecipient.Value }
+	return ""
+}
+
+// Failed returns true if the Action is "failed" or the class of the Status is "5".
+func (this *Recipient) Failed() bool {
+	if this.Action == ActionFailed { return true }
+	return this.Action == "" && strings.HasPrefix(this.Status, "5.")
+}
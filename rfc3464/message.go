@@ -0,0 +1,204 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      _____ _  _    __   _  _     ____  __                                  
+//  _ __ / _| ___|___ /| || |  / /_ | || |   / /  \/  | ___  ___ ___  __ _  __ _  ___ _ 
+// | '__| |_ / __| |_ \| || |_| '_ \| || |_ / /| |\/| |/ _ \/ __/ __|/ _` |/ _` |/ _ (_)
+// | |  |  _| (__ ___) |__   _| (_) |__   _/ / | |  | |  __/\__ \__ \ (_| | (_| |  __/_ 
+// |_|  |_|  \___|____/   |_|  \___/   |_|/_/  |_|  |_|\___||___/___/\__,_|\__, |\___(_)
+//                                                                         |___/        
+
+package rfc3464
+import "io"
+import "time"
+import "bytes"
+import "strconv"
+import "strings"
+import "crypto/rand"
+import "encoding/hex"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// Message composes a multipart/report message of the delivery status notification consisting of
+// the human readable part, the delivery status part, and the original message or its header.
+type Message struct {
+	Header   *rfc5322.HeaderWriter // Header fields such as From, To, Subject, Date, and Message-ID
+	Text     string                // Human readable part, Explain() is used when it is empty
+	Report   *Report               // Delivery status
+	Original []byte                // The original message, the third part is not written when it is empty
+	Content  bool                  // true: the whole original message as message/rfc822 (RET=FULL), false: its header only
+	Global   bool                  // Use message/global-delivery-status, message/global, and message/global-headers
+	Boundary string                // Boundary of the multipart, generated when it is empty
+}
+
+// NewMessage returns a Message with an empty HeaderWriter.
+//   Arguments:
+//     - report (*Report):   Delivery status.
+//     - original ([]byte): The original message.
+//   Returns:
+//     - (*Message): Message to be written by WriteTo() or Bytes().
+func NewMessage(report *Report, original []byte) *Message {
+	return &Message{Header: rfc5322.NewHeaderWriter(), Report: report, Original: original}
+}
+
+// WriteTo writes the multipart/report message. MIME-Version, Content-Type, and "Auto-Submitted:
+// auto-replied" are added to the header fields.
+//   Arguments:
+//     - w (io.Writer): Destination.
+//   Returns:
+//     - (int64): The number of bytes written.
+//     - (error): An error returned from Bytes() or the writer.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc6522
+//     - https://datatracker.ietf.org/doc/html/rfc3464#section-2
+func (this *Message) WriteTo(w io.Writer) (int64, error) {
+	message, nyaan := this.Bytes(); if nyaan != nil { return 0, nyaan }
+	n, nyaan := w.Write(message)
+	return int64(n), nyaan
+}
+
+// Bytes returns the multipart/report message, see WriteTo().
+//   Returns:
+//     - ([]byte): The message with CRLF line terminators.
+//     - (error):  An error returned from Report.Format() or MultipartReport.Bytes().
+func (this *Message) Bytes() ([]byte, error) {
+	if this.Report == nil { return nil, ErrNoReportingMTA }
+	status, nyaan := this.Report.Format(this.Global); if nyaan != nil { return nil, nyaan }
+
+	multipart := &MultipartReport{
+		Header: this.Header, ReportType: "delivery-status", Text: this.Text, MediaType: "message/delivery-status",
+		Report: status, Original: this.Original, Content: this.Content, Global: this.Global, Boundary: this.Boundary,
+	}
+	if multipart.Text     == "" { multipart.Text      = Explain(this.Report) }
+	if multipart.Boundary == "" { multipart.Boundary  = GenerateBoundary("DSN") }
+	if this.Global              { multipart.MediaType = "message/global-delivery-status" }
+	return multipart.Bytes()
+}
+
+// MultipartReport composes a multipart/report message consisting of the human readable part, the
+// machine readable part such as message/delivery-status, and the original message or its header.
+// Message of this package and rfc8098.Message are written by it.
+type MultipartReport struct {
+	Header     *rfc5322.HeaderWriter // Header fields such as From, To, Subject, Date, and Message-ID
+	ReportType string                // report-type parameter such as "delivery-status"
+	Text       string                // Human readable part
+	MediaType  string                // Media type of the machine readable part such as "message/delivery-status"
+	Report     []byte                // The machine readable part
+	Original   []byte                // The original message, the third part is not written when it is empty
+	Content    bool                  // true: the whole original message as message/rfc822, false: its header only
+	Global     bool                  // Use message/global and message/global-headers for the original message
+	Boundary   string                // Boundary of the multipart, GenerateBoundary("REPORT") when it is empty
+}
+
+// Bytes returns the multipart/report message. MIME-Version, Content-Type, and "Auto-Submitted:
+// auto-replied" are added to the header fields unless they exist.
+//   Returns:
+//     - ([]byte): The message with CRLF line terminators.
+//     - (error):  An error returned from rfc5322.HeaderWriter.Add().
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc6522
+func (this *MultipartReport) Bytes() ([]byte, error) {
+	boundary := this.Boundary; if boundary == "" { boundary = GenerateBoundary("REPORT") }
+	header   := rfc5322.NewHeaderWriter()
+	if this.Header != nil { header.Fields = append(header.Fields, this.Header.Fields...) }
+
+	fields := [][2]string{
+		{"MIME-Version", "1.0"},
+		{"Content-Type", rfc5322.FormatContentType("multipart/report", map[string]string{"report-type": this.ReportType, "boundary": boundary})},
+		{"Auto-Submitted", "auto-replied"},
+	}
+	for _, e := range fields {
+		// Add MIME header fields and Auto-Submitted unless they exist
+		exists := false
+		for _, f := range header.Fields { if strings.EqualFold(f.Name, e[0]) { exists = true; break } }
+		if exists { continue }
+		if nyaan := header.Add(e[0], e[1]); nyaan != nil { return nil, nyaan }
+	}
+
+	message := bytes.Buffer{}
+	message.Write(header.Bytes())
+	message.WriteString("\r\nThis is a MIME-encapsulated message.\r\n")
+
+	writePart(&message, boundary, "text/plain", []byte(this.Text))
+	writePart(&message, boundary, this.MediaType, this.Report)
+	if len(this.Original) > 0 {
+		// The original message or its header section
+		if this.Content {
+			if this.Global { writePart(&message, boundary, "message/global", this.Original) } else { writePart(&message, boundary, "message/rfc822", this.Original) }
+		} else {
+			original := headerOf(this.Original)
+			if this.Global { writePart(&message, boundary, "message/global-headers", original) } else { writePart(&message, boundary, "text/rfc822-headers", original) }
+		}
+	}
+	message.WriteString("\r\n--" + boundary + "--\r\n")
+	return message.Bytes(), nil
+}
+
+// Explain returns the human readable text of the delivery status.
+//   Arguments:
+//     - report (*Report): Delivery status.
+//   Returns:
+//     - (string): Text such as "The message to <neko@example.jp> could not be delivered: 5.1.1".
+func Explain(report *Report) string {
+	text := strings.Builder{}
+	if report.ReportingMTA != nil {
+		text.WriteString("This is the mail system at " + report.ReportingMTA.Value + ".\r\n")
+	}
+
+	for _, e := range report.Recipients {
+		// A sentence and Diagnostic-Code of each recipient
+		text.WriteString("\r\nThe message to <" + e.Address() + "> ")
+		switch e.Action {
+			case ActionFailed:    text.WriteString("could not be delivered")
+			case ActionDelayed:   text.WriteString("has not been delivered yet")
+			case ActionDelivered: text.WriteString("was delivered")
+			case ActionRelayed:   text.WriteString("was relayed to a system which does not send delivery reports")
+			case ActionExpanded:  text.WriteString("was delivered and forwarded to other addresses")
+			default:              text.WriteString("was processed")
+		}
+		if e.Status != "" { text.WriteString(": " + e.Status) }
+		text.WriteString("\r\n")
+
+		if e.Action == ActionDelayed && e.WillRetryUntil.IsZero() == false {
+			text.WriteString("    The delivery will be retried until " + e.WillRetryUntil.Format(time.RFC1123Z) + ".\r\n")
+		}
+		if e.DiagnosticCode != nil && e.DiagnosticCode.Value != "" { text.WriteString("    " + e.DiagnosticCode.Value + "\r\n") }
+	}
+	return text.String()
+}
+
+// writePart writes a body part of the multipart preceded by the delimiter. Content-Transfer-Encoding
+// is 8bit when the body includes non-ASCII characters, bare LFs are converted to CRLF.
+func writePart(message *bytes.Buffer, boundary, mediatype string, body []byte) {
+	body = bytes.ReplaceAll(bytes.ReplaceAll(body, []byte("\r\n"), []byte("\n")), []byte("\n"), []byte("\r\n"))
+	eightbit := isASCII(string(body)) == false
+
+	message.WriteString("\r\n--" + boundary + "\r\n")
+	if mediatype == "text/plain" {
+		charset := "us-ascii"; if eightbit { charset = "utf-8" }
+		message.WriteString("Content-Type: " + rfc5322.FormatContentType(mediatype, map[string]string{"charset": charset}) + "\r\n")
+	} else {
+		message.WriteString("Content-Type: " + mediatype + "\r\n")
+	}
+	if eightbit { message.WriteString("Content-Transfer-Encoding: 8bit\r\n") }
+
+	message.WriteString("\r\n")
+	message.Write(body)
+	if bytes.HasSuffix(body, []byte("\r\n")) == false { message.WriteString("\r\n") }
+}
+
+// headerOf returns the header section of the message without the empty line.
+func headerOf(message []byte) []byte {
+	for _, e := range []string{"\r\n\r\n", "\n\n"} {
+		if p := bytes.Index(message, []byte(e)); p > -1 { return message[:p + len(e) / 2] }
+	}
+	return message
+}
+
+// GenerateBoundary returns a boundary string of the multipart such as "1e8f3a2b5c4d6e7f.1760000000/DSN".
+//   Arguments:
+//     - suffix (string): Suffix of the boundary such as "DSN" or "MDN".
+//   Returns:
+//     - (string): Random boundary string.
+func GenerateBoundary(suffix string) string {
+	random := make([]byte, 8); rand.Read(random)
+	return hex.EncodeToString(random) + "." + strconv.FormatInt(time.Now().Unix(), 10) + "/" + suffix
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      _____ _  _    __   _  _     ______                       
+//  _ __ / _| ___|___ /| || |  / /_ | || |   / /  _ \ __ _ _ __ ___  ___ _ 
+// | '__| |_ / __| |_ \| || |_| '_ \| || |_ / /| |_) / _` | '__/ __|/ _ (_)
+// | |  |  _| (__ ___) |__   _| (_) |__   _/ / |  __/ (_| | |  \__ \  __/_ 
+// |_|  |_|  \___|____/   |_|  \___/   |_|/_/  |_|   \__,_|_|  |___/\___(_)
+
+package rfc3464
+import "io"
+import "time"
+import "bufio"
+import "strings"
+import "strconv"
+import "libsisimai.org/mailer-goemon/rfc5322"
+import "libsisimai.org/mailer-goemon/smtp/reply"
+import "libsisimai.org/mailer-goemon/smtp/status"
+import "libsisimai.org/mailer-goemon/smtp/command"
+
+// Parse parses the body of message/delivery-status or message/global-delivery-status part. A block
+// of fields without the empty line between the per-message fields and the per-recipient fields, and
+// per-recipient fields without the empty line between recipients are also dealt.
+//   Arguments:
+//     - r (io.Reader): The body of the delivery status part.
+//   Returns:
+//     - (*Report): Parsed delivery status, valid even when the error is ErrNoRecipient.
+//     - (error):   ErrNoRecipient or an error returned from the reader.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc3464#section-2
+//     - https://datatracker.ietf.org/doc/html/rfc6533#section-6
+func Parse(r io.Reader) (*Report, error) {
+	//   delivery-status-content = per-message-fields 1*( CRLF per-recipient-fields )
+	reader, ok := r.(*bufio.Reader); if ok == false { reader = bufio.NewReader(r) }
+	report := &Report{Fields: &rfc5322.Header{Fields: []*rfc5322.Field{}}, Recipients: []*Recipient{}}
+	blocks := []*rfc5322.Header{}
+
+	for {
+		// Read each block of fields separated by an empty line
+		header, nyaan := rfc5322.ReadHeader(reader)
+		if nyaan != nil { return report, nyaan }
+		if header.Size == 0 { break }
+		if len(header.Fields) == 0 { continue } // Consecutive empty lines
+		blocks = append(blocks, header)
+	}
+
+	for j, e := range blocks {
+		// Split each block into the per-message fields and the per-recipient fields
+		var current *rfc5322.Header
+		followed := false // Fields other than Original-Recipient and Final-Recipient follow them
+		if j == 0 && isRecipientField(e.Fields[0].Name) == false { current = report.Fields }
+
+		for _, f := range e.Fields {
+			if isRecipientField(f.Name) {
+				// Original-Recipient or Final-Recipient begins the next recipient
+				if current == nil || current == report.Fields || followed || current.Field(f.Name) != nil {
+					current = &rfc5322.Header{Fields: []*rfc5322.Field{}}
+					report.Recipients = append(report.Recipients, &Recipient{Fields: current})
+				}
+				followed = false
+
+			} else if current == nil {
+				// The block begins with a field such as Action without Final-Recipient
+				current = &rfc5322.Header{Fields: []*rfc5322.Field{}}
+				report.Recipients = append(report.Recipients, &Recipient{Fields: current})
+				followed = true
+
+			} else if current != report.Fields {
+				followed = true
+			}
+			current.Fields = append(current.Fields, f)
+			current.Flags |= f.Flags
+		}
+	}
+
+	report.ReportingMTA       = parseTypedValue(report.Fields.Field("Reporting-MTA"))
+	report.DSNGateway         = parseTypedValue(report.Fields.Field("DSN-Gateway"))
+	report.ReceivedFromMTA    = parseTypedValue(report.Fields.Field("Received-From-MTA"))
+	report.OriginalEnvelopeID = report.Fields.Get("Original-Envelope-Id")
+	report.ArrivalDate        = parseDate(report.Fields.Get("Arrival-Date"))
+	for _, e := range report.Recipients { e.parse() }
+
+	if len(report.Recipients) == 0 { return report, ErrNoRecipient }
+	return report, nil
+}
+
+// parse sets the per-recipient fields from Fields and finds codes and a command in Diagnostic-Code.
+func (this *Recipient) parse() {
+	this.OriginalRecipient = parseTypedValue(this.Fields.Field("Original-Recipient"))
+	this.FinalRecipient    = parseTypedValue(this.Fields.Field("Final-Recipient"))
+	this.RemoteMTA         = parseTypedValue(this.Fields.Field("Remote-MTA"))
+	this.DiagnosticCode    = parseTypedValue(this.Fields.Field("Diagnostic-Code"))
+	this.LastAttemptDate   = parseDate(this.Fields.Get("Last-Attempt-Date"))
+	this.WillRetryUntil    = parseDate(this.Fields.Get("Will-Retry-Until"))
+	this.FinalLogID        = this.Fields.Get("Final-Log-ID")
+
+	// "failed (bad destination mailbox address)" or "Failed"
+	if cv := strings.Fields(this.Fields.Get("Action")); len(cv) > 0 { this.Action = Action(strings.ToLower(cv[0])) }
+	if cv := strings.Fields(this.Fields.Get("Status")); len(cv) > 0 { this.Status = cv[0] }
+
+	if this.DiagnosticCode == nil { return }
+	this.ReplyCode  = reply.Find(this.DiagnosticCode.Value, this.Status)
+	this.StatusCode = status.Find(this.DiagnosticCode.Value, this.ReplyCode)
+	this.Command    = command.Find(this.DiagnosticCode.Value)
+}
+
+// isRecipientField returns true if the field name is Original-Recipient or Final-Recipient.
+func isRecipientField(name string) bool {
+	return strings.EqualFold(name, "Final-Recipient") || strings.EqualFold(name, "Original-Recipient")
+}
+
+// parseTypedValue parses the field body of the field, nil if the field is nil.
+func parseTypedValue(field *rfc5322.Field) *TypedValue {
+	if field == nil { return nil }
+	return ParseTypedValue(field.Value)
+}
+
+// ParseTypedValue parses the field body such as "rfc822; neko@example.jp" of Final-Recipient and
+// other fields consisting of a type and a value.
+//   Arguments:
+//     - value (string): The field body such as "dns; mx.example.jp".
+//   Returns:
+//     - (*TypedValue): The type and the value. The type is empty if the field body does not have ";".
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc3464#section-2.1.2
+//     - https://datatracker.ietf.org/doc/html/rfc6533#section-3
+func ParseTypedValue(value string) *TypedValue {
+	typedvalue := &TypedValue{Value: value}
+	if p := strings.IndexByte(value, ';'); p > 0 && strings.ContainsAny(value[:p], " \t<@") == false {
+		// "rfc822; neko@example.jp", "dns;mx.example.jp"
+		typedvalue.Type  = strings.ToLower(strings.TrimSpace(value[:p]))
+		typedvalue.Value = strings.TrimSpace(value[p + 1:])
+	}
+	if typedvalue.Type == "utf-8" { typedvalue.Value = decodeEmbeddedUnicode(typedvalue.Value) }
+	if typedvalue.Type == "rfc822" || typedvalue.Type == "utf-8" {
+		// "rfc822; <neko@example.jp>"
+		typedvalue.Value = strings.TrimSuffix(strings.TrimPrefix(typedvalue.Value, "<"), ">")
+	}
+	return typedvalue
+}
+
+// decodeEmbeddedUnicode decodes "\x{HHHH}" of utf-8-addr-xtext into a UTF-8 character.
+//   Arguments:
+//     - text (string): Encoded address such as "\x{732B}@example.jp".
+//   Returns:
+//     - (string): Decoded address such as "猫@example.jp".
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc6533#section-3
+func decodeEmbeddedUnicode(text string) string {
+	if strings.Contains(text, `\x{`) == false { return text }
+
+	decoded := strings.Builder{}
+	for {
+		p1 := strings.Index(text, `\x{`); if p1 < 0 { break }
+		p2 := strings.IndexByte(text[p1:], '}'); if p2 < 0 { break }
+		decoded.WriteString(text[:p1])
+
+		codepoint, nyaan := strconv.ParseUint(text[p1 + 3:p1 + p2], 16, 32)
+		if nyaan != nil || p2 < 5 || p2 > 9 { decoded.WriteString(text[p1:p1 + p2 + 1]) } else { decoded.WriteRune(rune(codepoint)) }
+		text = text[p1 + p2 + 1:]
+	}
+	decoded.WriteString(text)
+	return decoded.String()
+}
+
+// parseDate parses the date-time of Arrival-Date and other fields.
+func parseDate(value string) time.Time {
+	if value == "" { return time.Time{} }
+	cv, nyaan := rfc5322.ParseDate(value)
+	if nyaan != nil && nyaan != rfc5322.ErrWeekdayMismatch && nyaan != rfc5322.ErrMissingTimeZone { return time.Time{} }
+	return cv
+}
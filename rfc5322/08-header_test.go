@@ -0,0 +1,87 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc5322
+
+//  _____         _      ______  _____ ____ ____ _________  ____  
+// |_   _|__  ___| |_   / /  _ \|  ___/ ___| ___|___ /___ \|___ \ 
+//   | |/ _ \/ __| __| / /| |_) | |_ | |   |___ \ |_ \ __) | __) |
+//   | |  __/\__ \ |_ / / |  _ <|  _|| |___ ___) |__) / __/ / __/ 
+//   |_|\___||___/\__/_/  |_| \_\_|   \____|____/____/_____|_____|
+import "io"
+import "bufio"
+import "strings"
+import "testing"
+
+func TestReadHeader(t *testing.T) {
+	fn := "rfc5322.ReadHeader"
+	cx := 0
+	ce := strings.Join([]string{
+		"Received: from mx.example.org (c182128.example.net [192.0.2.128])\r\n",
+		"\tby mx.example.jp (8.14.4/8.14.4) with ESMTP id oBB3JxRJ022484\r\n",
+		"\tfor <shironeko@example.jp>; Sat, 11 Dec 2010 12:20:00 +0900 (JST)\r\n",
+		"Received: from localhost by mx.example.org; Sat, 11 Dec 2010 12:19:59 +0900\r\n",
+		"Subject : Nyaan\r\n",
+		"From: \"Neko\" <neko@example.jp>\r\n",
+		"X-Neko: \xe3\x81\xad\xe3\x81\x93\n",
+		"X-Folded: neko\r\n",
+		"  \r\n",
+		"  nyaan\r\n",
+		"\r\n",
+		"Body\r\n",
+	}, "")
+
+	cr := bufio.NewReader(strings.NewReader(ce))
+	cv, nyaan := ReadHeader(cr)
+	cx++; if nyaan != nil          { t.Fatalf("%s() returns error: %s", fn, nyaan) }
+	cx++; if len(cv.Fields) != 6   { t.Fatalf("%s() returns %d fields", fn, len(cv.Fields)) }
+	cx++; if cv.Fields[0].Name != "Received" { t.Errorf("%s().Fields[0].Name is %s", fn, cv.Fields[0].Name) }
+	cx++; if len(cv.Values("received")) != 2 { t.Errorf("%s().Values(received) is %v", fn, cv.Values("received")) }
+	cx++; if strings.Contains(cv.Fields[0].Value, "\n") { t.Errorf("%s().Fields[0].Value is not unfolded", fn) }
+	cx++; if strings.HasPrefix(cv.Fields[0].Value, "from mx.example.org") == false { t.Errorf("%s().Fields[0].Value is %s", fn, cv.Fields[0].Value) }
+	cx++; if strings.Contains(cv.Fields[0].Value, "8.14.4/8.14.4) with ESMTP id oBB3JxRJ022484\tfor") == false {
+		t.Errorf("%s().Fields[0].Value is %s", fn, cv.Fields[0].Value)
+	}
+	cx++; if cv.Get("SUBJECT") != "Nyaan"              { t.Errorf("%s().Get(Subject) is %s", fn, cv.Get("Subject")) }
+	cx++; if cv.Field("Subject").Flags & HeaderObsoleteWSP == 0 { t.Errorf("%s(): Subject has no HeaderObsoleteWSP", fn) }
+	cx++; if cv.Get("From") != `"Neko" <neko@example.jp>` { t.Errorf("%s().Get(From) is %s", fn, cv.Get("From")) }
+	cx++; if cv.Get("X-Neko") != "\xe3\x81\xad\xe3\x81\x93" { t.Errorf("%s().Get(X-Neko) is %s", fn, cv.Get("X-Neko")) }
+	cx++; if cv.Field("X-Neko").Flags & HeaderEightBit == 0 { t.Errorf("%s(): X-Neko has no HeaderEightBit", fn) }
+	cx++; if cv.Field("X-Neko").Flags & HeaderBareLF   == 0 { t.Errorf("%s(): X-Neko has no HeaderBareLF", fn)   }
+	cx++; if cv.Get("X-Folded") != "neko    nyaan"          { t.Errorf("%s().Get(X-Folded) is [%s]", fn, cv.Get("X-Folded")) }
+	cx++; if cv.Field("X-Folded").Flags & HeaderObsoleteWSP == 0 { t.Errorf("%s(): X-Folded has no HeaderObsoleteWSP", fn) }
+	cx++; if cv.Get("Date") != "" || cv.Field("Date") != nil { t.Errorf("%s().Get(Date) is not empty", fn) }
+
+	for j, e := range cv.Fields {
+		// Offset and Raw should point the original bytes
+		cx++; if ce[e.Offset:e.End()] != string(e.Raw) { t.Errorf("%s().Fields[%d] has the wrong offset %d", fn, j, e.Offset) }
+	}
+	cx++; if cv.Size != int64(strings.Index(ce, "Body")) { t.Errorf("%s().Size is %d", fn, cv.Size) }
+
+	cw, _ := io.ReadAll(cr)
+	cx++; if string(cw) != "Body\r\n" { t.Errorf("%s(): the body is %s", fn, cw) }
+
+	cv, _ = ReadHeader(strings.NewReader("From neko@example.jp Sat Dec 11 12:20:00 2010\n\tnyaan\nX-Long: " + strings.Repeat("a", 999)))
+	cx++; if len(cv.Fields) != 2 { t.Fatalf("%s() returns %d fields", fn, len(cv.Fields)) }
+	cx++; if cv.Fields[0].Name != "" || cv.Fields[0].Flags & HeaderBrokenLine == 0 { t.Errorf("%s(): From_ line is not broken", fn) }
+	cx++; if cv.Flags & HeaderLineTooLong == 0 { t.Errorf("%s(): X-Long has no HeaderLineTooLong", fn) }
+
+	cv, nyaan = ReadHeader(strings.NewReader(""))
+	cx++; if nyaan != nil || len(cv.Fields) != 0 { t.Errorf("%s('') returns %v, %v", fn, cv.Fields, nyaan) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestUnfold(t *testing.T) {
+	fn := "rfc5322.Unfold"
+	cx := 0
+	ae := []struct {text string; expected string}{
+		{"neko\r\n nyaan\r\n", "neko nyaan"},
+		{"neko\n\tnyaan", "neko\tnyaan"},
+		{"neko", "neko"},
+		{"", ""},
+	}
+	for _, e := range ae {
+		cx++; if cv := Unfold(e.text); cv != e.expected { t.Errorf("%s(%q) returns %q", fn, e.text, cv) }
+	}
+	t.Logf("The number of tests = %d", cx)
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc5322
+
+//  _____         _      ______  _____ ____ ____ _________  ____  
+// |_   _|__  ___| |_   / /  _ \|  ___/ ___| ___|___ /___ \|___ \ 
+//   | |/ _ \/ __| __| / /| |_) | |_ | |   |___ \ |_ \ __) | __) |
+//   | |  __/\__ \ |_ / / |  _ <|  _|| |___ ___) |__) / __/ / __/ 
+//   |_|\___||___/\__/_/  |_| \_\_|   \____|____/____/_____|_____|
+import "time"
+import "errors"
+import "testing"
+
+func TestParseDate(t *testing.T) {
+	fn := "rfc5322.ParseDate"
+	cx := 0
+	ae := []struct {
+		testname string; argument string; expected string; theerror error
+	}{
+		{"RFC5322",         "Thu, 29 Apr 2010 23:34:45 -0800 (PST)",  "Thu, 29 Apr 2010 23:34:45 -0800", nil},
+		{"No day-of-week",  "29 Apr 2010 23:34:45 +0900",             "Thu, 29 Apr 2010 23:34:45 +0900", nil},
+		{"1:2 time",        "Sun, 29 May 2016 1:2 +0900",             "Sun, 29 May 2016 01:02:00 +0900", nil},
+		{"3-digit day",     "Mon, 029 Apr 2019 23:34:45 -0800",       "Mon, 29 Apr 2019 23:34:45 -0800", nil},
+		{"2-digit year",    "29 Apr 99 1:2 EST",                      "Thu, 29 Apr 1999 01:02:00 -0500", nil},
+		{"2-digit year",    "Thu, 29 Apr 10 23:34:45 +0000",          "Thu, 29 Apr 2010 23:34:45 +0000", nil},
+		{"Full name",       "Thursday, 29 April 2010 23:34:45 +0900", "Thu, 29 Apr 2010 23:34:45 +0900", nil},
+		{"Zone name",       "Sat, 06 Jul 2013 23:34:45 JST",          "Sat, 06 Jul 2013 23:34:45 +0900", nil},
+		{"Zone name",       "Thu, 29 Apr 2010 23:34:45 GMT",          "Thu, 29 Apr 2010 23:34:45 +0000", nil},
+		{"Zone name",       "Thu, 29 Apr 2010 23:34:45 PDT",          "Thu, 29 Apr 2010 23:34:45 -0700", nil},
+		{"Military zone",   "Thu, 29 Apr 2010 23:34:45 Q",            "Thu, 29 Apr 2010 23:34:45 +0000", nil},
+		{"Zone comment",    "Thu, 29 Apr 2010 23:34:45 (PST)",        "Thu, 29 Apr 2010 23:34:45 -0800", nil},
+		{"Zone comment",    "Mon, 20 Sep 2021 21:32:59 (GMT+02:00)",  "Mon, 20 Sep 2021 21:32:59 +0200", nil},
+		{"Offset first",    "Sat, 11 Dec 2010 12:20:00 +0900 (PST)",  "Sat, 11 Dec 2010 12:20:00 +0900", nil},
+		{"ISO 8601",        "2014-03-26T00:01:19+09:00",              "Wed, 26 Mar 2014 00:01:19 +0900", nil},
+		{"ISO 8601",        "2014-03-26T00:01:19.250Z",               "Wed, 26 Mar 2014 00:01:19 +0000", nil},
+		{"ctime(3)",        "Thu Apr 29 23:34:45 2010 +0000",         "Thu, 29 Apr 2010 23:34:45 +0000", nil},
+		{"US style",        "4/29/01 11:34:45 PM",                    "Sun, 29 Apr 2001 23:34:45 +0000", ErrMissingTimeZone},
+		{"US style",        "4/29/01 12:04:45 AM -0400",              "Sun, 29 Apr 2001 00:04:45 -0400", nil},
+		{"Dash separated",  "2014-03-26 00-01-19",                    "Wed, 26 Mar 2014 00:01:19 +0000", ErrMissingTimeZone},
+		{"Day first",       "29-04-2017 22:22",                       "Sat, 29 Apr 2017 22:22:00 +0000", ErrMissingTimeZone},
+		{"Day first",       "26.03.2014 10:00:00 +0100",              "Wed, 26 Mar 2014 10:00:00 +0100", nil},
+		{"Month name",      "26-Mar-2014 10:00:00 +0100",             "Wed, 26 Mar 2014 10:00:00 +0100", nil},
+		{"No time zone",    "Thu, 29 Apr 2009 23:34:45",              "Wed, 29 Apr 2009 23:34:45 +0000", ErrWeekdayMismatch},
+		{"Wrong weekday",   "Tue, 029 Apr 2019 23:34:45 -0800 (PST)", "Mon, 29 Apr 2019 23:34:45 -0800", ErrWeekdayMismatch},
+		{"Wrong weekday",   "Fri, 29 Apr 2010 23:34:45 +0000",        "Thu, 29 Apr 2010 23:34:45 +0000", ErrWeekdayMismatch},
+	}
+
+	for _, e := range ae {
+		cv, ce := ParseDate(e.argument)
+		cx++; if errors.Is(ce, e.theerror) == false { t.Errorf("[%s] %s(%s) returns error %v, expected %v", e.testname, fn, e.argument, ce, e.theerror) }
+		cx++; if cv.IsZero() { t.Errorf("[%s] %s(%s).IsZero() is true", e.testname, fn, e.argument) }
+		cx++; if FormatDate(cv) != e.expected { t.Errorf("[%s] %s(%s) is %s, expected %s", e.testname, fn, e.argument, FormatDate(cv), e.expected) }
+	}
+
+	cx++; if cv, _ := ParseDate("2014-03-26T00:01:19.250Z"); cv.Nanosecond() != 250000000 { t.Errorf("%s() returns %d ns", fn, cv.Nanosecond()) }
+	cx++; if cv, _ := ParseDate("Sat, 06 Jul 2013 23:34:45 JST"); cv.UTC().Hour() != 14 { t.Errorf("%s() returns %v", fn, cv.UTC()) }
+
+	for _, e := range []string{"", "Neko", "MX 0", "75 1", "Thu, 29 Apr 2010", "Apr 2010 23:34:45 +0000", "29-04-2017-01 22:22"} {
+		// Unknown date format
+		_, ce := ParseDate(e)
+		cx++; if errors.Is(ce, ErrUnknownDateFormat) == false { t.Errorf("%s(%s) returns %v", fn, e, ce) }
+	}
+	for _, e := range []string{
+		"Thu, 31 Feb 2010 00:00:00 +0000", "Thu, 29 Apr 2010 24:34:45 +0000", "Thu, 29 Apr 2010 23:60:45 +0000",
+		"Thu, 29 Apr 2010 23:34:45 +0961", "29 Apr 2010 13:34:45 PM +0000", "Thu, 29 Apr 2010 23:34:45 +2400",
+	} {
+		// Out of range
+		_, ce := ParseDate(e)
+		cx++; if errors.Is(ce, ErrDateOutOfRange) == false { t.Errorf("%s(%s) returns %v", fn, e, ce) }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestFormatDate(t *testing.T) {
+	fn := "rfc5322.FormatDate"
+	cx := 0
+	cv := FormatDate(time.Date(2010, 4, 9, 3, 4, 5, 0, time.FixedZone("JST", 9 * 3600)))
+	cx++; if cv != "Fri, 09 Apr 2010 03:04:05 +0900" { t.Errorf("%s() returns %s", fn, cv) }
+
+	for _, e := range []string{"Thu, 29 Apr 2010 23:34:45 -0800", "Mon, 01 Jan 2024 00:00:00 +0530"} {
+		// Round trip
+		ct, ce := ParseDate(e)
+		cx++; if ce != nil { t.Errorf("%s(%s) returns %v", "rfc5322.ParseDate", e, ce) }
+		cx++; if FormatDate(ct) != e { t.Errorf("%s() returns %s, expected %s", fn, FormatDate(ct), e) }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
@@ -0,0 +1,97 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc5322
+
+//  _____         _      ______  _____ ____ ____ _________  ____  
+// |_   _|__  ___| |_   / /  _ \|  ___/ ___| ___|___ /___ \|___ \ 
+//   | |/ _ \/ __| __| / /| |_) | |_ | |   |___ \ |_ \ __) | __) |
+//   | |  __/\__ \ |_ / / |  _ <|  _|| |___ ___) |__) / __/ / __/ 
+//   |_|\___||___/\__/_/  |_| \_\_|   \____|____/____/_____|_____|
+import "testing"
+
+func TestParseReceived(t *testing.T) {
+	fn := "rfc5322.ParseReceived"
+	cx := 0
+	ae := []struct {
+		testname string; argument string; expected ReceivedHeader; datetime string
+	}{
+		{"Postfix",
+			"from mail.example.org (mail.example.org [192.0.2.1]) (using TLSv1.3 with cipher TLS_AES_256_GCM_SHA384 (256/256 bits) key-exchange X25519 server-signature RSA-PSS (2048 bits) server-digest SHA256) (No client certificate requested) by mx.example.jp (Postfix) with ESMTPS id 4AbCdE for <neko@example.jp>; Thu, 29 Apr 2010 23:34:45 +0900 (JST)",
+			ReceivedHeader{From: "mail.example.org", Helo: "mail.example.org", RDNS: "mail.example.org", Addr: "192.0.2.1",
+				By: "mx.example.jp", With: "esmtps", ID: "4AbCdE", For: "neko@example.jp", TLSVersion: "TLSv1.3",
+				TLSCipher: "TLS_AES_256_GCM_SHA384", MTA: "postfix"},
+			"Thu, 29 Apr 2010 23:34:45 +0900"},
+		{"Postfix/IPv6",
+			"from helo.example (unknown [IPv6:2001:DB8::1]) (Authenticated sender: neko@example.jp) by mx.example.jp (Postfix) with ESMTPSA id 4AbC; Thu, 29 Apr 2010 23:34:45 +0900",
+			ReceivedHeader{From: "helo.example", Helo: "helo.example", Addr: "2001:db8::1", By: "mx.example.jp",
+				With: "esmtpsa", ID: "4AbC", AuthSender: "neko@example.jp", MTA: "postfix"},
+			"Thu, 29 Apr 2010 23:34:45 +0900"},
+		{"Postfix/Local",
+			"by mx.example.jp (Postfix, from userid 1000) id 4AbCdE; Thu, 29 Apr 2010 23:34:45 +0900 (JST)",
+			ReceivedHeader{By: "mx.example.jp", ID: "4AbCdE", UID: "1000", MTA: "postfix"},
+			"Thu, 29 Apr 2010 23:34:45 +0900"},
+		{"Exim",
+			"from [192.0.2.1] (helo=mail.example.org) by mx.example.jp with esmtps (TLS1.3) tls TLS_AES_256_GCM_SHA384 (Exim 4.96) (envelope-from <neko@example.org>) id 1abcDE-000123-AB for neko@example.jp; Thu, 29 Apr 2010 23:34:45 +0900",
+			ReceivedHeader{From: "[192.0.2.1]", Helo: "mail.example.org", Addr: "192.0.2.1", By: "mx.example.jp",
+				With: "esmtps", ID: "1abcDE-000123-AB", For: "neko@example.jp", EnvelopeFrom: "neko@example.org",
+				TLSVersion: "TLS1.3", TLSCipher: "TLS_AES_256_GCM_SHA384", MTA: "exim"},
+			"Thu, 29 Apr 2010 23:34:45 +0900"},
+		{"Exim/Old",
+			"from c.example.net ([192.0.2.1]:52345 helo=mail.example.org) by mx.example.jp with esmtps (TLS1.2:ECDHE-RSA-AES256-GCM-SHA384:256) (Exim 4.92) (envelope-from <neko@example.org>) id 1abc for neko@example.jp; Thu, 29 Apr 2010 23:34:45 +0900",
+			ReceivedHeader{From: "c.example.net", Helo: "mail.example.org", RDNS: "c.example.net", Addr: "192.0.2.1",
+				By: "mx.example.jp", With: "esmtps", ID: "1abc", For: "neko@example.jp", EnvelopeFrom: "neko@example.org",
+				TLSVersion: "TLS1.2", TLSCipher: "ECDHE-RSA-AES256-GCM-SHA384", MTA: "exim"},
+			"Thu, 29 Apr 2010 23:34:45 +0900"},
+		{"Exim/Same HELO",
+			"from mail.example.org ([192.0.2.1]) by mx.example.jp with esmtp (Exim 4.96) id 1abc; Thu, 29 Apr 2010 23:34:45 +0900",
+			ReceivedHeader{From: "mail.example.org", Helo: "mail.example.org", RDNS: "mail.example.org", Addr: "192.0.2.1",
+				By: "mx.example.jp", With: "esmtp", ID: "1abc", MTA: "exim"},
+			"Thu, 29 Apr 2010 23:34:45 +0900"},
+		{"Sendmail",
+			"from mail.example.org (neko@c.example.net [192.0.2.1] (may be forged)) by mx.example.jp (8.15.2/8.15.2) with ESMTPS id 12345 (version=TLSv1.3 cipher=TLS_AES_256_GCM_SHA384 bits=256 verify=NOT) for <neko@example.jp>; Thu, 29 Apr 2010 23:34:45 +0900",
+			ReceivedHeader{From: "mail.example.org", Helo: "mail.example.org", RDNS: "c.example.net", Addr: "192.0.2.1",
+				By: "mx.example.jp", With: "esmtps", ID: "12345", For: "neko@example.jp", TLSVersion: "TLSv1.3",
+				TLSCipher: "TLS_AES_256_GCM_SHA384", MTA: "sendmail"},
+			"Thu, 29 Apr 2010 23:34:45 +0900"},
+		{"Exchange",
+			"from EXCH01.example.local (10.0.0.1) by EXCH02.example.local (10.0.0.2) with Microsoft SMTP Server (version=TLS1_2, cipher=TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384) id 15.20.1234.5 via Frontend Transport; Thu, 29 Apr 2010 23:34:45 +0000",
+			ReceivedHeader{From: "exch01.example.local", Helo: "exch01.example.local", Addr: "10.0.0.1", By: "exch02.example.local",
+				Via: "frontend transport", With: "microsoft smtp server", ID: "15.20.1234.5", TLSVersion: "TLS1_2",
+				TLSCipher: "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", MTA: "exchange"},
+			"Thu, 29 Apr 2010 23:34:45 +0000"},
+		{"Gmail",
+			"from mail-sor-f41.google.com (mail-sor-f41.google.com. [209.85.220.41]) by mx.google.com with SMTPS id a1sor123.2021.04.29 for <neko@example.jp> (Google Transport Security); Thu, 29 Apr 2010 23:34:45 -0700 (PDT)",
+			ReceivedHeader{From: "mail-sor-f41.google.com", Helo: "mail-sor-f41.google.com", RDNS: "mail-sor-f41.google.com",
+				Addr: "209.85.220.41", By: "mx.google.com", With: "smtps", ID: "a1sor123.2021.04.29", For: "neko@example.jp", MTA: "gmail"},
+			"Thu, 29 Apr 2010 23:34:45 -0700"},
+		{"Gmail/IPv6",
+			"by 2002:a05:6a10:a0d1:0:0:0:0 with SMTP id x17csp123; Thu, 29 Apr 2010 23:34:45 -0700 (PDT)",
+			ReceivedHeader{By: "2002:a05:6a10:a0d1:0:0:0:0", With: "smtp", ID: "x17csp123"},
+			"Thu, 29 Apr 2010 23:34:45 -0700"},
+		{"qmail",
+			"from unknown (HELO mail.example.org) (192.0.2.1) by mx.example.jp with SMTP; 29 Apr 2010 23:34:45 -0000",
+			ReceivedHeader{From: "unknown", Helo: "mail.example.org", Addr: "192.0.2.1", By: "mx.example.jp", With: "smtp", MTA: "qmail"},
+			"Thu, 29 Apr 2010 23:34:45 +0000"},
+		{"qmail/uid",
+			"(qmail 2220 invoked by uid 2); 17 Jul 2014 08:30:40 -0000",
+			ReceivedHeader{UID: "2", MTA: "qmail"},
+			"Thu, 17 Jul 2014 08:30:40 +0000"},
+	}
+
+	for _, e := range ae {
+		cv := ParseReceived(e.argument)
+		cx++; if cv == nil { t.Errorf("[%s] %s() returns nil", e.testname, fn); continue }
+
+		cd := FormatDate(cv.Date); cv.Date = e.expected.Date
+		cx++; if *cv != e.expected   { t.Errorf("[%s] %s() returns\n%+v, expected\n%+v", e.testname, fn, *cv, e.expected) }
+		cx++; if cd  != e.datetime   { t.Errorf("[%s] %s().Date is %s, expected %s", e.testname, fn, cd, e.datetime) }
+	}
+	cx++; if cv := ParseReceived("");    cv != nil { t.Errorf("%s() returns %+v", fn, cv) }
+	cx++; if cv := ParseReceived("   "); cv != nil { t.Errorf("%s() returns %+v", fn, cv) }
+	cx++; if cv := ParseReceived("from mx.example.jp by mx.example.org; neko"); cv.Date.IsZero() == false {
+		t.Errorf("%s().Date returns %v", fn, cv.Date)
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
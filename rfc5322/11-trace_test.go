@@ -0,0 +1,72 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc5322
+
+//  _____         _      ______  _____ ____ ____ _________  ____  
+// |_   _|__  ___| |_   / /  _ \|  ___/ ___| ___|___ /___ \|___ \ 
+//   | |/ _ \/ __| __| / /| |_) | |_ | |   |___ \ |_ \ __) | __) |
+//   | |  __/\__ \ |_ / / |  _ <|  _|| |___ ___) |__) / __/ / __/ 
+//   |_|\___||___/\__/_/  |_| \_\_|   \____|____/____/_____|_____|
+import "time"
+import "testing"
+import "net/netip"
+
+func TestParseTrace(t *testing.T) {
+	fn := "rfc5322.ParseTrace"
+	cx := 0
+	ae := []string{
+		"from localhost (localhost [127.0.0.1]) by mx.example.jp (Postfix) with ESMTP id 4B; Thu, 29 Apr 2010 23:35:15 +0900 (JST)",
+		"from gw.example.jp (gw.example.jp [192.0.2.25]) by mx.example.jp (Postfix) with ESMTPS id 4A; Thu, 29 Apr 2010 23:35:05 +0900 (JST)",
+		"from mail.example.org (mail.example.org [198.51.100.1]) by gw.example.jp (8.15.2/8.15.2) with ESMTPS id 123; Thu, 29 Apr 2010 07:35:00 -0700",
+		"from [10.0.0.5] (c.example.net [203.0.113.5]) by mail.example.org (Postfix) with ESMTPSA id 9Z; Thu, 29 Apr 2010 14:34:45 +0000",
+		"(qmail 2220 invoked by uid 2); 29 Apr 2010 14:34:40 -0000",
+	}
+
+	cv := ParseTrace(ae, &TraceOption{TrustedHosts: []string{"example.jp"}})
+	cx++; if len(cv.Hops) != 5 { t.Fatalf("%s() returns %d hops", fn, len(cv.Hops)) }
+	cx++; if cv.Hops[0].MTA != "qmail" { t.Errorf("%s().Hops[0].MTA is %s", fn, cv.Hops[0].MTA) }
+	cx++; if cv.Hops[4].ID  != "4B"    { t.Errorf("%s().Hops[4].ID is %s", fn, cv.Hops[4].ID) }
+	for j, e := range []time.Duration{0, 5 * time.Second, 15 * time.Second, 5 * time.Second, 10 * time.Second} {
+		cx++; if cv.Hops[j].Index != j { t.Errorf("%s().Hops[%d].Index is %d", fn, j, cv.Hops[j].Index) }
+		cx++; if cv.Hops[j].Delay != e { t.Errorf("%s().Hops[%d].Delay is %v, expected %v", fn, j, cv.Hops[j].Delay, e) }
+	}
+	cx++; if cv.Transit  != 35 * time.Second { t.Errorf("%s().Transit is %v", fn, cv.Transit) }
+	cx++; if cv.OriginIP != "198.51.100.1"   { t.Errorf("%s().OriginIP is %s", fn, cv.OriginIP) }
+	cx++; if cv.Origin   != cv.Hops[2]       { t.Errorf("%s().Origin is %+v", fn, cv.Origin) }
+	cx++; if cv.Loop     != false            { t.Errorf("%s().Loop is true", fn) }
+	cx++; if cv.TooMany  != false            { t.Errorf("%s().TooMany is true", fn) }
+	cx++; if cv.Hops[3].Trusted == false     { t.Errorf("%s().Hops[3].Trusted is false", fn) }
+	cx++; if cv.Hops[4].Trusted == false     { t.Errorf("%s().Hops[4].Trusted is false", fn) }
+
+	cv = ParseTrace(ae, &TraceOption{
+		TrustedHosts:    []string{"example.jp"},
+		TrustedNetworks: []netip.Prefix{netip.MustParsePrefix("198.51.100.0/24")},
+	})
+	cx++; if cv.OriginIP != "203.0.113.5" { t.Errorf("%s().OriginIP is %s", fn, cv.OriginIP) }
+
+	cv = ParseTrace(ae, nil)
+	cx++; if cv.OriginIP != "192.0.2.25" { t.Errorf("%s().OriginIP is %s", fn, cv.OriginIP) }
+
+	cv = ParseTrace(ae, &TraceOption{TrustedNetworks: []netip.Prefix{netip.MustParsePrefix("0.0.0.0/0")}})
+	cx++; if cv.Origin != nil || cv.OriginIP != "" { t.Errorf("%s().OriginIP is %s", fn, cv.OriginIP) }
+
+	cv = ParseTrace(ae, &TraceOption{MaxHops: 4})
+	cx++; if cv.TooMany == false { t.Errorf("%s().TooMany is false", fn) }
+
+	je := []string{
+		"from a.example.jp (a.example.jp [192.0.2.1]) by b.example.jp (Postfix) id 3; Thu, 29 Apr 2010 23:34:47 +0900",
+		"from b.example.jp (b.example.jp [192.0.2.2]) by a.example.jp (Postfix) id 2; Thu, 29 Apr 2010 23:34:46 +0900",
+		"from a.example.jp (a.example.jp [192.0.2.1]) by b.example.jp (Postfix) id 1; Thu, 29 Apr 2010 23:34:45 +0900",
+	}
+	cv = ParseTrace(je, nil)
+	cx++; if cv.Loop == false { t.Errorf("%s().Loop is false", fn) }
+
+	cv = ParseTrace([]string{"", "neko"}, nil)
+	cx++; if len(cv.Hops) != 0 { t.Errorf("%s() returns %d hops", fn, len(cv.Hops)) }
+	cx++; if cv.Transit   != 0 { t.Errorf("%s().Transit is %v", fn, cv.Transit) }
+	cv = ParseTrace(nil, nil)
+	cx++; if cv.Origin != nil { t.Errorf("%s().Origin is %+v", fn, cv.Origin) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
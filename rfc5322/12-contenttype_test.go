@@ -0,0 +1,124 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc5322
+
+//  _____         _      ______  _____ ____ ____ _________  ____  
+// |_   _|__  ___| |_   / /  _ \|  ___/ ___| ___|___ /___ \|___ \ 
+//   | |/ _ \/ __| __| / /| |_) | |_ | |   |___ \ |_ \ __) | __) |
+//   | |  __/\__ \ |_ / / |  _ <|  _|| |___ ___) |__) / __/ / __/ 
+//   |_|\___||___/\__/_/  |_| \_\_|   \____|____/____/_____|_____|
+import "testing"
+import "reflect"
+import "strings"
+
+func TestTokenize(t *testing.T) {
+	fn := "rfc5322.Tokenize"
+	cx := 0
+	cv := Tokenize(`text/plain; charset="utf-\"8\"" (comment (nested)) [192.0.2.1] na(me`, TSpecials)
+	ae := []Token{
+		{TokenAtom, "text", 0, 4}, {TokenSpecial, "/", 4, 5}, {TokenAtom, "plain", 5, 10}, {TokenSpecial, ";", 10, 11},
+		{TokenAtom, "charset", 12, 19}, {TokenSpecial, "=", 19, 20}, {TokenQuoted, `utf-"8"`, 20, 31},
+		{TokenComment, "comment (nested)", 32, 50}, {TokenLiteral, "192.0.2.1", 51, 62}, {TokenAtom, "na", 63, 65},
+		{TokenComment, "me", 65, 68},
+	}
+	cx++; if reflect.DeepEqual(cv, ae) == false { t.Errorf("%s() returns\n%v, expected\n%v", fn, cv, ae) }
+
+	cv = Tokenize("neko.nyaan@example.jp", Specials)
+	cx++; if len(cv) != 7 { t.Errorf("%s() returns %v", fn, cv) }
+	cv = Tokenize("neko.nyaan@example.jp", TSpecials)
+	cx++; if len(cv) != 3 { t.Errorf("%s() returns %v", fn, cv) }
+	cv = Tokenize(`"unterminated`, TSpecials)
+	cx++; if len(cv) != 1 || cv[0].Value != "unterminated" || cv[0].End != 13 { t.Errorf("%s() returns %v", fn, cv) }
+	cx++; if cv := Tokenize(" \t\r\n", Specials); len(cv) != 0 { t.Errorf("%s() returns %v", fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParseContentType(t *testing.T) {
+	fn := "rfc5322.ParseContentType"
+	cx := 0
+	ae := []struct {
+		testname string; argument string; mediatype string; params map[string]string
+	}{
+		{"Simple", "text/plain", "text/plain", map[string]string{}},
+		{"Parameters", `Multipart/Report; Report-Type=delivery-status; boundary="AA.1/BB" (comment)`,
+			"multipart/report", map[string]string{"report-type": "delivery-status", "boundary": "AA.1/BB"}},
+		{"Spaces", "text / plain ; charset = utf-8", "text/plain", map[string]string{"charset": "utf-8"}},
+		{"RFC2231 charset", "application/x-stuff; title*=us-ascii'en-us'This%20is%20%2A%2A%2Afun%2A%2A%2A",
+			"application/x-stuff", map[string]string{"title": "This is ***fun***"}},
+		{"RFC2231 continuations", `message/external-body; access-type=URL; URL*0="ftp://"; URL*1="cs.utk.edu/pub/moore/bulk-mailer/bulk-mailer.tar"`,
+			"message/external-body", map[string]string{"access-type": "URL", "url": "ftp://cs.utk.edu/pub/moore/bulk-mailer/bulk-mailer.tar"}},
+		{"RFC2231 both", `application/pdf; name*0*=UTF-8''%E7%8C%AB; name*1*=%E3%81%AE.pdf; name*2="(1)"; name="neko.pdf"`,
+			"application/pdf", map[string]string{"name": "猫の.pdf(1)"}},
+		{"RFC2231 out of order", `text/plain; name*1=b; name*0=a; name*3=d`, "text/plain", map[string]string{"name": "ab"}},
+		{"RFC2231 Latin-1", `text/plain; name*=iso-8859-1''Caf%E9.txt`, "text/plain", map[string]string{"name": "Café.txt"}},
+		{"RFC2231 broken", `text/plain; name*=utf-8''%ZZ%E7%8C%AB`, "text/plain", map[string]string{"name": "%ZZ猫"}},
+		{"RFC2047", `text/plain; name="=?UTF-8?B?54yr?=.txt"`, "text/plain", map[string]string{"name": "猫.txt"}},
+		{"Unquoted spaces", "application/pdf; name=neko nyaan.pdf; size=1", "application/pdf",
+			map[string]string{"name": "neko nyaan.pdf", "size": "1"}},
+		{"Duplicated", "text/plain; charset=iso-2022-jp; CHARSET=utf-8", "text/plain", map[string]string{"charset": "iso-2022-jp"}},
+		{"Missing semicolon", "text/plain charset=utf-8 format=flowed", "text/plain", map[string]string{"charset": "utf-8", "format": "flowed"}},
+		{"Trailing semicolon", "text/plain;; charset=utf-8;", "text/plain", map[string]string{"charset": "utf-8"}},
+		{"No value", "text/plain; charset; format=", "text/plain", map[string]string{"format": ""}},
+		{"8-bit", "text/plain; name=\xe7\x8c\xab.txt", "text/plain", map[string]string{"name": "猫.txt"}},
+		{"Invalid", "text", "", map[string]string{}},
+		{"Invalid", "/plain; charset=utf-8", "", map[string]string{"charset": "utf-8"}},
+		{"Empty", "", "", map[string]string{}},
+	}
+	for _, e := range ae {
+		cv, cw := ParseContentType(e.argument)
+		cx++; if cv != e.mediatype { t.Errorf("[%s] %s(%s) returns %s, expected %s", e.testname, fn, e.argument, cv, e.mediatype) }
+		cx++; if reflect.DeepEqual(cw, e.params) == false { t.Errorf("[%s] %s(%s) returns %v, expected %v", e.testname, fn, e.argument, cw, e.params) }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParseContentDisposition(t *testing.T) {
+	fn := "rfc5322.ParseContentDisposition"
+	cx := 0
+	cv, cw := ParseContentDisposition(`Attachment; filename*0*=UTF-8''%E7%8C%AB; filename*1*=.txt; filename="neko.txt"`)
+	cx++; if cv != "attachment"        { t.Errorf("%s() returns %s", fn, cv) }
+	cx++; if cw["filename"] != "猫.txt" { t.Errorf("%s() returns %v", fn, cw) }
+
+	cv, cw = ParseContentDisposition("inline")
+	cx++; if cv != "inline" || len(cw) != 0 { t.Errorf("%s() returns %s %v", fn, cv, cw) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestFormatContentType(t *testing.T) {
+	fn := "rfc5322.FormatContentType"
+	cx := 0
+	ae := []struct {
+		testname string; mediatype string; params map[string]string; expected string
+	}{
+		{"Simple", "Text/Plain", nil, "text/plain"},
+		{"Token", "text/plain", map[string]string{"format": "flowed", "Charset": "utf-8"}, "text/plain; charset=utf-8; format=flowed"},
+		{"Quoted", "multipart/mixed", map[string]string{"boundary": `AA.1/"BB"`}, `multipart/mixed; boundary="AA.1/\"BB\""`},
+		{"Empty", "attachment", map[string]string{"filename": ""}, `attachment; filename=""`},
+		{"UTF-8", "attachment", map[string]string{"filename": "猫 の.txt"}, "attachment; filename*=utf-8''%E7%8C%AB%20%E3%81%AE.txt"},
+		{"Long", "text/plain", map[string]string{"name": "0123456789012345678901234567890123456789012345678901234567890123456789.txt"},
+			`text/plain; name*0=012345678901234567890123456789012345678901234567890123456789; name*1=0123456789.txt`},
+		{"Long UTF-8", "attachment", map[string]string{"filename": "猫猫猫猫猫猫猫猫猫猫猫.txt"},
+			"attachment; filename*0*=utf-8''%E7%8C%AB%E7%8C%AB%E7%8C%AB%E7%8C%AB%E7%8C%AB; " +
+			"filename*1*=%E7%8C%AB%E7%8C%AB%E7%8C%AB%E7%8C%AB%E7%8C%AB%E7%8C%AB.txt"},
+		{"Control", "text/plain", map[string]string{"name": "a\r\nb"}, "text/plain; name*=utf-8''a%0D%0Ab"},
+		{"Invalid name", "text/plain", map[string]string{"na me": "a", "name*": "b", "": "c"}, "text/plain"},
+	}
+	for _, e := range ae {
+		cv := FormatContentType(e.mediatype, e.params)
+		cx++; if cv != e.expected { t.Errorf("[%s] %s() returns\n%s, expected\n%s", e.testname, fn, cv, e.expected) }
+
+		// Round trip
+		cw, ce := ParseContentType(cv)
+		if cw == "" { cw, ce = ParseContentDisposition(cv) }
+		for k, v := range e.params {
+			if isMIMEToken(k) == false || k == "name*" { continue }
+			cx++; if ce[strings.ToLower(k)] != v { t.Errorf("[%s] %s(%s) returns %v", e.testname, "ParseContentType", cv, ce) }
+		}
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
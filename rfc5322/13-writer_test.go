@@ -0,0 +1,115 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc5322
+
+//  _____         _      ______  _____ ____ ____ _________  ____  
+// |_   _|__  ___| |_   / /  _ \|  ___/ ___| ___|___ /___ \|___ \ 
+//   | |/ _ \/ __| __| / /| |_) | |_ | |   |___ \ |_ \ __) | __) |
+//   | |  __/\__ \ |_ / / |  _ <|  _|| |___ ___) |__) / __/ / __/ 
+//   |_|\___||___/\__/_/  |_| \_\_|   \____|____/____/_____|_____|
+import "mime"
+import "time"
+import "strings"
+import "testing"
+
+func TestHeaderWriter(t *testing.T) {
+	fn := "rfc5322.HeaderWriter"
+	cx := 0
+	cw := NewHeaderWriter()
+	cx++; if nyaan := cw.Add("X-Mailer", "mailer-goemon"); nyaan != nil { t.Errorf("%s.Add() returns %s", fn, nyaan) }
+	cx++; if nyaan := cw.Add("Subject", "Returned mail: see transcript for details"); nyaan != nil { t.Errorf("%s.Add() returns %s", fn, nyaan) }
+	cx++; if nyaan := cw.AddAddress("To", FormatAddress("neko@example.jp", "Neko, Nyaan"), "kijitora@example.jp"); nyaan != nil {
+		t.Errorf("%s.AddAddress() returns %s", fn, nyaan)
+	}
+	cx++; if nyaan := cw.AddAddress("From", "MAILER-DAEMON@example.jp"); nyaan != nil { t.Errorf("%s.AddAddress() returns %s", fn, nyaan) }
+	cx++; if nyaan := cw.AddDate("Date", time.Date(2010, 4, 29, 23, 34, 45, 0, time.FixedZone("", 9 * 3600))); nyaan != nil {
+		t.Errorf("%s.AddDate() returns %s", fn, nyaan)
+	}
+	cx++; if nyaan := cw.Add("Received", "from a by b"); nyaan != nil { t.Errorf("%s.Add() returns %s", fn, nyaan) }
+	cx++; if nyaan := cw.Add("Received", "from c by d"); nyaan != nil { t.Errorf("%s.Add() returns %s", fn, nyaan) }
+
+	ae := strings.Join([]string{
+		"Received: from a by b\r\n",
+		"Received: from c by d\r\n",
+		"Date: Thu, 29 Apr 2010 23:34:45 +0900\r\n",
+		"From: MAILER-DAEMON@example.jp\r\n",
+		"To: \"Neko, Nyaan\" <neko@example.jp>, kijitora@example.jp\r\n",
+		"Subject: Returned mail: see transcript for details\r\n",
+		"X-Mailer: mailer-goemon\r\n",
+	}, "")
+	cx++; if cv := string(cw.Bytes()); cv != ae { t.Errorf("%s.Bytes() returns\n%s, expected\n%s", fn, cv, ae) }
+
+	// Round trip with ReadHeader()
+	cv, _ := ReadHeader(strings.NewReader(string(cw.Bytes()) + "\r\n"))
+	cx++; if len(cv.Fields) != 7 || cv.Flags != 0 { t.Errorf("%s: ReadHeader() returns %d fields, flags %d", fn, len(cv.Fields), cv.Flags) }
+	cx++; if cv.Get("To") != `"Neko, Nyaan" <neko@example.jp>, kijitora@example.jp` { t.Errorf("%s: To is %s", fn, cv.Get("To")) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestHeaderWriterErrors(t *testing.T) {
+	fn := "rfc5322.HeaderWriter"
+	cx := 0
+	cw := NewHeaderWriter()
+	cx++; if nyaan := cw.Add("Subject", "Nyaan\r\nBcc: neko@example.jp"); nyaan != ErrHeaderInjection { t.Errorf("%s.Add() returns %v", fn, nyaan) }
+	cx++; if nyaan := cw.Add("Subject", "Nyaan\nBcc: neko@example.jp"); nyaan != ErrHeaderInjection { t.Errorf("%s.Add() returns %v", fn, nyaan) }
+	cx++; if nyaan := cw.AddAddress("To", "neko@example.jp\r\nBcc: a@example.jp"); nyaan != ErrHeaderInjection { t.Errorf("%s.AddAddress() returns %v", fn, nyaan) }
+	cx++; if nyaan := cw.Add("", "Nyaan"); nyaan != ErrInvalidFieldName { t.Errorf("%s.Add() returns %v", fn, nyaan) }
+	cx++; if nyaan := cw.Add("X Neko", "Nyaan"); nyaan != ErrInvalidFieldName { t.Errorf("%s.Add() returns %v", fn, nyaan) }
+	cx++; if nyaan := cw.Add("X-Neko:", "Nyaan"); nyaan != ErrInvalidFieldName { t.Errorf("%s.Add() returns %v", fn, nyaan) }
+	cx++; if nyaan := cw.Add("X-Long", strings.Repeat("n", 1000)); nyaan != ErrWordTooLong { t.Errorf("%s.Add() returns %v", fn, nyaan) }
+	cx++; if len(cw.Fields) != 0 { t.Errorf("%s.Fields has %d fields", fn, len(cw.Fields)) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestHeaderWriterFolding(t *testing.T) {
+	fn := "rfc5322.HeaderWriter.Add"
+	cx := 0
+	cw := NewHeaderWriter()
+	ce := strings.Repeat("nyaan ", 40)
+	cx++; if nyaan := cw.Add("X-Folded", ce); nyaan != nil { t.Fatalf("%s() returns %s", fn, nyaan) }
+	cx++; if nyaan := cw.Add("Subject", "Nekochan ねこ にゃーん 猫 is a cat"); nyaan != nil { t.Fatalf("%s() returns %s", fn, nyaan) }
+	cx++; if nyaan := cw.Add("X-Empty", ""); nyaan != nil { t.Fatalf("%s() returns %s", fn, nyaan) }
+	cx++; if nyaan := cw.Add("X-Long", strings.Repeat("n", 100) + " " + strings.Repeat("y", 100)); nyaan != nil { t.Fatalf("%s() returns %s", fn, nyaan) }
+
+	for _, e := range cw.Fields {
+		// Every line is folded within 78 octets except a long word, unfolding returns the original body
+		for _, f := range strings.Split(strings.TrimSuffix(string(e.Raw), "\r\n"), "\r\n") {
+			cx++; if len(f) > 78 && strings.Count(strings.TrimSpace(f), " ") > 1 { t.Errorf("%s(%s) has a long line: %s", fn, e.Name, f) }
+		}
+		cx++; if cv := strings.TrimSpace(Unfold(string(e.Raw)[len(e.Name) + 1:])); cv != e.Value { t.Errorf("%s(%s) is\n%s, expected\n%s", fn, e.Name, cv, e.Value) }
+	}
+	cx++; if cv := cw.Fields[0].Value; cv != strings.TrimSpace(ce) { t.Errorf("%s() returns %s", fn, cv) }
+	cx++; if cv := string(cw.Fields[2].Raw); cv != "X-Empty: \r\n" { t.Errorf("%s() returns [%s]", fn, cv) }
+	cx++; if cv := strings.Count(string(cw.Fields[3].Raw), "\r\n"); cv != 2 { t.Errorf("%s() returns %d lines", fn, cv) }
+
+	cv, _ := ReadHeader(strings.NewReader(string(cw.Bytes())))
+	cx++; if cv.Flags != 0 { t.Errorf("%s: ReadHeader() returns flags %d", fn, cv.Flags) }
+	cz, nyaan := new(mime.WordDecoder).DecodeHeader(cv.Get("Subject"))
+	cx++; if nyaan != nil || cz != "Nekochan ねこ にゃーん 猫 is a cat" { t.Errorf("%s: Subject is decoded as %s (%v)", fn, cz, nyaan) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestFormatAddress(t *testing.T) {
+	fn := "rfc5322.FormatAddress"
+	cx := 0
+	ae := []struct {
+		testname string; email string; name string; expected string
+	}{
+		{"No name", "neko@example.jp", "", "neko@example.jp"},
+		{"Angle", "<neko@example.jp>", "", "neko@example.jp"},
+		{"Atoms", "neko@example.jp", " Neko   Nyaan ", "Neko Nyaan <neko@example.jp>"},
+		{"Specials", "neko@example.jp", "Neko, Nyaan", `"Neko, Nyaan" <neko@example.jp>`},
+		{"Quote", "neko@example.jp", `Neko "Nyaan" Jr.`, `"Neko \"Nyaan\" Jr." <neko@example.jp>`},
+		{"UTF-8", "neko@example.jp", "ねこ", "=?utf-8?b?44Gt44GT?= <neko@example.jp>"},
+		{"Latin", "neko@example.jp", "Café au lait", "=?utf-8?q?Caf=C3=A9_au_lait?= <neko@example.jp>"},
+	}
+	for _, e := range ae {
+		cv := FormatAddress(e.email, e.name)
+		cx++; if cv != e.expected { t.Errorf("[%s] %s(%s, %s) returns %s, expected %s", e.testname, fn, e.email, e.name, cv, e.expected) }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//  ____  _____ ____ ____ _________  ____     ______            _             _  _____                   
+// |  _ \|  ___/ ___| ___|___ /___ \|___ \   / / ___|___  _ __ | |_ ___ _ __ | ||_   _|   _ _ __   ___ _ 
+// | |_) | |_ | |   |___ \ |_ \ __) | __) | / / |   / _ \| '_ \| __/ _ \ '_ \| __|| || | | | '_ \ / _ (_)
+// |  _ <|  _|| |___ ___) |__) / __/ / __/ / /| |__| (_) | | | | ||  __/ | | | |_ | || |_| | |_) |  __/_ 
+// |_| \_\_|   \____|____/____/_____|_____/_/  \____\___/|_| |_|\__\___|_| |_|\__||_| \__, | .__/ \___(_)
+//                                                                                    |___/|_|           
+
+package rfc5322
+import "fmt"
+import "mime"
+import "sort"
+import "strconv"
+import "strings"
+import "unicode/utf8"
+
+// ParseContentType returns the media type and the parameters of Content-Type header. RFC2231
+// continuations and charsets, RFC2047 encoded-words in parameters, unquoted values including white
+// spaces, missing ";", and duplicated parameters (the first one wins) are dealt.
+//   Arguments:
+//     - value (string): Content-Type such as `text/plain; charset="utf-8"; format=flowed`.
+//   Returns:
+//     - (string):            Media type in lower case such as "text/plain", "" when it is not valid.
+//     - (map[string]string): Decoded parameters, keys are in lower case.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc2045#section-5.1
+//     - https://datatracker.ietf.org/doc/html/rfc2231
+func ParseContentType(value string) (string, map[string]string) {
+	mediatype, params := parseParameters(value)
+	if p := strings.IndexByte(mediatype, '/'); p < 1 || p == len(mediatype) - 1 { mediatype = "" }
+	return mediatype, params
+}
+
+// ParseContentDisposition returns the disposition type and the parameters of Content-Disposition.
+//   Arguments:
+//     - value (string): Content-Disposition such as `attachment; filename*=UTF-8''%E7%8C%AB.txt`.
+//   Returns:
+//     - (string):            Disposition type in lower case such as "attachment".
+//     - (map[string]string): Decoded parameters, keys are in lower case.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc2183
+func ParseContentDisposition(value string) (string, map[string]string) {
+	return parseParameters(value)
+}
+
+// FormatContentType returns Content-Type or Content-Disposition with the parameters in order of the
+// name. A value including non-ASCII characters is encoded as RFC2231 in UTF-8, a long value is split
+// into continuations.
+//   Arguments:
+//     - mediatype (string):         Media type such as "text/plain" or disposition type such as "attachment".
+//     - params (map[string]string): Parameters, names are converted to lower case.
+//   Returns:
+//     - (string): Field body such as `text/plain; charset=utf-8; name*=utf-8''%E7%8C%AB.txt`.
+func FormatContentType(mediatype string, params map[string]string) string {
+	fields := []string{strings.ToLower(strings.TrimSpace(mediatype))}
+	names  := make([]string, 0, len(params))
+	for e := range params { names = append(names, e) }
+	sort.Strings(names)
+
+	for _, e := range names {
+		// Invalid parameter names are not written
+		name := strings.ToLower(e); if isMIMEToken(name) == false || strings.IndexByte(name, '*') > -1 { continue }
+		fields = append(fields, formatParameter(name, params[e])...)
+	}
+	return strings.Join(fields, "; ")
+}
+
+// parameterSection is a section of RFC2231 continuations such as "filename*1*"
+type parameterSection struct {
+	value   string
+	encoded bool
+}
+
+// parseParameters returns the value before the first ";" and the parameters.
+func parseParameters(value string) (string, map[string]string) {
+	tokens := Tokenize(value, TSpecials)
+	params := map[string]string{}
+	thetype := strings.Builder{}
+	sections := map[string]map[int]parameterSection{}
+	j := 0
+
+	for ; j < len(tokens); j++ {
+		// The media type or the disposition type until ";"
+		e := tokens[j]
+		if e.Kind == TokenSpecial && e.Value == ";" { break }
+		if e.Kind == TokenComment { continue }
+		if e.Kind == TokenAtom && thetype.Len() > 0 && isParameterAt(tokens, j) { break } // Missing ";"
+		thetype.WriteString(e.Value)
+	}
+
+	for j < len(tokens) {
+		// Each parameter: name "=" value
+		if tokens[j].Kind != TokenAtom || isParameterAt(tokens, j) == false { j++; continue }
+		name := strings.ToLower(tokens[j].Value); j += 2
+		cv, last := strings.Builder{}, -1
+
+		for ; j < len(tokens); j++ {
+			// The value until ";", the next parameter without ";", or the end
+			e := tokens[j]
+			if e.Kind == TokenSpecial && e.Value == ";" { break }
+			if e.Kind == TokenComment { continue }
+			if e.Kind == TokenAtom && last > -1 && e.Offset > last && isParameterAt(tokens, j) { break }
+
+			// Unquoted white spaces in the value such as "filename=neko nyaan.txt" are kept
+			if last > -1 && e.Offset > last && cv.Len() > 0 { cv.WriteByte(' ') }
+			if e.Kind == TokenLiteral { cv.WriteString("[" + e.Value + "]") } else { cv.WriteString(e.Value) }
+			last = e.End
+		}
+
+		if p := strings.IndexByte(name, '*'); p > 0 {
+			// RFC2231: "name*", "name*0", "name*0*", "name*1*"
+			base, rest := name[:p], name[p + 1:]
+			section := parameterSection{value: cv.String(), encoded: rest == "" || strings.HasSuffix(rest, "*")}
+			index, nyaan := strconv.Atoi(strings.TrimSuffix(rest, "*")); if rest == "" { index, nyaan = 0, nil }
+			if nyaan != nil || index < 0 { continue }
+
+			if sections[base] == nil { sections[base] = map[int]parameterSection{} }
+			if _, ok := sections[base][index]; ok == false { sections[base][index] = section }
+			continue
+		}
+		if _, ok := params[name]; ok { continue } // The first one wins
+		params[name] = cv.String()
+		if strings.Contains(params[name], "=?") {
+			// RFC2047 encoded-word in the parameter such as `filename="=?UTF-8?B?55Gr?="`
+			if cw, nyaan := new(mime.WordDecoder).DecodeHeader(params[name]); nyaan == nil { params[name] = cw }
+		}
+	}
+
+	for base, e := range sections {
+		// RFC2231 parameters take precedence over the same name parameter
+		if cv := decodeContinuations(e); cv != "" { params[base] = cv }
+	}
+	return strings.ToLower(thetype.String()), params
+}
+
+// isParameterAt returns true if the token at the index is followed by "=".
+func isParameterAt(tokens []Token, index int) bool {
+	if index + 1 >= len(tokens) { return false }
+	return tokens[index + 1].Kind == TokenSpecial && tokens[index + 1].Value == "="
+}
+
+// decodeContinuations concatenates RFC2231 sections and converts them to UTF-8.
+func decodeContinuations(sections map[int]parameterSection) string {
+	buffer  := []byte{}
+	charset := ""
+	for j := 0; ; j++ {
+		// Concatenate sections from 0 until the missing section
+		e, ok := sections[j]; if ok == false { break }
+		if e.encoded == false { buffer = append(buffer, e.value...); continue }
+
+		cv := e.value
+		if j == 0 {
+			// charset'language'encoded-text
+			if p := strings.SplitN(cv, "'", 3); len(p) == 3 { charset, cv = strings.ToLower(p[0]), p[2] }
+		}
+		buffer = append(buffer, percentDecode(cv)...)
+	}
+
+	if utf8.Valid(buffer) { return string(buffer) }
+	switch charset {
+		case "iso-8859-1", "latin1", "windows-1252", "us-ascii", "utf-8", "":
+			// Each octet of ISO-8859-1 is the code point of Unicode
+			cv := strings.Builder{}
+			for _, e := range buffer { cv.WriteRune(rune(e)) }
+			return cv.String()
+	}
+	return string(buffer)
+}
+
+// percentDecode decodes "%XX", an invalid sequence is kept as is.
+func percentDecode(text string) []byte {
+	cv := make([]byte, 0, len(text))
+	for j := 0; j < len(text); j++ {
+		if text[j] == '%' && j + 2 < len(text) && isHexDigit(text[j + 1]) && isHexDigit(text[j + 2]) {
+			ch, _ := strconv.ParseUint(text[j + 1:j + 3], 16, 8)
+			cv = append(cv, byte(ch)); j += 2; continue
+		}
+		cv = append(cv, text[j])
+	}
+	return cv
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// isMIMEToken returns true if the string is token of RFC2045 5.1
+func isMIMEToken(text string) bool {
+	if text == "" { return false }
+	for j := 0; j < len(text); j++ {
+		if text[j] < 33 || text[j] > 126 || strings.IndexByte(TSpecials, text[j]) > -1 { return false }
+	}
+	return true
+}
+
+// formatParameter returns the parameter as `name=value`, `name="quoted value"`, `name*=utf-8''...`,
+// or continuations such as `name*0*=utf-8''...`, `name*1*=...`.
+func formatParameter(name, value string) []string {
+	const width = 60 // The maximum length of each section
+	printable := true
+	for j := 0; j < len(value); j++ { if value[j] < 32 || value[j] > 126 { printable = false; break } }
+
+	if printable {
+		// US-ASCII: split into continuations when the value is too long
+		pieces := []string{}
+		for j := 0; j == 0 || j < len(value); j += width { pieces = append(pieces, value[j:min(j + width, len(value))]) }
+		if len(pieces) == 1 { return []string{name + "=" + quoteValue(value)} }
+
+		fields := []string{}
+		for j, e := range pieces { fields = append(fields, name + "*" + strconv.Itoa(j) + "=" + quoteValue(e)) }
+		return fields
+	}
+
+	// Non-ASCII characters or control characters: percent-encoded in UTF-8
+	if utf8.ValidString(value) == false { value = decodeContinuations(map[int]parameterSection{0: {value: value}}) }
+	encoded := []string{}
+	for _, e := range value {
+		// Each character, attribute-char is a character of token except "*", "'", and "%"
+		cv := string(e)
+		if len(cv) == 1 && cv[0] > 32 && cv[0] < 127 && strings.IndexByte(TSpecials + "*'%", cv[0]) < 0 { encoded = append(encoded, cv); continue }
+		cw := ""; for j := 0; j < len(cv); j++ { cw += fmt.Sprintf("%%%02X", cv[j]) }
+		encoded = append(encoded, cw)
+	}
+	if cv := strings.Join(encoded, ""); len(cv) <= width { return []string{name + "*=utf-8''" + cv} }
+
+	fields, buffer := []string{}, "utf-8''"
+	for _, e := range encoded {
+		// Do not split a character encoded as "%XX%XX%XX"
+		if len(buffer) + len(e) > width {
+			fields = append(fields, name + "*" + strconv.Itoa(len(fields)) + "*=" + buffer); buffer = ""
+		}
+		buffer += e
+	}
+	return append(fields, name + "*" + strconv.Itoa(len(fields)) + "*=" + buffer)
+}
+
+// quoteValue returns the value as it is if it is a token, otherwise as a quoted-string.
+func quoteValue(value string) string {
+	if isMIMEToken(value) { return value }
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
@@ -1,4 +1,4 @@
-// Copyright (C) 2024-2025 azumakuniyuki and sisimai development team, All rights reserved.
+// Copyright (C) 2024-2026 azumakuniyuki and sisimai development team, All rights reserved.
 // This software is distributed under The BSD 2-Clause License.
 //  ____  _____ ____ ____ _________  ____     ______        _         
 // |  _ \|  ___/ ___| ___|___ /___ \|___ \   / /  _ \  __ _| |_ ___ _ 
@@ -55,6 +55,8 @@ package rfc5322
   the last two digits of the zone MUST be within the range 00 through 59.
 **************************************************************************************************/
 import "fmt"
+import "time"
+import "errors"
 import "slices"
 import "strings"
 import "strconv"
@@ -68,6 +70,7 @@ var dayofweek = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
 //     - date0 (string): Date string.
 //   Returns:
 //     - (string): Tidied date string.
+//   NOTE: Use ParseDate() and FormatDate() for the time.Time value and the strict validation.
 //   See:
 //     - https://datatracker.ietf.org/doc/html/rfc5322#section-3.3
 //     - https://datatracker.ietf.org/doc/html/rfc3339
@@ -120,7 +123,7 @@ func Date(date0 string) string {
 				p[4] = fmt.Sprintf("%02d:%02d:%02d", ct[0], ct[1], ct[2])
 
 			} else {
-				// NOTE: Should we support other formatted date string like the followings?
+				// Other formatted date strings like the followings are parsed by ParseDate()
 				// - "Sun, 29 May 2014 1:2 +0900"
 				// - "4/29/01 11:34:45 PM",
 				// - "2014-03-26 00-01-19",
@@ -146,3 +149,263 @@ func Date(date0 string) string {
 	return w.String()
 }
 
+
+var ErrUnknownDateFormat = errors.New("rfc5322: unknown date format")
+var ErrDateOutOfRange    = errors.New("rfc5322: date or time is out of range")
+var ErrWeekdayMismatch   = errors.New("rfc5322: day-of-week does not match the date")
+var ErrMissingTimeZone   = errors.New("rfc5322: time zone is missing, dealt as UTC")
+
+// Offset from UTC in minutes of time zone names: obs-zone in RFC5322 and others found in the world
+var zoneoffset = map[string]int{
+	"UT":   0,   "UTC":  0,   "GMT":  0,   "Z":    0,   "WET":  0,   "WEST": 60,  "BST":  60,
+	"EST":  -300, "EDT": -240, "CST": -360, "CDT": -300, "MST": -420, "MDT": -360, "PST": -480,
+	"PDT":  -420, "AKST": -540, "AKDT": -480, "HST": -600,
+	"CET":  60,  "CEST": 120, "MET":  60,  "MEST": 120, "EET":  120, "EEST": 180, "MSK":  180,
+	"IST":  330, "PKT":  300, "ICT":  420, "WIB":  420, "SGT":  480, "HKT":  480, "PHT":  480,
+	"KST":  540, "JST":  540, "AWST": 480, "ACST": 570, "AEST": 600, "AEDT": 660, "NZST": 720,
+	"NZDT": 780,
+}
+var monthnames = []string{
+	"january", "february", "march", "april", "may", "june", "july", "august", "september",
+	"october", "november", "december",
+}
+var daysofweek = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// ParseDate parses the date string in RFC5322, obsolete, and other formats found in the real world.
+//   Arguments:
+//     - date0 (string): Date string such as "Thu, 29 Apr 2019 23:34:45 -0800 (PST)".
+//   Returns:
+//     - (time.Time): Parsed time, valid even when the error is ErrWeekdayMismatch or ErrMissingTimeZone.
+//     - (error):     ErrUnknownDateFormat, ErrDateOutOfRange, ErrWeekdayMismatch, ErrMissingTimeZone.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-3.3
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-4.3
+//     - https://datatracker.ietf.org/doc/html/rfc3339
+func ParseDate(date0 string) (time.Time, error) {
+	datestring := date0
+	zonenoted := "" // Time zone in the comment such as "(PST)", "(GMT+02:00)"
+	for moji.Aligned(datestring, []string{"(", ")"}) {
+		// Remove comments and keep the last comment as a hint of the time zone
+		p1 := strings.IndexByte(datestring, '('); p2 := strings.IndexByte(datestring[p1:], ')') + p1
+		zonenoted  = strings.TrimSpace(datestring[p1 + 1:p2])
+		datestring = datestring[:p1] + " " + datestring[p2 + 1:]
+	}
+	datestring = strings.ReplaceAll(datestring, ",", " ")
+
+	y, m, d, weekday := 0, 0, 0, -1 // Year, Month, Day, Day of week
+	H, M, S, N, ampm := -1, 0, 0, 0, "" // Hour, Minute, Second, Nanosecond, AM or PM
+	yearwidth := 0                    // The number of digits of the year
+	zonegiven := false                // The time zone is given in the date string
+	offset    := 0                    // Offset from UTC in minutes
+	zonename  := ""                   // Time zone name such as "JST"
+
+	for _, e := range strings.Fields(datestring) {
+		// Check each piece of the date string
+		if p := strings.IndexByte(e, 'T'); p == 10 && moji.ContainsOnlyNumbers(e[:4]) {
+			// ISO 8601 such as "2014-03-26T00:01:19+09:00", "20140326T000119Z" is not supported
+			if parseDatePart(e[:p], &y, &m, &d, &yearwidth) == false { return time.Time{}, ErrUnknownDateFormat }
+			e = e[p + 1:]
+		}
+		lower := strings.ToLower(strings.TrimRight(e, "."))
+
+		if len(lower) > 2 && isAlphabet(lower) {
+			// Day of week, month name, AM/PM, or a time zone name
+			if cv := indexOfName(daysofweek, lower); cv > -1 { weekday = cv; continue }
+			if cv := indexOfName(monthnames, lower); cv > -1 { m = cv + 1;   continue }
+		}
+		if lower == "am" || lower == "pm" || lower == "a.m" || lower == "p.m" { ampm = lower[0:1]; continue }
+
+		if cv, ok := zoneoffset[strings.ToUpper(e)]; ok && zonegiven == false {
+			// Time zone name such as "JST", "GMT", "EST", and "Z"
+			offset, zonename, zonegiven = cv, strings.ToUpper(e), true; continue
+		}
+		if len(e) == 1 && isAlphabet(lower) && lower != "j" && zonegiven == false {
+			// Military time zones: RFC5322 4.3 says they SHOULD all be considered equivalent to "-0000"
+			zonegiven = true; continue
+		}
+		if cv, ok := parseZoneOffset(e); ok {
+			// Numeric time zone offset such as "+0900", "-08:00", or "GMT+02:00"
+			if cv == 9999 { return time.Time{}, ErrDateOutOfRange }
+			if zonegiven && zonename == "" { continue }
+			offset, zonename, zonegiven = cv, "", true; continue
+		}
+
+		if strings.IndexByte(e, ':') > 0 {
+			// Time such as "23:34:45", "1:2", "11:34:45.123", "00:01:19Z", "00:01:19+09:00"
+			if H > -1 { continue }
+			cv, cz := splitZone(e)
+			if parseTimePart(cv, ":", &H, &M, &S, &N) == false { return time.Time{}, ErrUnknownDateFormat }
+			if cz != "" {
+				// Time zone attached to the time
+				if cw, ok := parseZoneOffset(cz); ok && cw != 9999 { offset, zonename, zonegiven = cw, "", true }
+			}
+			continue
+		}
+
+		if strings.ContainsAny(e, "-/.") && strings.IndexFunc(e, func(r rune) bool { return r >= '0' && r <= '9' }) > -1 {
+			// Date such as "2014-03-26", "29-04-2017", "4/29/01", "26.03.2014", or the time separated
+			// by "-" such as "00-01-19" after the date
+			if y > 0 && H < 0 && parseTimePart(e, "-", &H, &M, &S, &N) { continue }
+			if y > 0 { continue }
+			if parseDatePart(e, &y, &m, &d, &yearwidth) == false { return time.Time{}, ErrUnknownDateFormat }
+			continue
+		}
+
+		if moji.ContainsOnlyNumbers(e) {
+			// Day of month, year, or 3-digit day like "029"
+			cv, _ := strconv.Atoi(e)
+			switch {
+				case len(e) <  3 && d == 0:                       d = cv
+				case len(e) <  3 && y == 0:                       y = cv; yearwidth = len(e)
+				case len(e) == 3 && e[0] == '0' && d == 0:        d = cv
+				case len(e) == 3 && y == 0:                       y = cv; yearwidth = 3
+				case len(e) == 4 && y == 0:                       y = cv; yearwidth = 4
+				case len(e) == 4 && y > 0 && yearwidth < 3 && d == 0: d = y; y = cv; yearwidth = 4
+			}
+		}
+	}
+	if y == 0 || m == 0 || d == 0 || H < 0 { return time.Time{}, ErrUnknownDateFormat }
+
+	if yearwidth == 2 { if y < 50 { y += 2000 } else { y += 1900 } } // RFC5322 4.3 obs-year
+	if yearwidth == 3 { y += 1900 }
+
+	if ampm != "" {
+		// 12-hour clock such as "11:34:45 PM"
+		if H < 1 || H > 12 { return time.Time{}, ErrDateOutOfRange }
+		if ampm == "p" && H < 12 { H += 12 }
+		if ampm == "a" && H == 12 { H = 0 }
+	}
+	if m > 12 || d > daysIn(m, y) || H > 23 || M > 59 || S > 60 { return time.Time{}, ErrDateOutOfRange }
+
+	var nyaan error
+	if zonegiven == false {
+		// Use the time zone in the comment such as "(PST)" or "(GMT+02:00)" as a hint
+		if cv, ok := zoneoffset[strings.ToUpper(zonenoted)]; ok {
+			offset, zonename = cv, strings.ToUpper(zonenoted)
+
+		} else if cv, ok := parseZoneOffset(zonenoted); ok && cv != 9999 {
+			offset = cv
+
+		} else {
+			nyaan = ErrMissingTimeZone
+		}
+	}
+
+	timezone := time.UTC; if offset != 0 || zonename != "" { timezone = time.FixedZone(zonename, offset * 60) }
+	datetime := time.Date(y, time.Month(m), d, H, M, S, N, timezone)
+	if weekday > -1 && int(datetime.Weekday()) != weekday { return datetime, ErrWeekdayMismatch }
+	return datetime, nyaan
+}
+
+// FormatDate returns the date string formatted as RFC5322 date-time.
+//   Arguments:
+//     - date1 (time.Time): Time to be formatted.
+//   Returns:
+//     - (string): Date string such as "Thu, 29 Apr 2010 23:34:45 +0900".
+func FormatDate(date1 time.Time) string {
+	return date1.Format("Mon, 02 Jan 2006 15:04:05 -0700")
+}
+
+// parseDatePart parses the date separated by "-", "/", or "." such as "2014-03-26", "4/29/01".
+func parseDatePart(text string, y, m, d, w *int) bool {
+	separator := "-"; for _, e := range []string{"/", "."} { if strings.Contains(text, e) { separator = e } }
+	piece := strings.Split(text, separator); if len(piece) != 3 { return false }
+	digit := [3]int{}
+
+	for j, e := range piece {
+		// Each piece should be a number except the month name such as "26-Mar-2014"
+		if cv := indexOfName(monthnames, strings.ToLower(e)); cv > -1 && j == 1 { digit[j] = -(cv + 1); continue }
+		if moji.ContainsOnlyNumbers(e) == false || len(e) > 4 { return false }
+		digit[j], _ = strconv.Atoi(e)
+	}
+
+	if len(piece[0]) == 4 {
+		// Year first: "2014-03-26", "2014/03/26"
+		*y, *m, *d, *w = digit[0], digit[1], digit[2], 4
+
+	} else if separator == "/" && digit[0] <= 12 {
+		// The US style: "4/29/01", "04/29/2001"
+		*m, *d, *y, *w = digit[0], digit[1], digit[2], len(piece[2])
+
+	} else if digit[1] > 12 && digit[0] <= 12 {
+		// Month first: "04-29-2017"
+		*m, *d, *y, *w = digit[0], digit[1], digit[2], len(piece[2])
+
+	} else {
+		// Day first: "29-04-2017", "29/04/2017", "26.03.2014"
+		*d, *m, *y, *w = digit[0], digit[1], digit[2], len(piece[2])
+	}
+	if *m < 0 { *m = -*m }
+	return *m > 0 && *d > 0
+}
+
+// parseTimePart parses the time such as "23:34:45", "1:2", "11:34:45.123".
+func parseTimePart(text, separator string, H, M, S, N *int) bool {
+	piece := strings.Split(text, separator); if len(piece) < 2 || len(piece) > 3 { return false }
+	digit := [4]int{0, 0, 0, 0}
+
+	for j, e := range piece {
+		// Hour, minute, second, and fractional second
+		if j == 2 && strings.IndexByte(e, '.') > 0 {
+			// Fractional second such as "45.123"
+			cv := e[strings.IndexByte(e, '.') + 1:]; e = e[:strings.IndexByte(e, '.')]
+			if moji.ContainsOnlyNumbers(cv) == false { return false }
+			cv = (cv + "000000000")[:9]; digit[3], _ = strconv.Atoi(cv)
+		}
+		if moji.ContainsOnlyNumbers(e) == false || len(e) > 2 { return false }
+		digit[j], _ = strconv.Atoi(e)
+	}
+	if separator == "-" && (len(piece) != 3 || digit[0] > 23 || digit[1] > 59 || digit[2] > 60) { return false }
+	*H, *M, *S, *N = digit[0], digit[1], digit[2], digit[3]
+	return true
+}
+
+// parseZoneOffset parses the numeric time zone such as "+0900", "-08:00", "+9", or "GMT+02:00".
+// The 1st return value is 9999 when the minutes is out of range.
+func parseZoneOffset(text string) (int, bool) {
+	text = strings.ToUpper(text)
+	for _, e := range []string{"GMT", "UTC", "UT"} {
+		// "GMT+02:00", "UTC+9", "UT-0500"
+		if strings.HasPrefix(text, e) && len(text) > len(e) + 1 { text = text[len(e):]; break }
+	}
+	if len(text) < 2 || (text[0] != '+' && text[0] != '-') { return 0, false }
+
+	digits := strings.ReplaceAll(text[1:], ":", ""); if moji.ContainsOnlyNumbers(digits) == false { return 0, false }
+	hh, mm := 0, 0
+	switch len(digits) {
+		case 1, 2: hh, _ = strconv.Atoi(digits)
+		case 4:    hh, _ = strconv.Atoi(digits[0:2]); mm, _ = strconv.Atoi(digits[2:4])
+		default:   return 0, false
+	}
+	if mm > 59 || hh > 23 { return 9999, true }
+	if text[0] == '-' { return -(hh * 60 + mm), true }
+	return hh * 60 + mm, true
+}
+
+// splitZone splits the time and the time zone attached to the time such as "00:01:19+09:00".
+func splitZone(text string) (string, string) {
+	if strings.HasSuffix(text, "Z") || strings.HasSuffix(text, "z") { return text[:len(text) - 1], "+0000" }
+	if p := strings.IndexAny(text, "+-"); p > 0 { return text[:p], text[p:] }
+	return text, ""
+}
+
+// indexOfName returns the index of the full name or the abbreviated name such as "Thu", "Thursday".
+func indexOfName(names []string, text string) int {
+	if len(text) < 3 { return -1 }
+	for j, e := range names {
+		// "thu" or "thursday", "sep" or "sept" or "september"
+		if text == e || text == e[0:3] || (len(text) > 3 && strings.HasPrefix(e, text)) { return j }
+	}
+	return -1
+}
+
+// isAlphabet returns true if the string consists of ASCII letters only.
+func isAlphabet(text string) bool {
+	for _, e := range text { if (e < 'a' || e > 'z') && (e < 'A' || e > 'Z') && e != '.' { return false } }
+	return text != ""
+}
+
+// daysIn returns the number of days in the month of the year.
+func daysIn(m, y int) int {
+	return time.Date(y, time.Month(m) + 1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//  ____  _____ ____ ____ _________  ____     ___   _                _             
+// |  _ \|  ___/ ___| ___|___ /___ \|___ \   / / | | | ___  __ _  __| | ___ _ __ _ 
+// | |_) | |_ | |   |___ \ |_ \ __) | __) | / /| |_| |/ _ \/ _` |/ _` |/ _ \ '__(_)
+// |  _ <|  _|| |___ ___) |__) / __/ / __/ / / |  _  |  __/ (_| | (_| |  __/ |   _ 
+// |_| \_\_|   \____|____/____/_____|_____/_/  |_| |_|\___|\__,_|\__,_|\___|_|  (_)
+
+package rfc5322
+import "io"
+import "bufio"
+import "bytes"
+import "strings"
+
+const (
+	HeaderLineTooLong  = 1 << iota // A line is longer than 998 octets excluding CRLF
+	HeaderBareLF                   // A line ends with LF without CR
+	HeaderObsoleteWSP              // Whitespace before ":" or a folded line consisting only of whitespace
+	HeaderEightBit                 // A line includes octets greater than 127
+	HeaderBrokenLine               // A line without ":" or a folded line without the preceding field
+)
+
+// Field is a header field read by ReadHeader().
+type Field struct {
+	Name   string // Field name as it appears such as "Received"
+	Value  string // Unfolded field body without the leading and trailing whitespace
+	Raw    []byte // Raw bytes of the field including folded lines and line terminators
+	Offset int64  // Byte offset of the first byte of the field from the beginning of the reader
+	Flags  uint8  // Flags such as HeaderBareLF found in the field
+}
+
+// End returns the byte offset of the next byte of the field.
+func (this *Field) End() int64 {
+	return this.Offset + int64(len(this.Raw))
+}
+
+// Header is a header section keeping the order and the duplicates of fields.
+type Header struct {
+	Fields []*Field // Header fields in order of appearance
+	Size   int64    // The number of bytes read including the empty line after the header section
+	Flags  uint8    // Flags found in all the fields
+}
+
+// Get returns the value of the first field of the given name.
+//   Arguments:
+//     - name (string): Field name such as "Subject", case-insensitive.
+//   Returns:
+//     - (string): Unfolded value of the first field, empty if the field does not exist.
+func (this *Header) Get(name string) string {
+	if cv := this.Field(name); cv != nil { return cv.Value }
+	return ""
+}
+
+// Values returns the values of all the fields of the given name in order of appearance.
+//   Arguments:
+//     - name (string): Field name such as "Received", case-insensitive.
+//   Returns:
+//     - ([]string): Unfolded values.
+func (this *Header) Values(name string) []string {
+	values := []string{}
+	for _, e := range this.FieldsOf(name) { values = append(values, e.Value) }
+	return values
+}
+
+// Field returns the first field of the given name.
+//   Arguments:
+//     - name (string): Field name such as "Subject", case-insensitive.
+//   Returns:
+//     - (*Field): The first field, nil if the field does not exist.
+func (this *Header) Field(name string) *Field {
+	for _, e := range this.Fields { if strings.EqualFold(e.Name, name) { return e } }
+	return nil
+}
+
+// FieldsOf returns all the fields of the given name in order of appearance.
+//   Arguments:
+//     - name (string): Field name such as "Received", case-insensitive.
+//   Returns:
+//     - ([]*Field): Fields of the name.
+func (this *Header) FieldsOf(name string) []*Field {
+	fields := []*Field{}
+	for _, e := range this.Fields { if strings.EqualFold(e.Name, name) { fields = append(fields, e) } }
+	return fields
+}
+
+// ReadHeader reads a header section until the empty line or the end of the reader.
+//   Arguments:
+//     - r (io.Reader): Message. When r is a *bufio.Reader, the body can be read from r after this
+//                      function returns.
+//   Returns:
+//     - (*Header): The header section.
+//     - (error):   An error except io.EOF returned from the reader.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-2.2
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-4.5
+func ReadHeader(r io.Reader) (*Header, error) {
+	reader, ok := r.(*bufio.Reader); if ok == false { reader = bufio.NewReader(r) }
+	header := &Header{Fields: []*Field{}}
+	var field *Field
+
+	for {
+		// Read each line including the line terminator
+		line, nyaan := reader.ReadBytes('\n')
+		if len(line) == 0 {
+			if nyaan == nil || nyaan == io.EOF { break }
+			return header, nyaan
+		}
+		offset := header.Size; header.Size += int64(len(line))
+		wholeline := bytes.TrimRight(line, "\r\n")
+		lineflags := uint8(0)
+
+		if len(wholeline) == 0 { break } // The empty line: the end of the header section
+		if len(wholeline) > 998 { lineflags |= HeaderLineTooLong }
+		if bytes.HasSuffix(line, []byte("\n")) && bytes.HasSuffix(line, []byte("\r\n")) == false { lineflags |= HeaderBareLF }
+		for _, e := range wholeline { if e > 127 { lineflags |= HeaderEightBit; break } }
+
+		if wholeline[0] == ' ' || wholeline[0] == '\t' {
+			// A folded line: a continuation of the previous field
+			if len(bytes.TrimLeft(wholeline, " \t")) == 0 { lineflags |= HeaderObsoleteWSP }
+			if field == nil {
+				// There is no field before the folded line
+				field = &Field{Offset: offset, Raw: []byte{}}
+				header.Fields = append(header.Fields, field)
+				lineflags |= HeaderBrokenLine
+			}
+			field.Raw    = append(field.Raw, line...)
+			field.Flags |= lineflags
+			header.Flags |= lineflags
+			if nyaan != nil { break }
+			continue
+		}
+
+		field = &Field{Offset: offset, Raw: append([]byte{}, line...)}
+		if p := bytes.IndexByte(wholeline, ':'); p > 0 {
+			// "Name: Value" or "Name : Value"(obs-optional)
+			field.Name = string(wholeline[:p])
+			if cv := strings.TrimRight(field.Name, " \t"); cv != field.Name { field.Name = cv; lineflags |= HeaderObsoleteWSP }
+			if field.Name == "" || strings.ContainsAny(field.Name, " \t") { field.Name = ""; lineflags |= HeaderBrokenLine }
+
+		} else {
+			// A line without ":" such as "From neko@example.jp Thu Apr 29 23:34:45 2010" of mbox
+			lineflags |= HeaderBrokenLine
+		}
+		field.Flags   = lineflags
+		header.Flags |= lineflags
+		header.Fields = append(header.Fields, field)
+		if nyaan != nil { break }
+	}
+
+	for _, e := range header.Fields {
+		// Unfold each field and set the field body to Value
+		if e.Name == "" { e.Value = strings.TrimSpace(Unfold(string(e.Raw))); continue }
+		cv := string(e.Raw); cv = cv[strings.IndexByte(cv, ':') + 1:]
+		e.Value = strings.Trim(Unfold(cv), " \t")
+	}
+	return header, nil
+}
+
+// Unfold removes CRLF or LF immediately followed by whitespace and the line terminator at the end.
+//   Arguments:
+//     - text (string): Folded field body such as "neko\r\n nyaan\r\n".
+//   Returns:
+//     - (string): Unfolded string such as "neko nyaan".
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-2.2.3
+func Unfold(text string) string {
+	if strings.IndexByte(text, '\n') < 0 { return text }
+
+	text = strings.TrimRight(text, "\r\n")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\n", "")
+}
@@ -1,4 +1,4 @@
-// Copyright (C) 2020,2024-2025 azumakuniyuki and sisimai development team, All rights reserved.
+// Copyright (C) 2020,2024-2026 azumakuniyuki and sisimai development team, All rights reserved.
 // This software is distributed under The BSD 2-Clause License.
 //  ____  _____ ____ ____ _________  ____     ______               _               _   
 // |  _ \|  ___/ ___| ___|___ /___ \|___ \   / /  _ \ ___  ___ ___(_)_   _____  __| |_ 
@@ -7,6 +7,8 @@
 // |_| \_\_|   \____|____/____/_____|_____/_/  |_| \_\___|\___\___|_| \_/ \___|\__,_(_)
 
 package rfc5322
+import "net"
+import "time"
 import "slices"
 import "strings"
 import "libsisimai.org/mailer-goemon/moji"
@@ -140,3 +142,258 @@ func Received(rhead string) [6]string {
 	return [6]string{token["from"], token["by"], token["via"], token["with"], token["id"], token["for"]}
 }
 
+
+// ReceivedHeader is a structured data of a Received header returned by ParseReceived().
+type ReceivedHeader struct {
+	From         string    // Hostname or IP address literal just after "from"
+	Helo         string    // Hostname claimed in HELO/EHLO command
+	RDNS         string    // Hostname resolved from the IP address by the receiving MTA
+	Addr         string    // IPv4 or IPv6 address of the sending host
+	By           string    // Hostname just after "by"
+	Via          string    // Link type such as "tcp", "frontend transport"
+	With         string    // Protocol such as "esmtps", "microsoft smtp server"
+	ID           string    // Queue ID such as "oBB3JxRJ022484"
+	For          string    // Envelope recipient address
+	EnvelopeFrom string    // Envelope sender address in "(envelope-from <...>)"
+	TLSVersion   string    // TLS version such as "TLSv1.3", "TLS1_2"
+	TLSCipher    string    // TLS cipher suite such as "TLS_AES_256_GCM_SHA384"
+	AuthSender   string    // Authenticated sender in "(Authenticated sender: ...)"
+	UID          string    // User ID in "(qmail 2220 invoked by uid 2)", "(Postfix, from userid 1000)"
+	MTA          string    // "postfix", "exim", "sendmail", "exchange", "gmail", "qmail", or ""
+	Date         time.Time // Date-time after ";"
+}
+
+// ParseReceived converts a Received header to the ReceivedHeader struct.
+//   Arguments:
+//     - rhead (string): Received header without "Received:".
+//   Returns:
+//     - (*ReceivedHeader): Parsed Received header, or nil when the argument is empty.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5321#section-4.4
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-3.6.7
+func ParseReceived(rhead string) *ReceivedHeader {
+	rhead = strings.TrimSpace(Unfold(rhead)); if rhead == "" { return nil }
+
+	thing := new(ReceivedHeader)
+	tdate := "" // Date-time after the last ";" out of comments
+	depth := 0
+	for j := len(rhead) - 1; j > -1; j-- {
+		// Find the last ";" which is not in comments such as "(using TLSv1.3; ...)"
+		if rhead[j] == ')' { depth++; continue }
+		if rhead[j] == '(' { depth--; continue }
+		if rhead[j] == ';' && depth == 0 { tdate = rhead[j + 1:]; rhead = rhead[:j]; break }
+	}
+	if cv, _ := ParseDate(tdate); cv.IsZero() == false { thing.Date = cv }
+
+	label := []string{"from", "by", "via", "with", "id", "for"}
+	recvd := receivedTokens(rhead)
+	clause := ""    // Current clause: "from", "by", "via", "with", "id", or "for"
+	valued := false // The current clause has a value
+	eximtls := false
+	wordfrom := ""  // The word just after "from"
+	fromhelo := ""  // "(HELO ...)" of qmail or "helo=" of Exim in the comment after "from"
+	fromaddr := false
+
+	for _, e := range recvd {
+		// Read each token: a word or a comment
+		if e[0] == '(' {
+			// Comments: "(envelope-from <...>)", "(using TLSv1.3 with cipher ...)", "(Postfix)", ...
+			inner := strings.TrimSpace(strings.TrimSuffix(e[1:], ")"))
+			lower := strings.ToLower(inner)
+			piece := strings.Fields(inner)
+
+			switch {
+				case strings.HasPrefix(lower, "envelope-from") || strings.HasPrefix(lower, "envelope-sender"):
+					// Exim, qmail-ldap: "(envelope-from <neko@example.jp>)"
+					if len(piece) > 1 { thing.EnvelopeFrom = strings.Trim(piece[1], "<>") }
+
+				case moji.HasPrefixAny(lower, []string{"authenticated sender:", "authenticated user:", "authenticated as "}):
+					// Postfix: "(Authenticated sender: neko@example.jp)"
+					thing.AuthSender = strings.Trim(piece[len(piece) - 1], "<>")
+
+				case strings.HasPrefix(lower, "using ") && len(piece) > 1:
+					// Postfix: "(using TLSv1.3 with cipher TLS_AES_256_GCM_SHA384 (256/256 bits) ...)"
+					thing.TLSVersion = piece[1]
+					for k := range piece { if k > 1 && strings.ToLower(piece[k - 1]) == "cipher" { thing.TLSCipher = piece[k]; break } }
+
+				case strings.Contains(lower, "version=") || strings.Contains(lower, "cipher="):
+					// Sendmail: "(version=TLSv1.3 cipher=TLS_AES_256_GCM_SHA384 bits=256 verify=NOT)"
+					// Exchange: "(version=TLS1_2, cipher=TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384)"
+					for _, f := range piece {
+						// key=value pairs separated by " " or ", "
+						f = strings.TrimRight(f, ",;")
+						if strings.HasPrefix(strings.ToLower(f), "version=") { thing.TLSVersion = f[8:] }
+						if strings.HasPrefix(strings.ToLower(f), "cipher=")  { thing.TLSCipher  = f[7:] }
+					}
+
+				case clause == "with" && strings.HasPrefix(lower, "tls") && len(piece) == 1:
+					// Exim: "with esmtps (TLS1.3) tls TLS_AES_256_GCM_SHA384", "(TLS1.2:ECDHE-RSA-AES256-GCM-SHA384:256)"
+					cv := strings.Split(inner, ":"); thing.TLSVersion = cv[0]
+					if len(cv) > 1 { thing.TLSCipher = cv[1] }
+
+				case strings.HasPrefix(lower, "qmail "):
+					// qmail: "(qmail 2220 invoked by uid 2)", "(qmail 2202 invoked from network)"
+					thing.MTA = "qmail"
+					if p := strings.Index(lower, " invoked by uid "); p > 0 { thing.UID = strings.TrimSpace(inner[p + 16:]) }
+
+				case strings.HasPrefix(lower, "postfix"):
+					// Postfix: "(Postfix)", "(Postfix, from userid 1000)"
+					thing.MTA = "postfix"
+					if p := strings.Index(lower, "from userid "); p > 0 { thing.UID = strings.TrimSpace(inner[p + 12:]) }
+
+				case strings.HasPrefix(lower, "exim "):
+					// Exim: "(Exim 4.96)"
+					thing.MTA = "exim"
+
+				case strings.HasPrefix(lower, "google transport security"):
+					// Gmail: "(Google Transport Security)"
+					thing.MTA = "gmail"
+
+				case clause == "by" && isSendmailVersion(lower):
+					// Sendmail: "(8.14.4/8.14.4)", "(8.15.2/8.15.2/Submit)"
+					thing.MTA = "sendmail"
+
+				case clause == "from" && fromaddr == false:
+					// The comment just after "from":
+					// - Postfix, Sendmail, Gmail: "(rdns [192.0.2.1])", "(unknown [IPv6:2001:db8::1])", "(user@rdns [ip])"
+					// - Exim:  "([192.0.2.1] helo=mail.example.org)", "([192.0.2.1]:52345 helo=...)", "(helo=...)"
+					// - qmail: "(HELO mail.example.org)", "(192.0.2.1)", "(user@192.0.2.1)"
+					// - Exchange: "(192.0.2.1)", "(2001:db8::1)"
+					if strings.HasPrefix(lower, "helo ") { fromhelo = strings.TrimSpace(inner[5:]); thing.MTA = "qmail"; continue }
+					fromaddr = true
+
+					for k, f := range piece {
+						// Look for the IP address and the hostname resolved
+						if strings.IndexByte(f, '(') > -1 { break } // "(may be forged)"
+						if strings.HasPrefix(strings.ToLower(f), "helo=") { fromhelo = f[5:]; thing.MTA = "exim"; continue }
+						if cv := receivedIPAddr(f); cv != "" { thing.Addr = cv; continue }
+						if k == 0 && strings.IndexByte(f, '[') < 0 {
+							// "rdns", "user@rdns", "rdns."
+							if p := strings.LastIndexByte(f, '@'); p > -1 { f = f[p + 1:] }
+							thing.RDNS = strings.TrimRight(strings.ToLower(f), ".")
+						}
+					}
+			}
+			continue
+		}
+
+		lower := strings.ToLower(e)
+		if slices.Contains(label, lower) && (clause == "" || valued) { clause = lower; valued = false; continue }
+
+		switch clause {
+			case "from":
+				if valued == false { wordfrom = strings.TrimRight(lower, ".") }
+			case "by":
+				if valued == false { thing.By = strings.Trim(strings.TrimRight(lower, "."), "[]") }
+			case "via":
+				thing.Via = strings.TrimSpace(thing.Via + " " + lower)
+			case "with":
+				if valued == false {
+					// "with ESMTPS", "with Microsoft SMTP Server", "with mapi"
+					thing.With = lower
+
+				} else if strings.HasPrefix(thing.With, "microsoft") && thing.TLSVersion == "" {
+					// "with Microsoft SMTP Server"
+					thing.With += " " + lower
+
+				} else if lower == "tls" {
+					// Exim: "with esmtps (TLS1.3) tls TLS_AES_256_GCM_SHA384"
+					eximtls = true
+
+				} else if eximtls {
+					thing.TLSCipher = e; eximtls = false
+				}
+			case "id":
+				if valued == false { thing.ID = e }
+			case "for":
+				if valued == false { thing.For = strings.Trim(e, "<>") }
+		}
+		valued = true
+	}
+	if thing.By == "" && wordfrom == "" && thing.MTA == "" { return nil }
+
+	thing.From = wordfrom
+	switch {
+		case thing.MTA == "qmail" || (thing.MTA == "exim" && fromhelo != ""):
+			// qmail:  "from rdns (HELO helo) (ip)", "from rdns (ip)" when rdns == helo
+			// Exim:   "from rdns ([ip] helo=helo)", "from [ip] (helo=helo)"
+			if cv := receivedIPAddr(wordfrom); cv != "" {
+				// "from [192.0.2.1] (helo=mail.example.org)"
+				if thing.Addr == "" { thing.Addr = cv }
+
+			} else if wordfrom != "unknown" {
+				thing.RDNS = wordfrom
+			}
+			thing.Helo = fromhelo; if thing.Helo == "" { thing.Helo = thing.RDNS }
+
+		case thing.MTA == "exim" && thing.RDNS == "" && receivedIPAddr(wordfrom) == "":
+			// Exim: "from mail.example.org ([192.0.2.1])" when the HELO name is the same as rDNS
+			thing.Helo, thing.RDNS = wordfrom, wordfrom
+
+		default:
+			// Postfix, Sendmail, Exchange, Gmail: "from helo (rdns [ip])"
+			thing.Helo = wordfrom
+			if thing.Addr == "" { thing.Addr = receivedIPAddr(wordfrom) }
+	}
+	thing.Helo = strings.ToLower(thing.Helo)
+	if thing.RDNS == "unknown" { thing.RDNS = "" }
+
+	if thing.MTA == "" {
+		// Detect the MTA from the protocol or the hostname
+		switch {
+			case strings.HasPrefix(thing.With, "microsoft smtp server") || thing.With == "mapi":
+				thing.MTA = "exchange"
+			case strings.HasSuffix(thing.By, ".google.com") || thing.By == "smtp.gmail.com":
+				thing.MTA = "gmail"
+		}
+	}
+	return thing
+}
+
+// receivedTokens splits the Received header into words and comments including nested comments.
+func receivedTokens(text string) []string {
+	tokens := []string{}
+	depth  := 0
+	begin  := -1
+
+	for j := 0; j < len(text); j++ {
+		// Words are separated by white spaces, a comment begins with "(" and ends with ")"
+		switch c := text[j]; {
+			case c == '(':
+				if depth == 0 {
+					if begin > -1 { tokens = append(tokens, text[begin:j]) }
+					begin = j
+				}
+				depth++
+			case c == ')' && depth > 0:
+				depth--; if depth == 0 { tokens = append(tokens, text[begin:j + 1]); begin = -1 }
+			case depth > 0:
+				continue
+			case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ';':
+				if begin > -1 { tokens = append(tokens, text[begin:j]); begin = -1 }
+			default:
+				if begin < 0 { begin = j }
+		}
+	}
+	if begin > -1 { tokens = append(tokens, text[begin:]) }
+	return tokens
+}
+
+// receivedIPAddr returns the IP address in the string such as "[192.0.2.1]", "[IPv6:2001:db8::1]",
+// "[192.0.2.1]:52345", "192.0.2.1", and "user@192.0.2.1".
+func receivedIPAddr(text string) string {
+	if p := strings.LastIndexByte(text, '@'); p > -1 { text = text[p + 1:] }
+	if strings.HasPrefix(text, "[") {
+		// "[192.0.2.1]", "[192.0.2.1]:52345", "[IPv6:2001:db8::1]"
+		if p := strings.IndexByte(text, ']'); p > 0 { text = text[1:p] } else { return "" }
+		if len(text) > 5 && strings.EqualFold(text[:5], "ipv6:") { text = text[5:] }
+	}
+	if cv := net.ParseIP(strings.TrimRight(text, ".,;")); cv != nil { return cv.String() }
+	return ""
+}
+
+// isSendmailVersion returns true if the string is the version of sendmail such as "8.14.4/8.14.4".
+func isSendmailVersion(text string) bool {
+	cv := strings.Split(text, "/"); if len(cv) < 2 || strings.Count(cv[0], ".") != 2 { return false }
+	return moji.ContainsOnlyNumbers(strings.ReplaceAll(cv[0], ".", ""))
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//  ____  _____ ____ ____ _________  ____     _______     _                
+// |  _ \|  ___/ ___| ___|___ /___ \|___ \   / /_   _|__ | | _____ _ __  _ 
+// | |_) | |_ | |   |___ \ |_ \ __) | __) | / /  | |/ _ \| |/ / _ \ '_ \(_)
+// |  _ <|  _|| |___ ___) |__) / __/ / __/ / /   | | (_) |   <  __/ | | |_ 
+// |_| \_\_|   \____|____/____/_____|_____/_/    |_|\___/|_|\_\___|_| |_(_)
+
+package rfc5322
+import "strings"
+
+const (
+	TokenAtom    = iota // A sequence of characters except specials and white spaces
+	TokenQuoted         // quoted-string, Value is unquoted
+	TokenComment        // comment, Value is the text inside the outermost parentheses
+	TokenLiteral        // domain-literal, Value is the text inside "[" and "]"
+	TokenSpecial        // A special character such as ";"
+)
+
+// Specials are special characters of RFC5322 3.2.3 and TSpecials are tspecials of RFC2045 5.1
+const Specials  = `()<>[]:;@\,."`
+const TSpecials = `()<>@,;:\"/[]?=`
+
+// Token is a lexical token of a structured field body.
+type Token struct {
+	Kind   uint8  // TokenAtom, TokenQuoted, TokenComment, TokenLiteral, or TokenSpecial
+	Value  string // The token, quoted-pairs in quoted strings and comments are unescaped
+	Offset int    // Byte offset of the first byte of the token in the field body
+	End    int    // Byte offset of the next byte of the token, the token is text[Offset:End]
+}
+
+// Tokenize splits the structured field body into tokens. Unterminated quoted strings, comments, and
+// domain literals end at the end of the text.
+//   Arguments:
+//     - text (string):     Field body such as `text/plain; charset="utf-8" (comment)`.
+//     - specials (string): Special characters: Specials or TSpecials.
+//   Returns:
+//     - ([]Token): Tokens except white spaces.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-3.2
+//     - https://datatracker.ietf.org/doc/html/rfc2045#section-5.1
+func Tokenize(text string, specials string) []Token {
+	tokens := []Token{}
+	for j := 0; j < len(text); {
+		// Read each token from the current position
+		c := text[j]
+		if c == ' ' || c == '\t' || c == '\r' || c == '\n' { j++; continue }
+
+		switch c {
+			case '"', '[':
+				// quoted-string or domain-literal
+				closing := byte('"'); kind := uint8(TokenQuoted)
+				if c == '[' { closing = ']'; kind = TokenLiteral }
+				value, end := readUntil(text, j + 1, closing)
+				tokens = append(tokens, Token{Kind: kind, Value: value, Offset: j, End: end}); j = end
+
+			case '(':
+				// Comment, may be nested
+				depth, cv, k := 1, strings.Builder{}, j + 1
+				for ; k < len(text) && depth > 0; k++ {
+					if text[k] == '\\' && k + 1 < len(text) { k++; cv.WriteByte(text[k]); continue }
+					if text[k] == '(' { depth++ } else if text[k] == ')' { depth--; if depth == 0 { break } }
+					cv.WriteByte(text[k])
+				}
+				if k < len(text) { k++ }
+				tokens = append(tokens, Token{Kind: TokenComment, Value: cv.String(), Offset: j, End: k}); j = k
+
+			default:
+				if strings.IndexByte(specials, c) > -1 {
+					// A special character
+					tokens = append(tokens, Token{Kind: TokenSpecial, Value: text[j:j + 1], Offset: j, End: j + 1}); j++
+					continue
+				}
+				k := j + 1
+				for ; k < len(text); k++ {
+					// Atom until a white space, a special character, or the beginning of the other token
+					if strings.IndexByte(" \t\r\n\"([", text[k]) > -1 || strings.IndexByte(specials, text[k]) > -1 { break }
+				}
+				tokens = append(tokens, Token{Kind: TokenAtom, Value: text[j:k], Offset: j, End: k}); j = k
+		}
+	}
+	return tokens
+}
+
+// readUntil returns the unescaped text until the closing character and the offset after it.
+func readUntil(text string, begin int, closing byte) (string, int) {
+	cv := strings.Builder{}
+	for j := begin; j < len(text); j++ {
+		if text[j] == '\\' && j + 1 < len(text) { j++; cv.WriteByte(text[j]); continue }
+		if text[j] == closing { return cv.String(), j + 1 }
+		cv.WriteByte(text[j])
+	}
+	return cv.String(), len(text)
+}
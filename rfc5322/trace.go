@@ -0,0 +1,115 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//  ____  _____ ____ ____ _________  ____     _______                     
+// |  _ \|  ___/ ___| ___|___ /___ \|___ \   / /_   _| __ __ _  ___ ___ _ 
+// | |_) | |_ | |   |___ \ |_ \ __) | __) | / /  | || '__/ _` |/ __/ _ (_)
+// |  _ <|  _|| |___ ___) |__) / __/ / __/ / /   | || | | (_| | (_|  __/_ 
+// |_| \_\_|   \____|____/____/_____|_____/_/    |_||_|  \__,_|\___\___(_)
+
+package rfc5322
+import "time"
+import "strings"
+import "net/netip"
+
+// Hop is a Received header in the order of the message transfer.
+type Hop struct {
+	*ReceivedHeader
+	Index   int           // 0 is the oldest hop, the last Received header in the header section
+	Delay   time.Duration // Date of this hop minus the date of the previous hop, 0 when unknown
+	Trusted bool          // The sending host (Addr, RDNS) is in TraceOption.TrustedHosts or TrustedNetworks
+}
+
+// Trace is the result of analyzing all the Received headers of a message.
+type Trace struct {
+	Hops     []*Hop        // Hops from the oldest to the newest
+	Transit  time.Duration // Date of the newest hop minus the date of the oldest hop, 0 when unknown
+	Origin   *Hop          // The hop received from the first host outside the trusted hosts and networks
+	OriginIP string        // IP address of the first host outside the trusted hosts and networks
+	Loop     bool          // The same pair of the sending host and the receiving host appears twice or more
+	TooMany  bool          // The number of hops is greater than TraceOption.MaxHops
+}
+
+// TraceOption is the configuration for ParseTrace().
+type TraceOption struct {
+	TrustedHosts    []string       // Hostnames or domain names such as "example.jp" of trusted hosts
+	TrustedNetworks []netip.Prefix // Networks of trusted hosts such as netip.MustParsePrefix("192.0.2.0/24")
+	MaxHops         int            // The number of hops considered suspicious, DefaultMaxHops when it is 0
+}
+
+// DefaultMaxHops is the number of hops which is too many for an ordinary message
+const DefaultMaxHops = 25
+
+// ParseTrace orders Received headers as hops, computes the delay of each hop and the total transit
+// time, and finds the first hop outside the trusted hosts and networks.
+//   Arguments:
+//     - rheads ([]string):       Received headers in order of appearance in the header section.
+//     - option (*TraceOption):   Trusted hosts and networks, nil means no trusted host.
+//   Returns:
+//     - (*Trace): Analyzed result, Hops is empty when no Received header is parsed.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5321#section-4.4
+//     - https://datatracker.ietf.org/doc/html/rfc5321#section-6.3
+func ParseTrace(rheads []string, option *TraceOption) *Trace {
+	if option == nil { option = &TraceOption{} }
+	maxhops := option.MaxHops; if maxhops < 1 { maxhops = DefaultMaxHops }
+	thetrace := &Trace{Hops: []*Hop{}}
+
+	for j := len(rheads) - 1; j > -1; j-- {
+		// The newest Received header is at the top of the header section
+		cv := ParseReceived(rheads[j]); if cv == nil { continue }
+		thetrace.Hops = append(thetrace.Hops, &Hop{ReceivedHeader: cv, Index: len(thetrace.Hops)})
+	}
+	if len(thetrace.Hops) == 0 { return thetrace }
+
+	previous := time.Time{} // The date of the previous hop having the date
+	theoldest := time.Time{}
+	sentfrom := map[string]bool{}
+
+	for _, e := range thetrace.Hops {
+		// Compute the delay and look for the same pair of the sending host and the receiving host
+		e.Trusted = isTrustedHop(e.ReceivedHeader, option)
+		if e.Date.IsZero() == false {
+			if previous.IsZero() == false { e.Delay = e.Date.Sub(previous) }
+			if theoldest.IsZero() == true { theoldest = e.Date }
+			previous = e.Date
+		}
+
+		cv := e.Addr; if cv == "" { cv = e.Helo }
+		if cv == "" || e.By == "" { continue }
+		if sentfrom[cv + " " + e.By] { thetrace.Loop = true }
+		sentfrom[cv + " " + e.By] = true
+	}
+	if theoldest.IsZero() == false { thetrace.Transit = previous.Sub(theoldest) }
+	thetrace.TooMany = len(thetrace.Hops) > maxhops
+
+	for j := len(thetrace.Hops) - 1; j > -1; j-- {
+		// Walk from the newest hop received by our own host to the oldest hop, the first sending host
+		// which is not trusted is the true origin of the message
+		e := thetrace.Hops[j]
+		if e.Addr == "" || e.Trusted { continue }
+		thetrace.Origin, thetrace.OriginIP = e, e.Addr
+		break
+	}
+	return thetrace
+}
+
+// isTrustedHop returns true if the sending host of the hop is a loopback address or is included in
+// the trusted hosts or networks. The HELO name is not used because the sending host can claim any name.
+func isTrustedHop(hop *ReceivedHeader, option *TraceOption) bool {
+	if hop.Addr != "" {
+		// Check the IP address of the sending host
+		ipaddr, nyaan := netip.ParseAddr(hop.Addr)
+		if nyaan == nil {
+			ipaddr = ipaddr.Unmap(); if ipaddr.IsLoopback() { return true }
+			for _, e := range option.TrustedNetworks { if e.Contains(ipaddr) { return true } }
+		}
+	}
+	if hop.RDNS == "" { return false }
+
+	for _, e := range option.TrustedHosts {
+		// "mx.example.jp" matches "example.jp" and "mx.example.jp"
+		e = strings.ToLower(strings.Trim(e, ".")); if e == "" { continue }
+		if hop.RDNS == e || strings.HasSuffix(hop.RDNS, "." + e) { return true }
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//  ____  _____ ____ ____ _________  ____       ____        __       _  _                  
+// |  _ \|  ___/ ___| ___|___ /___ \|___ \     / /\ \      / / _ __ (_)| |_   ___  _ __  _ 
+// | |_) | |_ | |   |___ \ |_ \ __) | __) |   / /  \ \ /\ / / | '__|| || __| / _ \| '__|(_)
+// |  _ <|  _|| |___ ___) |__) / __/ / __/   / /    \ V  V /  | |   | || |_ |  __/| |    _ 
+// |_| \_\_|   \____|____/____/_____|_____| /_/      \_/\_/   |_|   |_| \__| \___||_|   (_)
+
+package rfc5322
+import "io"
+import "mime"
+import "sort"
+import "time"
+import "errors"
+import "strings"
+
+var ErrInvalidFieldName = errors.New("rfc5322: field name is empty or includes an invalid character")
+var ErrHeaderInjection  = errors.New("rfc5322: field body includes CR or LF")
+var ErrWordTooLong      = errors.New("rfc5322: a word is too long to fold within 998 octets")
+
+// fieldOrder is the order of fields written by HeaderWriter, other fields follow them in order of
+// the addition. Fields of the same name keep the order of the addition.
+var fieldOrder = []string{
+	"return-path", "received", "date", "from", "sender", "reply-to", "to", "cc", "bcc", "message-id",
+	"in-reply-to", "references", "subject", "mime-version", "content-type", "content-transfer-encoding",
+	"content-disposition",
+}
+
+// HeaderWriter composes a header section. Each field is validated and folded when it is added.
+type HeaderWriter struct {
+	Fields []*Field // Fields in order of the addition, Raw is the folded field including CRLF
+}
+
+// NewHeaderWriter returns an empty HeaderWriter.
+func NewHeaderWriter() *HeaderWriter {
+	return &HeaderWriter{Fields: []*Field{}}
+}
+
+// Add adds an unstructured field such as "Subject". Words including non-ASCII characters are encoded
+// as RFC2047 encoded-words in UTF-8.
+//   Arguments:
+//     - name (string):  Field name such as "Subject".
+//     - value (string): Field body such as "Returned mail: see transcript for details".
+//   Returns:
+//     - (error): ErrInvalidFieldName, ErrHeaderInjection, or ErrWordTooLong.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc2047
+func (this *HeaderWriter) Add(name, value string) error {
+	if strings.ContainsAny(value, "\r\n") { return ErrHeaderInjection }
+	return this.add(name, encodeWords(strings.TrimSpace(value)))
+}
+
+// AddAddress adds an address field such as "To" with the addresses separated by ",".
+//   Arguments:
+//     - name (string):      Field name such as "To".
+//     - addrs (...string):  Addresses formatted by FormatAddress() or EmailAddress.String().
+//   Returns:
+//     - (error): ErrInvalidFieldName, ErrHeaderInjection, or ErrWordTooLong.
+func (this *HeaderWriter) AddAddress(name string, addrs ...string) error {
+	list := []string{}
+	for _, e := range addrs {
+		if strings.ContainsAny(e, "\r\n") { return ErrHeaderInjection }
+		if e = strings.TrimSpace(e); e != "" { list = append(list, e) }
+	}
+	return this.add(name, strings.Join(list, ", "))
+}
+
+// AddDate adds a date field such as "Date" formatted by FormatDate().
+//   Arguments:
+//     - name (string):      Field name such as "Date".
+//     - date1 (time.Time):  Time of the field.
+//   Returns:
+//     - (error): ErrInvalidFieldName.
+func (this *HeaderWriter) AddDate(name string, date1 time.Time) error {
+	return this.add(name, FormatDate(date1))
+}
+
+// WriteTo writes the fields in the stable order: fields listed in fieldOrder first, then the others.
+//   Arguments:
+//     - w (io.Writer): Destination.
+//   Returns:
+//     - (int64): The number of bytes written.
+//     - (error): An error returned from the writer.
+func (this *HeaderWriter) WriteTo(w io.Writer) (int64, error) {
+	fields := append([]*Field{}, this.Fields...)
+	sort.SliceStable(fields, func(a, b int) bool { return fieldRank(fields[a].Name) < fieldRank(fields[b].Name) })
+
+	size := int64(0)
+	for _, e := range fields {
+		n, nyaan := w.Write(e.Raw); size += int64(n)
+		if nyaan != nil { return size, nyaan }
+	}
+	return size, nil
+}
+
+// Bytes returns the header section written by WriteTo() without the empty line after it.
+func (this *HeaderWriter) Bytes() []byte {
+	cv := strings.Builder{}
+	this.WriteTo(&cv)
+	return []byte(cv.String())
+}
+
+// add folds the field body and appends the field.
+func (this *HeaderWriter) add(name, value string) error {
+	if isFieldName(name) == false { return ErrInvalidFieldName }
+	if strings.ContainsAny(value, "\r\n") { return ErrHeaderInjection }
+
+	folded, nyaan := foldField(name, value); if nyaan != nil { return nyaan }
+	this.Fields = append(this.Fields, &Field{Name: name, Value: value, Raw: []byte(folded)})
+	return nil
+}
+
+// FormatAddress returns the address with the display name such as `"Neko, Nyaan" <neko@example.jp>`.
+// The display name is quoted when it includes special characters, and is encoded as RFC2047
+// encoded-words when it includes non-ASCII characters.
+//   Arguments:
+//     - email (string): Email address such as "neko@example.jp".
+//     - name (string):  Display name such as "Neko", may be empty.
+//   Returns:
+//     - (string): Formatted address such as "Neko <neko@example.jp>".
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5322#section-3.4
+func FormatAddress(email, name string) string {
+	email = strings.Trim(strings.TrimSpace(email), "<>")
+	name  = strings.Join(strings.Fields(name), " ")
+	if name == "" { return email }
+
+	phrase := true
+	for j := 0; j < len(name); j++ {
+		// A phrase consisting of atoms does not need quoting
+		if name[j] > 127 { return encodeWord(name) + " <" + email + ">" }
+		if name[j] < 32 || name[j] == 127 || strings.IndexByte(Specials, name[j]) > -1 { phrase = false }
+	}
+	if phrase { return name + " <" + email + ">" }
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name) + `" <` + email + ">"
+}
+
+// fieldRank returns the index of the field name in fieldOrder.
+func fieldRank(name string) int {
+	name = strings.ToLower(name)
+	for j, e := range fieldOrder { if e == name { return j } }
+	return len(fieldOrder)
+}
+
+// isFieldName returns true if the string is field-name of RFC5322 3.6.8
+func isFieldName(name string) bool {
+	if name == "" { return false }
+	for j := 0; j < len(name); j++ { if name[j] < 33 || name[j] > 126 || name[j] == ':' { return false } }
+	return true
+}
+
+// encodeWord returns RFC2047 encoded-words of the text in "Q" or "B" encoding whichever is shorter.
+func encodeWord(text string) string {
+	qp, b64 := mime.QEncoding.Encode("utf-8", text), mime.BEncoding.Encode("utf-8", text)
+	if len(b64) < len(qp) { return b64 }
+	return qp
+}
+
+// encodeWords encodes each run of the words including non-ASCII characters. White spaces between
+// the encoded words are encoded in them because they are ignored when decoding.
+func encodeWords(text string) string {
+	segments := splitSegments(text)
+	cv := strings.Builder{}
+	for j := 0; j < len(segments); j++ {
+		// Each segment is white spaces followed by a word
+		e := segments[j]
+		if isASCII(e[1]) { cv.WriteString(e[0] + e[1]); continue }
+
+		run := e[1]
+		for ; j + 1 < len(segments) && isASCII(segments[j + 1][1]) == false; j++ { run += segments[j + 1][0] + segments[j + 1][1] }
+		cv.WriteString(e[0] + encodeWord(run))
+	}
+	return cv.String()
+}
+
+// foldField returns "Name: body\r\n" folded at white spaces to fit in 78 octets when possible.
+func foldField(name, value string) (string, error) {
+	const width = 78
+	lines := []string{}
+	line  := name + ":"
+	for j, e := range splitSegments(value) {
+		// Fold before the white spaces of the segment
+		if j == 0 { e[0] = " " }
+		if len(line) + len(e[0]) + len(e[1]) > width && line != name + ":" {
+			lines = append(lines, line); line = ""
+		}
+		line += e[0] + e[1]
+		if len(line) > 998 { return "", ErrWordTooLong }
+	}
+	if line == name + ":" { line += " " }
+	return strings.Join(append(lines, line), "\r\n") + "\r\n", nil
+}
+
+// splitSegments splits the text into pairs of the leading white spaces and the following word.
+func splitSegments(text string) [][2]string {
+	segments := [][2]string{}
+	for j := 0; j < len(text); {
+		k := j; for k < len(text) && (text[k] == ' ' || text[k] == '\t') { k++ }
+		l := k; for l < len(text) && text[l] != ' ' && text[l] != '\t' { l++ }
+		segments = append(segments, [2]string{text[j:k], text[k:l]}); j = l
+	}
+	return segments
+}
+
+// isASCII returns true if the text consists of octets less than 128
+func isASCII(text string) bool {
+	for j := 0; j < len(text); j++ { if text[j] > 127 { return false } }
+	return true
+}
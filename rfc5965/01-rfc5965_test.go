@@ -0,0 +1,198 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc5965
+
+//  _____         _      __     __      ____  ___   __  ____  
+// |_   _|__  ___| |_   / / __ / _| ___| ___|/ _ \ / /_| ___| 
+//   | |/ _ \/ __| __| / / '__| |_ / __|___ \ (_) | '_ \___ \ 
+//   | |  __/\__ \ |_ / /| |  |  _| (__ ___) \__, | (_) |__) |
+//   |_|\___||___/\__/_/ |_|  |_|  \___|____/  /_/ \___/____/ 
+import "strings"
+import "testing"
+import "libsisimai.org/mailer-goemon/rfc3464"
+
+var arfMessage = "From: <abusedesk@example.com>\r\n" +
+	"Date: Thu, 8 Mar 2005 17:40:36 EDT\r\n" +
+	"Subject: FW: Earn money\r\n" +
+	"To: <abuse@example.net>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/report; report-type=feedback-report;\r\n" +
+	"     boundary=\"part1_13d.2e68ed54_boundary\"\r\n" +
+	"\r\n" +
+	"--part1_13d.2e68ed54_boundary\r\n" +
+	"Content-Type: text/plain; charset=\"US-ASCII\"\r\n" +
+	"Content-Transfer-Encoding: 7bit\r\n" +
+	"\r\n" +
+	"This is an email abuse report for an email message received from IP\r\n" +
+	"192.0.2.1 on Thu, 8 Mar 2005 14:00:00 EDT.\r\n" +
+	"\r\n" +
+	"--part1_13d.2e68ed54_boundary\r\n" +
+	"Content-Type: message/feedback-report\r\n" +
+	"\r\n" +
+	"Feedback-Type: abuse\r\n" +
+	"User-Agent: SomeGenerator/1.0\r\n" +
+	"Version: 1\r\n" +
+	"Original-Mail-From: <somespammer@example.net>\r\n" +
+	"Original-Rcpt-To: <user@example.com>\r\n" +
+	"Arrival-Date: Thu, 8 Mar 2005 14:00:00 EDT\r\n" +
+	"Reporting-MTA: dns; mail.example.com\r\n" +
+	"Source-IP: 192.0.2.1\r\n" +
+	"Authentication-Results: mail.example.com;\r\n" +
+	"               spf=fail smtp.mailfrom=somespammer@example.com\r\n" +
+	"Reported-Domain: example.net\r\n" +
+	"Reported-Uri: http://example.net/earn_money.html\r\n" +
+	"Reported-Uri: mailto:user@example.com\r\n" +
+	"Removal-Recipient: user@example.com\r\n" +
+	"\r\n" +
+	"--part1_13d.2e68ed54_boundary\r\n" +
+	"Content-Type: message/rfc822\r\n" +
+	"Content-Disposition: inline\r\n" +
+	"\r\n" +
+	"From: <somespammer@example.net>\r\n" +
+	"Received: from mailserver.example.net (mailserver.example.net\r\n" +
+	"        [192.0.2.1]) by example.com with ESMTP id M63d4137594e46;\r\n" +
+	"        Thu, 08 Mar 2005 14:00:00 -0400\r\n" +
+	"To: <Undisclosed Recipients>\r\n" +
+	"Subject: Earn money\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-type: text/plain\r\n" +
+	"Message-ID: 8787KJKJ3K4J3K4J3K4J3.mail@example.net\r\n" +
+	"Date: Thu, 02 Sep 2004 12:31:03 -0500\r\n" +
+	"\r\n" +
+	"Spam Spam Spam\r\n" +
+	"Spam Spam Spam\r\n" +
+	"\r\n" +
+	"--part1_13d.2e68ed54_boundary--\r\n"
+
+func TestParseMessage(t *testing.T) {
+	fn := "rfc5965.ParseMessage"
+	cx := 0
+
+	cv, nyaan := ParseMessage(strings.NewReader(arfMessage))
+	cx++; if nyaan != nil { t.Fatalf("%s() returns an error: %s", fn, nyaan) }
+	cx++; if cv.FeedbackType != FeedbackAbuse || cv.IsComplaint() == false { t.Errorf("%s().FeedbackType is %s", fn, cv.FeedbackType) }
+	cx++; if cv.UserAgent != "SomeGenerator/1.0" { t.Errorf("%s().UserAgent is %s", fn, cv.UserAgent) }
+	cx++; if cv.Version != "1" { t.Errorf("%s().Version is %s", fn, cv.Version) }
+	cx++; if cv.OriginalMailFrom == nil || cv.OriginalMailFrom.Address != "somespammer@example.net" { t.Errorf("%s().OriginalMailFrom is %v", fn, cv.OriginalMailFrom) }
+	cx++; if len(cv.OriginalRcptTo) != 1 || cv.OriginalRcptTo[0].Host != "example.com" { t.Errorf("%s().OriginalRcptTo is %v", fn, cv.OriginalRcptTo) }
+	cx++; if cv.ArrivalDate.IsZero() { t.Errorf("%s().ArrivalDate is zero", fn) }
+	cx++; if cv.ReportingMTA.Type != "dns" || cv.ReportingMTA.Value != "mail.example.com" { t.Errorf("%s().ReportingMTA is %v", fn, cv.ReportingMTA) }
+	cx++; if cv.SourceIP != "192.0.2.1" { t.Errorf("%s().SourceIP is %s", fn, cv.SourceIP) }
+	cx++; if cv.Incidents != 1 { t.Errorf("%s().Incidents is %d", fn, cv.Incidents) }
+	cx++; if len(cv.AuthenticationResults) != 1 { t.Fatalf("%s().AuthenticationResults is %v", fn, cv.AuthenticationResults) }
+	cx++; if len(cv.AuthenticationResults[0].Failed()) != 1 { t.Errorf("%s().AuthenticationResults[0] does not fail", fn) }
+	cx++; if len(cv.ReportedDomain) != 1 || cv.ReportedDomain[0] != "example.net" { t.Errorf("%s().ReportedDomain is %v", fn, cv.ReportedDomain) }
+	cx++; if len(cv.ReportedURI) != 2 || cv.ReportedURI[1] != "mailto:user@example.com" { t.Errorf("%s().ReportedURI is %v", fn, cv.ReportedURI) }
+	cx++; if cv.Fields.Get("Removal-Recipient") != "user@example.com" { t.Errorf("%s().Fields does not have Removal-Recipient", fn) }
+	cx++; if cv.Header == nil { t.Fatalf("%s().Header is nil", fn) }
+	cx++; if cv.Header.Get("Subject") != "Earn money" { t.Errorf("%s().Header Subject is %s", fn, cv.Header.Get("Subject")) }
+	cx++; if len(cv.Header.Values("Received")) != 1 { t.Errorf("%s().Header does not have Received", fn) }
+	cx++; if ce := cv.Complained(); len(ce) != 1 || ce[0].Address != "user@example.com" { t.Errorf("%s().Complained() is %v", fn, ce) }
+
+	// text/rfc822-headers, without Original-Rcpt-To
+	ce := strings.Replace(arfMessage, "Original-Rcpt-To: <user@example.com>\r\n", "", 1)
+	ce  = strings.Replace(ce, "Content-Type: message/rfc822\r\n", "Content-Type: text/rfc822-headers\r\n", 1)
+	ce  = strings.Replace(ce, "To: <Undisclosed Recipients>\r\n", "To: \"Neko, Nyaan\" <neko@example.com>, kijitora@example.com\r\n", 1)
+	cv, nyaan = ParseMessage(strings.NewReader(ce))
+	cx++; if nyaan != nil { t.Fatalf("%s() returns an error: %s", fn, nyaan) }
+	cx++; if cv.Header == nil || cv.Header.Get("Message-ID") == "" { t.Fatalf("%s().Header is %v", fn, cv.Header) }
+	cx++; if cf := cv.Complained(); len(cf) != 2 || cf[0].Address != "neko@example.com" || cf[1].Address != "kijitora@example.com" { t.Errorf("%s().Complained() is %v", fn, cf) }
+
+	// Not a feedback report
+	cv, nyaan = ParseMessage(strings.NewReader("From: neko@example.jp\r\nSubject: Nyaan\r\n\r\nHello\r\n"))
+	cx++; if nyaan != ErrNotFeedbackReport || cv != nil { t.Errorf("%s() returns %v", fn, nyaan) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParse(t *testing.T) {
+	fn := "rfc5965.Parse"
+	cx := 0
+
+	// RFC6591 auth-failure report
+	cv, nyaan := Parse(strings.NewReader("Feedback-Type: auth-failure\r\n" +
+		"User-Agent: Someisp!Mail-Feedback/1.0\r\n" +
+		"Version: 1\r\n" +
+		"Original-Mail-From: <>\r\n" +
+		"Received-Date: Thu, 29 Apr 2010 23:34:45 +0900\r\n" +
+		"Source-IP: [IPv6:2001:DB8::25]\r\n" +
+		"Incidents: 12\r\n" +
+		"Auth-Failure: DMARC\r\n" +
+		"Delivery-Result: reject\r\n" +
+		"Reported-Domain: Example.JP.\r\n"))
+	cx++; if nyaan != nil { t.Fatalf("%s() returns an error: %s", fn, nyaan) }
+	cx++; if cv.FeedbackType != FeedbackAuthFailure || cv.IsComplaint() { t.Errorf("%s().FeedbackType is %s", fn, cv.FeedbackType) }
+	cx++; if cv.OriginalMailFrom != nil { t.Errorf("%s().OriginalMailFrom is %v", fn, cv.OriginalMailFrom) }
+	cx++; if cv.ArrivalDate.Unix() != 1272551685 { t.Errorf("%s().ArrivalDate is %v", fn, cv.ArrivalDate) }
+	cx++; if cv.SourceIP != "2001:db8::25" { t.Errorf("%s().SourceIP is %s", fn, cv.SourceIP) }
+	cx++; if cv.Incidents != 12 { t.Errorf("%s().Incidents is %d", fn, cv.Incidents) }
+	cx++; if cv.AuthFailure != "dmarc" || cv.DeliveryResult != "reject" { t.Errorf("%s() returns %s %s", fn, cv.AuthFailure, cv.DeliveryResult) }
+	cx++; if cv.ReportedDomain[0] != "example.jp" { t.Errorf("%s().ReportedDomain is %v", fn, cv.ReportedDomain) }
+	cx++; if cv.Header != nil || len(cv.Complained()) != 0 { t.Errorf("%s().Header is not nil", fn) }
+
+	// Missing fields
+	_, nyaan = Parse(strings.NewReader("Feedback-Type: abuse\r\nVersion: 1\r\n"))
+	cx++; if nyaan != ErrMissingField { t.Errorf("%s() returns %v", fn, nyaan) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestDeliveryStatus(t *testing.T) {
+	fn := "rfc5965.Report.DeliveryStatus"
+	cx := 0
+	abuse := "Feedback-Type: abuse\r\nUser-Agent: SomeGenerator/1.0\r\nVersion: 1\r\n" +
+		"Original-Mail-From: <kijitora@example.jp>\r\nOriginal-Rcpt-To: <neko@example.com>\r\nOriginal-Rcpt-To: <nyaan@example.com>\r\n" +
+		"Arrival-Date: Thu, 29 Apr 2010 23:34:45 +0900\r\nReporting-MTA: dns; mail.example.com\r\nSource-IP: 192.0.2.1\r\n"
+
+	cf, nyaan := Parse(strings.NewReader(abuse))
+	cx++; if nyaan != nil { t.Fatalf("Parse() returns an error: %s", nyaan) }
+	cv := cf.DeliveryStatus()
+	cx++; if len(cv.Recipients) != 2 { t.Fatalf("%s() returns %d recipients", fn, len(cv.Recipients)) }
+	cx++; if cv.ReportingMTA == cf.ReportingMTA { t.Errorf("%s().ReportingMTA is shared", fn) }
+	for j, e := range []string{"neko@example.com", "nyaan@example.com"} {
+		cx++; if cv.Recipients[j].Address() != e || cv.Recipients[j].Failed() == false { t.Errorf("%s().Recipients[%d] is %v", fn, j, cv.Recipients[j]) }
+		cx++; if cv.Recipients[j].Status != ComplaintStatus { t.Errorf("%s().Recipients[%d].Status is %s", fn, j, cv.Recipients[j].Status) }
+		cx++; if cv.Recipients[j].Fields != nil { t.Errorf("%s().Recipients[%d].Fields is %v", fn, j, cv.Recipients[j].Fields) }
+	}
+
+	// Formatted as message/delivery-status and parsed again
+	ce, nyaan := cv.Format(false)
+	cx++; if nyaan != nil { t.Fatalf("rfc3464.Report.Format() returns an error: %s", nyaan) }
+	cx++; if string(ce) != "Reporting-MTA: dns; mail.example.com\r\nArrival-Date: Thu, 29 Apr 2010 23:34:45 +0900\r\n" +
+		"\r\nFinal-Recipient: rfc822; neko@example.com\r\nAction: failed\r\nStatus: 5.7.1\r\nLast-Attempt-Date: Thu, 29 Apr 2010 23:34:45 +0900\r\n" +
+		"\r\nFinal-Recipient: rfc822; nyaan@example.com\r\nAction: failed\r\nStatus: 5.7.1\r\nLast-Attempt-Date: Thu, 29 Apr 2010 23:34:45 +0900\r\n" {
+		t.Errorf("rfc3464.Report.Format() returns %q", ce)
+	}
+	for _, e := range []string{"Feedback-Type", "User-Agent", "Source-IP", "Original-Rcpt-To"} {
+		cx++; if strings.Contains(string(ce), e) { t.Errorf("rfc3464.Report.Format() has %s", e) }
+	}
+	cr, nyaan := rfc3464.Parse(strings.NewReader(string(ce)))
+	cx++; if nyaan != nil || len(cr.Recipients) != 2 || cr.Recipients[1].Address() != "nyaan@example.com" { t.Errorf("rfc3464.Parse() returns %v", nyaan) }
+
+	// Not a complaint
+	cf, _ = Parse(strings.NewReader(strings.Replace(abuse, "Feedback-Type: abuse", "Feedback-Type: not-spam", 1)))
+	cx++; if cv := cf.DeliveryStatus(); len(cv.Recipients) != 0 || cv.ReportingMTA == nil { t.Errorf("%s() returns %v", fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParseIP(t *testing.T) {
+	fn := "rfc5965.parseIP"
+	cx := 0
+	ae := []struct {testname string; argument string; expected string}{
+		{"IPv4",         "192.0.2.1",                  "192.0.2.1"},
+		{"IPv4 comment", "192.0.2.1 (mx.example.jp)",  "192.0.2.1"},
+		{"IPv4 bracket", "[192.0.2.1]",                "192.0.2.1"},
+		{"IPv6",         "2001:db8::1",                "2001:db8::1"},
+		{"IPv6 tag",     "[IPv6:2001:DB8:0:0::1]",     "2001:db8::1"},
+		{"Broken",       "192.0.2.256",                ""},
+		{"Hostname",     "mx.example.jp",              ""},
+	}
+	for _, e := range ae {
+		t.Run(e.testname, func(t *testing.T) {
+			cv := parseIP(e.argument)
+			cx++; if cv != e.expected { t.Errorf("%s(%q) returns %q", fn, e.argument, cv) }
+		})
+	}
+	t.Logf("The number of tests = %d", cx)
+}
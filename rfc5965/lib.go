@@ -0,0 +1,102 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ____  ___   __  ____  
+//  _ __ / _| ___| ___|/ _ \ / /_| ___| 
+// | '__| |_ / __|___ \ (_) | '_ \___ \ 
+// | |  |  _| (__ ___) \__, | (_) |__) |
+// |_|  |_|  \___|____/  /_/ \___/____/ 
+
+// Package "rfc5965" provides a parser of Abuse Reporting Format (ARF) messages sent from feedback
+// loops of mailbox providers. https://datatracker.ietf.org/doc/html/rfc5965
+package rfc5965
+import "time"
+import "errors"
+import "libsisimai.org/mailer-goemon/address"
+import "libsisimai.org/mailer-goemon/authres"
+import "libsisimai.org/mailer-goemon/rfc3464"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+var ErrNotFeedbackReport = errors.New("rfc5965: the message does not have a message/feedback-report part")
+var ErrMissingField      = errors.New("rfc5965: Feedback-Type, User-Agent, or Version is missing")
+
+// Feedback types registered in the IANA "Feedback Report Type Values" registry
+const (
+	FeedbackAbuse       = "abuse"        // Unsolicited email or some other kind of email abuse
+	FeedbackAuthFailure = "auth-failure" // Email authentication failure report (RFC6591)
+	FeedbackFraud       = "fraud"        // Indicates some kind of fraud or phishing activity
+	FeedbackNotSpam     = "not-spam"     // Indicates that the entity providing the report does not consider the message to be spam
+	FeedbackOther       = "other"        // Any other feedback that does not fit into other registered types
+	FeedbackVirus       = "virus"        // Report of a virus found in the originating message
+)
+
+// ComplaintStatus is the Status of the per-recipient fields converted from a complaint by
+// DeliveryStatus(): "5.7.1" Delivery not authorized, message refused.
+const ComplaintStatus = "5.7.1"
+
+// Report is a parsed message/feedback-report part and the header of the original message.
+type Report struct {
+	FeedbackType          string                   // Feedback-Type in lower case such as "abuse"
+	UserAgent             string                   // User-Agent such as "Yahoo!-Mail-Feedback/2.0"
+	Version               string                   // Version such as "1"
+	OriginalEnvelopeID    string                   // Original-Envelope-Id
+	OriginalMailFrom      *address.EmailAddress    // Original-Mail-From, nil if it does not exist or is "<>"
+	OriginalRcptTo        []*address.EmailAddress  // Original-Rcpt-To fields
+	ArrivalDate           time.Time                // Arrival-Date or Received-Date, zero time if it does not exist
+	ReportingMTA          *rfc3464.TypedValue      // Reporting-MTA such as {"dns", "mx.example.jp"}
+	SourceIP              string                   // Source-IP, IPv4 address checked by rfc791.IsIPv4Address() or IPv6 address
+	Incidents             int                      // Incidents, 1 if the field does not exist
+	AuthenticationResults []*authres.Result        // Authentication-Results fields
+	ReportedDomain        []string                 // Reported-Domain fields in lower case
+	ReportedURI           []string                 // Reported-URI fields
+	AuthFailure           string                   // Auth-Failure of the auth-failure report in lower case such as "dmarc"
+	DeliveryResult        string                   // Delivery-Result of the auth-failure report in lower case such as "reject"
+	Fields                *rfc5322.Header          // All the fields of the message/feedback-report part
+	Header                *rfc5322.Header          // Header of the original message, nil if the message does not include it
+}
+
+// Complained returns the recipient addresses complained about the message: Original-Rcpt-To, or
+// the "To" addresses of the original message when Original-Rcpt-To does not exist. Mailbox
+// providers often redact the addresses, callers should check them as well as the bounced addresses
+// returned from rfc3464.Recipient.Address().
+//   Returns:
+//     - ([]*address.EmailAddress): Recipient addresses.
+func (this *Report) Complained() []*address.EmailAddress {
+	if len(this.OriginalRcptTo) > 0 { return this.OriginalRcptTo }
+
+	addrs := []*address.EmailAddress{}
+	if this.Header == nil { return addrs }
+	for _, e := range this.Header.Values("To") {
+		for _, f := range splitAddresses(e) {
+			if cv := address.Rise(address.Find(f)); cv != nil { addrs = append(addrs, cv) }
+		}
+	}
+	return addrs
+}
+
+// DeliveryStatus returns the complaint as a delivery status to suppress the complained recipients in
+// the same way as the bounced recipients: each address of Complained() becomes the per-recipient
+// fields with "Action: failed", ComplaintStatus, and Arrival-Date as Last-Attempt-Date. The fields of
+// the feedback report are not copied as extension fields.
+//   Returns:
+//     - (*rfc3464.Report): Reporting-MTA, Original-Envelope-Id, Arrival-Date, and the per-recipient
+//                          fields, no recipient when the report is not a complaint such as "not-spam".
+func (this *Report) DeliveryStatus() *rfc3464.Report {
+	report := &rfc3464.Report{OriginalEnvelopeID: this.OriginalEnvelopeID, ArrivalDate: this.ArrivalDate, Recipients: []*rfc3464.Recipient{}}
+	if this.ReportingMTA != nil { cv := *this.ReportingMTA; report.ReportingMTA = &cv }
+	if this.IsComplaint() == false { return report }
+
+	for _, e := range this.Complained() {
+		report.Recipients = append(report.Recipients, &rfc3464.Recipient{
+			FinalRecipient:  &rfc3464.TypedValue{Type: "rfc822", Value: e.Address},
+			Action:          rfc3464.ActionFailed,
+			Status:          ComplaintStatus,
+			LastAttemptDate: this.ArrivalDate,
+		})
+	}
+	return report
+}
+
+// IsComplaint returns true if the report is a complaint: "abuse", "fraud", or "virus".
+func (this *Report) IsComplaint() bool {
+	return this.FeedbackType == FeedbackAbuse || this.FeedbackType == FeedbackFraud || this.FeedbackType == FeedbackVirus
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ____  ___   __  ____    ______                       
+//  _ __ / _| ___| ___|/ _ \ / /_| ___|  / /  _ \ __ _ _ __ ___  ___ _ 
+// | '__| |_ / __|___ \ (_) | '_ \___ \ / /| |_) / _` | '__/ __|/ _ (_)
+// | |  |  _| (__ ___) \__, | (_) |__) / / |  __/ (_| | |  \__ \  __/_ 
+// |_|  |_|  \___|____/  /_/ \___/____/_/  |_|   \__,_|_|  |___/\___(_)
+
+package rfc5965
+import "io"
+import "bufio"
+import "strconv"
+import "strings"
+import "net/netip"
+import "libsisimai.org/mailer-goemon/address"
+import "libsisimai.org/mailer-goemon/authres"
+import "libsisimai.org/mailer-goemon/rfc791"
+import "libsisimai.org/mailer-goemon/rfc2045"
+import "libsisimai.org/mailer-goemon/rfc3464"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// ParseMessage parses the multipart/report message of the feedback report and reads the header of
+// the original message in the message/rfc822 part or the text/rfc822-headers part.
+//   Arguments:
+//     - r (io.Reader): The whole message of the feedback report.
+//   Returns:
+//     - (*Report): Parsed report, nil when the error is ErrNotFeedbackReport.
+//     - (error):   ErrNotFeedbackReport, an error returned from Parse(), or an error of the reader.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5965#section-2
+func ParseMessage(r io.Reader) (*Report, error) {
+	var report *Report
+	var header *rfc5322.Header
+	var failed error
+
+	nyaan := rfc2045.NewReader(r).Walk(func(p *rfc2045.Part) error {
+		switch {
+			case p.MediaType == "message/feedback-report":
+				if report != nil { break }
+				report, failed = Parse(p.Body())
+
+			case p.MediaType == "text/rfc822-headers" || p.MediaType == "message/global-headers":
+				if header != nil { break }
+				cv, nyaan := rfc5322.ReadHeader(p.Body()); if nyaan != nil { return nyaan }
+				header = cv
+
+			case p.Parent != nil && p.Parent.IsMessage() && p.Parent.Depth == 1:
+				// The header of the encapsulated message in the message/rfc822 part of the report
+				if header == nil { header = p.Header }
+		}
+		return nil
+	})
+	if nyaan != nil { return report, nyaan }
+	if report == nil { return nil, ErrNotFeedbackReport }
+
+	report.Header = header
+	return report, failed
+}
+
+// Parse parses the body of the message/feedback-report part.
+//   Arguments:
+//     - r (io.Reader): The body of the message/feedback-report part.
+//   Returns:
+//     - (*Report): Parsed report, valid even when the error is ErrMissingField.
+//     - (error):   ErrMissingField or an error returned from the reader.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc5965#section-3.1
+//     - https://datatracker.ietf.org/doc/html/rfc6591#section-3
+func Parse(r io.Reader) (*Report, error) {
+	reader, ok := r.(*bufio.Reader); if ok == false { reader = bufio.NewReader(r) }
+	report := &Report{
+		Fields:                &rfc5322.Header{Fields: []*rfc5322.Field{}},
+		OriginalRcptTo:        []*address.EmailAddress{},
+		Incidents:             1,
+		AuthenticationResults: []*authres.Result{},
+		ReportedDomain:        []string{},
+		ReportedURI:           []string{},
+	}
+
+	for {
+		// Some reporters insert empty lines between fields
+		header, nyaan := rfc5322.ReadHeader(reader)
+		if nyaan != nil { return report, nyaan }
+		if header.Size == 0 { break }
+		report.Fields.Fields = append(report.Fields.Fields, header.Fields...)
+		report.Fields.Flags |= header.Flags
+	}
+
+	for _, e := range report.Fields.Fields {
+		// Set each field to the member of Report
+		switch strings.ToLower(e.Name) {
+			case "feedback-type":        report.FeedbackType = strings.ToLower(e.Value)
+			case "user-agent":           report.UserAgent = e.Value
+			case "version":              report.Version = e.Value
+			case "original-envelope-id": report.OriginalEnvelopeID = e.Value
+			case "original-mail-from":   report.OriginalMailFrom = address.Rise(address.Find(e.Value))
+			case "reported-uri":         report.ReportedURI = append(report.ReportedURI, strings.Trim(e.Value, "<>"))
+			case "auth-failure":         report.AuthFailure = strings.ToLower(e.Value)
+			case "delivery-result":      report.DeliveryResult = strings.ToLower(e.Value)
+			case "source-ip":            report.SourceIP = parseIP(e.Value)
+			case "reporting-mta":        report.ReportingMTA = rfc3464.ParseTypedValue(e.Value)
+
+			case "original-rcpt-to":
+				if cv := address.Rise(address.Find(e.Value)); cv != nil { report.OriginalRcptTo = append(report.OriginalRcptTo, cv) }
+
+			case "arrival-date", "received-date":
+				// Received-Date is an alias of Arrival-Date in the earlier version of ARF
+				if report.ArrivalDate.IsZero() == false { continue }
+				if cv, nyaan := rfc5322.ParseDate(e.Value); nyaan == nil || nyaan == rfc5322.ErrWeekdayMismatch || nyaan == rfc5322.ErrMissingTimeZone { report.ArrivalDate = cv }
+
+			case "incidents":
+				if cv, nyaan := strconv.Atoi(e.Value); nyaan == nil && cv > 0 { report.Incidents = cv }
+
+			case "authentication-results":
+				if cv := authres.Parse(e.Value); cv != nil { report.AuthenticationResults = append(report.AuthenticationResults, cv) }
+
+			case "reported-domain":
+				if cv := strings.ToLower(strings.Trim(e.Value, ". ")); cv != "" { report.ReportedDomain = append(report.ReportedDomain, cv) }
+		}
+	}
+	if report.FeedbackType == "" || report.UserAgent == "" || report.Version == "" { return report, ErrMissingField }
+	return report, nil
+}
+
+// parseIP returns the IP address in Source-IP field such as "192.0.2.1" or "[IPv6:2001:db8::1]".
+//   Arguments:
+//     - value (string): The field body of Source-IP.
+//   Returns:
+//     - (string): IPv4 address or IPv6 address, empty if the value is not an IP address.
+func parseIP(value string) string {
+	if cv := strings.Fields(value); len(cv) > 0 { value = cv[0] } // "192.0.2.1 (mx.example.jp)"
+	value = strings.Trim(value, "[]")
+	if len(value) > 5 && strings.EqualFold(value[:5], "IPv6:") { value = value[5:] }
+
+	if rfc791.IsIPv4Address(value) { return value }
+	if cv, nyaan := netip.ParseAddr(value); nyaan == nil && cv.Is6() { return cv.String() }
+	return ""
+}
+
+// splitAddresses splits the address list such as `"Neko, Nyaan" <neko@example.jp>, kijitora@example.jp`.
+func splitAddresses(value string) []string {
+	addrs := []string{}; p := 0
+	for _, e := range rfc5322.Tokenize(value, ",") {
+		if e.Kind != rfc5322.TokenSpecial || e.Value != "," { continue }
+		addrs = append(addrs, value[p:e.Offset]); p = e.End
+	}
+	return append(addrs, value[p:])
+}
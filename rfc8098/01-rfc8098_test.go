@@ -0,0 +1,144 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc8098
+
+//  _____         _      __     __      ___   ___   ___   ___  
+// |_   _|__  ___| |_   / / __ / _| ___( _ ) / _ \ / _ \ ( _ ) 
+//   | |/ _ \/ __| __| / / '__| |_ / __/ _ \| | | | (_) |/ _ \ 
+//   | |  __/\__ \ |_ / /| |  |  _| (_| (_) | |_| |\__, | (_) |
+//   |_|\___||___/\__/_/ |_|  |_|  \___\___/ \___/   /_/ \___/ 
+import "strings"
+import "testing"
+
+var mdnMessage = "Date: Wed, 20 Sep 1995 00:19:00 (EDT) -0400\r\n" +
+	"From: Joe Recipient <Joe_Recipient@mega.edu>\r\n" +
+	"Message-Id: <199509200019.12345@mega.edu>\r\n" +
+	"Subject: Disposition notification\r\n" +
+	"To: Jane Sender <Jane_Sender@huge.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/report; report-type=disposition-notification;\r\n" +
+	"    boundary=\"RAA14128.773615765/mega.edu\"\r\n" +
+	"\r\n" +
+	"--RAA14128.773615765/mega.edu\r\n" +
+	"\r\n" +
+	"The message sent on 1995 Sep 19 at 13:30:00 (EDT) -0400 to Joe\r\n" +
+	"Recipient <Joe_Recipient@mega.edu> with subject \"First draft of\r\n" +
+	"report\" has been displayed.\r\n" +
+	"\r\n" +
+	"--RAA14128.773615765/mega.edu\r\n" +
+	"Content-Type: message/disposition-notification\r\n" +
+	"\r\n" +
+	"Reporting-UA: joes-pc.cs.mega.edu; Foomail 97.1\r\n" +
+	"Original-Recipient: rfc822;Joe_Recipient@mega.edu\r\n" +
+	"Final-Recipient: rfc822;Joe_Recipient@mega.edu\r\n" +
+	"Original-Message-ID: <199509192301.23456@huge.com>\r\n" +
+	"Disposition: manual-action/MDN-sent-manually; displayed\r\n" +
+	"\r\n" +
+	"--RAA14128.773615765/mega.edu\r\n" +
+	"Content-Type: text/rfc822-headers\r\n" +
+	"\r\n" +
+	"Date: Tue, 19 Sep 1995 13:30:00 -0400\r\n" +
+	"From: Jane Sender <Jane_Sender@huge.com>\r\n" +
+	"Message-Id: <199509192301.23456@huge.com>\r\n" +
+	"Subject: First draft of report\r\n" +
+	"To: Joe Recipient <Joe_Recipient@mega.edu>\r\n" +
+	"Disposition-Notification-To: Jane Sender <Jane_Sender@huge.com>\r\n" +
+	"\r\n" +
+	"--RAA14128.773615765/mega.edu--\r\n"
+
+func TestParseMessage(t *testing.T) {
+	fn := "rfc8098.ParseMessage"
+	cx := 0
+
+	cv, nyaan := ParseMessage(strings.NewReader(mdnMessage))
+	cx++; if nyaan != nil { t.Fatalf("%s() returns an error: %s", fn, nyaan) }
+	cx++; if cv.ReportingUA != "joes-pc.cs.mega.edu; Foomail 97.1" { t.Errorf("%s().ReportingUA is %s", fn, cv.ReportingUA) }
+	cx++; if cv.OriginalRecipient.Value != "Joe_Recipient@mega.edu" { t.Errorf("%s().OriginalRecipient is %v", fn, cv.OriginalRecipient) }
+	cx++; if cv.FinalRecipient.Type != "rfc822" || cv.FinalRecipient.Value != "Joe_Recipient@mega.edu" { t.Errorf("%s().FinalRecipient is %v", fn, cv.FinalRecipient) }
+	cx++; if cv.OriginalMessageID != "<199509192301.23456@huge.com>" { t.Errorf("%s().OriginalMessageID is %s", fn, cv.OriginalMessageID) }
+	cx++; if cv.MDNGateway != nil { t.Errorf("%s().MDNGateway is %v", fn, cv.MDNGateway) }
+	cx++; if cv.Disposition == nil { t.Fatalf("%s().Disposition is nil", fn) }
+	cx++; if cv.Disposition.ActionMode != ActionManual { t.Errorf("%s().Disposition.ActionMode is %s", fn, cv.Disposition.ActionMode) }
+	cx++; if cv.Disposition.SendingMode != SendingManual { t.Errorf("%s().Disposition.SendingMode is %s", fn, cv.Disposition.SendingMode) }
+	cx++; if cv.Disposition.Type != TypeDisplayed || cv.Disposition.Failed() { t.Errorf("%s().Disposition.Type is %s", fn, cv.Disposition.Type) }
+	cx++; if cv.Header == nil || cv.Header.Get("Subject") != "First draft of report" { t.Errorf("%s().Header is %v", fn, cv.Header) }
+
+	cv, nyaan = ParseMessage(strings.NewReader("From: neko@example.jp\r\nSubject: Nyaan\r\n\r\nHello\r\n"))
+	cx++; if nyaan != ErrNotDispositionNotification || cv != nil { t.Errorf("%s() returns %v", fn, nyaan) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParse(t *testing.T) {
+	fn := "rfc8098.Parse"
+	cx := 0
+
+	cv, nyaan := Parse(strings.NewReader("Reporting-UA: mua.example.jp; Nekomail 2.2\r\n" +
+		"MDN-Gateway: smtp; gw.example.jp\r\n" +
+		"Final-Recipient: rfc822; neko@example.jp\r\n" +
+		"Disposition: automatic-action/MDN-sent-automatically; deleted/error\r\n" +
+		"Error: The mailbox is locked\r\n" +
+		"X-Nyaan: 2\r\n"))
+	cx++; if nyaan != nil { t.Fatalf("%s() returns an error: %s", fn, nyaan) }
+	cx++; if cv.MDNGateway.Type != "smtp" || cv.MDNGateway.Value != "gw.example.jp" { t.Errorf("%s().MDNGateway is %v", fn, cv.MDNGateway) }
+	cx++; if cv.OriginalRecipient != nil { t.Errorf("%s().OriginalRecipient is %v", fn, cv.OriginalRecipient) }
+	cx++; if cv.Disposition.Type != TypeDeleted || cv.Disposition.Failed() == false { t.Errorf("%s().Disposition is %v", fn, cv.Disposition) }
+	cx++; if len(cv.Errors) != 1 || cv.Errors[0] != "The mailbox is locked" { t.Errorf("%s().Errors is %v", fn, cv.Errors) }
+	cx++; if cv.Fields.Get("X-Nyaan") != "2" { t.Errorf("%s().Fields does not have X-Nyaan", fn) }
+	cx++; if cv.Header != nil { t.Errorf("%s().Header is not nil", fn) }
+
+	_, nyaan = Parse(strings.NewReader("Disposition: manual-action/MDN-sent-manually; displayed\r\n"))
+	cx++; if nyaan != ErrNoFinalRecipient { t.Errorf("%s() returns %v", fn, nyaan) }
+	_, nyaan = Parse(strings.NewReader("Final-Recipient: rfc822; neko@example.jp\r\n"))
+	cx++; if nyaan != ErrNoDisposition { t.Errorf("%s() returns %v", fn, nyaan) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestParseDisposition(t *testing.T) {
+	fn := "rfc8098.ParseDisposition"
+	cx := 0
+	ae := []struct {testname string; argument string; expected string; modifiers int}{
+		{"Displayed",   "manual-action/MDN-sent-manually; displayed",                        "manual-action/MDN-sent-manually; displayed", 0},
+		{"Spaces",      "Automatic-Action / MDN-Sent-Automatically ; Processed",             "automatic-action/MDN-sent-automatically; processed", 0},
+		{"Modifiers",   "automatic-action/MDN-sent-automatically; deleted/error, expired",   "automatic-action/MDN-sent-automatically; deleted/error,expired", 2},
+		{"Comment",     "manual-action/MDN-sent-manually; dispatched (forwarded)",           "manual-action/MDN-sent-manually; dispatched", 0},
+		{"No mode",     "displayed",                                                          "displayed", 0},
+		{"No sending",  "manual-action; displayed",                                           "manual-action; displayed", 0},
+		{"No sending/", "automatic-action/; deleted/error",                                   "automatic-action; deleted/error", 1},
+		{"No type",     "manual-action/MDN-sent-manually;",                                   "", 0},
+	}
+	for _, e := range ae {
+		t.Run(e.testname, func(t *testing.T) {
+			cv := ParseDisposition(e.argument)
+			cx++; if cv.String() != e.expected { t.Errorf("%s(%q) returns %q", fn, e.argument, cv.String()) }
+			if cv == nil { return }
+			cx++; if len(cv.Modifiers) != e.modifiers { t.Errorf("%s(%q) returns %d modifiers", fn, e.argument, len(cv.Modifiers)) }
+		})
+	}
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestClassify(t *testing.T) {
+	fn := "rfc8098.Classify"
+	cx := 0
+	ae := []struct {testname string; argument string; expected string}{
+		{"MDN", mdnMessage, ReportMDN},
+		{"DSN", strings.Replace(mdnMessage, "report-type=disposition-notification", "report-type=delivery-status", 1), ReportDSN},
+		{"No report-type", strings.Replace(mdnMessage, " report-type=disposition-notification;", "", 1), ReportMDN},
+		{"ARF part", "Content-Type: multipart/mixed; boundary=\"b\"\r\n\r\n--b\r\nContent-Type: text/plain\r\n\r\nabuse\r\n" +
+			"--b\r\nContent-Type: message/feedback-report\r\n\r\nFeedback-Type: abuse\r\n\r\n--b--\r\n", ReportARF},
+		{"Forwarded DSN", "Content-Type: multipart/mixed; boundary=\"b\"\r\n\r\n--b\r\nContent-Type: message/rfc822\r\n\r\n" +
+			"Content-Type: multipart/report; boundary=\"c\"\r\n\r\n--c\r\nContent-Type: message/delivery-status\r\n\r\n" +
+			"Reporting-MTA: dns; mx.example.jp\r\n\r\n--c--\r\n\r\n--b--\r\n", ""},
+		{"Not a report", "From: neko@example.jp\r\nSubject: Nyaan\r\n\r\nHello\r\n", ""},
+	}
+	for _, e := range ae {
+		t.Run(e.testname, func(t *testing.T) {
+			cv, nyaan := Classify(strings.NewReader(e.argument))
+			cx++; if nyaan != nil { t.Errorf("%s() returns an error: %s", fn, nyaan) }
+			cx++; if cv != e.expected { t.Errorf("%s() returns %q", fn, cv) }
+		})
+	}
+	t.Logf("The number of tests = %d", cx)
+}
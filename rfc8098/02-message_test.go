@@ -0,0 +1,135 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package rfc8098
+
+//  _____         _      ____  __                                
+// |_   _|__  ___| |_   / /  \/  | ___  ___ ___  __ _  __ _  ___ 
+//   | |/ _ \/ __| __| / /| |\/| |/ _ \/ __/ __|/ _` |/ _` |/ _ \
+//   | |  __/\__ \ |_ / / | |  | |  __/\__ \__ \ (_| | (_| |  __/
+//   |_|\___||___/\__/_/  |_|  |_|\___||___/___/\__,_|\__, |\___|
+//                                                    |___/      
+import "io"
+import "bytes"
+import "errors"
+import "strings"
+import "testing"
+import "libsisimai.org/mailer-goemon/rfc2045"
+import "libsisimai.org/mailer-goemon/rfc3464"
+
+func sampleReport() *Report {
+	return &Report{
+		ReportingUA:       "mua.example.jp; Nekomail 2.2",
+		FinalRecipient:    &rfc3464.TypedValue{Type: "rfc822", Value: "neko@example.jp"},
+		OriginalMessageID: "<20100429233445.kijitora@example.org>",
+		Disposition:       &Disposition{ActionMode: ActionManual, SendingMode: SendingManual, Type: TypeDisplayed},
+	}
+}
+
+func TestFormat(t *testing.T) {
+	fn := "rfc8098.Format"
+	cx := 0
+
+	cv, nyaan := sampleReport().Format(false)
+	cx++; if nyaan != nil { t.Fatalf("%s() returns %s", fn, nyaan) }
+	cx++; if string(cv) != "Reporting-UA: mua.example.jp; Nekomail 2.2\r\nFinal-Recipient: rfc822; neko@example.jp\r\n" +
+		"Original-Message-ID: <20100429233445.kijitora@example.org>\r\n" +
+		"Disposition: manual-action/MDN-sent-manually; displayed\r\n" { t.Errorf("%s() returns %q", fn, cv) }
+
+	// Round trip
+	ce, nyaan := Parse(bytes.NewReader(cv))
+	cx++; if nyaan != nil { t.Fatalf("Parse() returns %s", nyaan) }
+	cx++; if ce.Disposition.String() != sampleReport().Disposition.String() { t.Errorf("Parse().Disposition is %s", ce.Disposition) }
+	cx++; if ce.OriginalMessageID != sampleReport().OriginalMessageID { t.Errorf("Parse().OriginalMessageID is %s", ce.OriginalMessageID) }
+
+	ae := []struct {testname string; modify func(*Report); expected error}{
+		{"No Final-Recipient", func(r *Report) { r.FinalRecipient = nil }, ErrNoFinalRecipient},
+		{"No Disposition",     func(r *Report) { r.Disposition = nil }, ErrNoDisposition},
+		{"No action mode",     func(r *Report) { r.Disposition.ActionMode = "" }, ErrNoDisposition},
+		{"Invalid sending",    func(r *Report) { r.Disposition.SendingMode = "mdn-sent" }, ErrNoDisposition},
+		{"No type",            func(r *Report) { r.Disposition.Type = "" }, ErrNoDisposition},
+		{"Non-ASCII",          func(r *Report) { r.Errors = []string{"メールボックスがロックされています"} }, ErrEightBit},
+		{"CRLF",               func(r *Report) { r.ReportingUA = "mua.example.jp\r\nBcc: neko@example.jp" }, nil},
+	}
+	for _, e := range ae {
+		t.Run(e.testname, func(t *testing.T) {
+			cv := sampleReport(); e.modify(cv)
+			_, nyaan := cv.Format(false)
+			if e.expected == nil { cx++; if nyaan == nil { t.Errorf("%s() does not return an error", fn) }; return }
+			cx++; if errors.Is(nyaan, e.expected) == false { t.Errorf("%s() returns %v", fn, nyaan) }
+		})
+	}
+
+	ce = sampleReport(); ce.Errors = []string{"メールボックスがロックされています"}
+	_, nyaan = ce.Format(true)
+	cx++; if nyaan != nil { t.Errorf("%s(true) returns %v", fn, nyaan) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestMessage(t *testing.T) {
+	fn := "rfc8098.Message.Bytes"
+	cx := 0
+	original := "From: kijitora@example.org\nTo: neko@example.jp\nSubject: Nyaan\nDisposition-Notification-To: kijitora@example.org\n\nHello\n"
+
+	cv := NewMessage(sampleReport(), []byte(original))
+	cv.Boundary = "NEKO/MDN"
+	cv.Header.AddAddress("From", "neko@example.jp")
+	cv.Header.AddAddress("To", "kijitora@example.org")
+	cv.Header.Add("Subject", "Read: Nyaan")
+
+	message, nyaan := cv.Bytes()
+	cx++; if nyaan != nil { t.Fatalf("%s() returns %s", fn, nyaan) }
+	cx++; if bytes.Contains(message, []byte("report-type=disposition-notification\r\n")) == false { t.Errorf("%s() returns %q", fn, message) }
+	cx++; if bytes.Contains(message, []byte("\r\nAuto-Submitted: auto-replied\r\n")) == false { t.Errorf("%s() does not have Auto-Submitted", fn) }
+	cx++; if bytes.HasSuffix(message, []byte("\r\n--NEKO/MDN--\r\n")) == false { t.Errorf("%s() does not end with the boundary", fn) }
+
+	cf, nyaan := Classify(bytes.NewReader(message))
+	cx++; if nyaan != nil || cf != ReportMDN { t.Errorf("Classify() returns %q", cf) }
+
+	ce, nyaan := ParseMessage(bytes.NewReader(message))
+	cx++; if nyaan != nil { t.Fatalf("ParseMessage() returns %s", nyaan) }
+	cx++; if ce.Disposition.Type != TypeDisplayed { t.Errorf("ParseMessage().Disposition is %s", ce.Disposition) }
+	cx++; if ce.Header == nil || ce.Header.Get("Disposition-Notification-To") != "kijitora@example.org" { t.Errorf("ParseMessage().Header is %v", ce.Header) }
+
+	parts := []string{}; bodies := []string{}
+	rfc2045.NewReader(bytes.NewReader(message)).Walk(func(p *rfc2045.Part) error {
+		data, _ := io.ReadAll(p.Body())
+		parts = append(parts, p.MediaType); bodies = append(bodies, string(data))
+		return nil
+	})
+	cx++; if strings.Join(parts, " ") != "multipart/report text/plain message/disposition-notification text/rfc822-headers" { t.Errorf("%s() has parts %v", fn, parts) }
+	cx++; if strings.Contains(bodies[1], "The message <20100429233445.kijitora@example.org> sent to <neko@example.jp> has been displayed.") == false { t.Errorf("%s() part 1 is %q", fn, bodies[1]) }
+
+	cv.Global = true; cv.Content = true
+	message, _ = cv.Bytes()
+	cx++; if bytes.Contains(message, []byte("Content-Type: message/global-disposition-notification\r\n")) == false { t.Errorf("%s() returns %q", fn, message) }
+	cx++; if bytes.Contains(message, []byte("Content-Type: message/global\r\n")) == false { t.Errorf("%s() returns %q", fn, message) }
+
+	cv.Report.Disposition = nil
+	_, nyaan = cv.Bytes()
+	cx++; if nyaan != ErrNoDisposition { t.Errorf("%s() returns %v", fn, nyaan) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestExplain(t *testing.T) {
+	fn := "rfc8098.Explain"
+	cx := 0
+
+	cx++; if cv := Explain(sampleReport()); cv != "The message <20100429233445.kijitora@example.org> sent to <neko@example.jp> has been " +
+		"displayed. This is no guarantee that the message has been read or understood.\r\n" { t.Errorf("%s() returns %q", fn, cv) }
+
+	// The report returned with ErrNoFinalRecipient or ErrNoDisposition
+	cr, nyaan := Parse(strings.NewReader("Reporting-UA: mua.example.jp; Nekomail 2.2\r\n"))
+	cx++; if nyaan != ErrNoFinalRecipient { t.Fatalf("Parse() returns %v", nyaan) }
+	cx++; if cv := Explain(cr); cv != "The message has been processed.\r\n" { t.Errorf("%s() returns %q", fn, cv) }
+
+	cr, nyaan = Parse(strings.NewReader("Final-Recipient: rfc822; neko@example.jp\r\nError: Nyaan\r\n"))
+	cx++; if nyaan != ErrNoDisposition { t.Fatalf("Parse() returns %v", nyaan) }
+	cx++; if cv := Explain(cr); cv != "The message sent to <neko@example.jp> has been processed.\r\n    Nyaan\r\n" { t.Errorf("%s() returns %q", fn, cv) }
+
+	cx++; if cv := Explain(&Report{}); cv != "The message has been processed.\r\n" { t.Errorf("%s() returns %q", fn, cv) }
+	cx++; if cv := Explain(nil); cv != "" { t.Errorf("%s(nil) returns %q", fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ___   ___   ___   ___  
+//  _ __ / _| ___( _ ) / _ \ / _ \ ( _ ) 
+// | '__| |_ / __/ _ \| | | | (_) |/ _ \ 
+// | |  |  _| (_| (_) | |_| |\__, | (_) |
+// |_|  |_|  \___\___/ \___/   /_/ \___/ 
+
+// Package "rfc8098" provides a parser and a generator of Message Disposition Notifications (MDN)
+// known as read receipts, and a classifier of multipart/report messages. https://datatracker.ietf.org/doc/html/rfc8098
+package rfc8098
+import "errors"
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc3464"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+var ErrNotDispositionNotification = errors.New("rfc8098: the message does not have a message/disposition-notification part")
+var ErrNoFinalRecipient           = errors.New("rfc8098: Final-Recipient is missing")
+var ErrNoDisposition              = errors.New("rfc8098: Disposition is missing or broken")
+var ErrEightBit                   = errors.New("rfc8098: non-ASCII characters are not allowed in message/disposition-notification")
+
+// Report types of multipart/report returned from Classify()
+const (
+	ReportDSN = "delivery-status"          // Delivery status notification (RFC3464)
+	ReportMDN = "disposition-notification" // Message disposition notification (RFC8098)
+	ReportARF = "feedback-report"          // Abuse reporting format (RFC5965)
+)
+
+const (
+	ActionManual     = "manual-action"          // The disposition was taken by the user
+	ActionAutomatic  = "automatic-action"       // The disposition was taken automatically
+	SendingManual    = "mdn-sent-manually"      // The user explicitly gave permission to send the MDN
+	SendingAutomatic = "mdn-sent-automatically" // The MDN was sent automatically
+)
+
+const (
+	TypeDisplayed  = "displayed"  // The message has been displayed to the user
+	TypeDeleted    = "deleted"    // The message has been deleted without being displayed
+	TypeDispatched = "dispatched" // The message has been sent somewhere without being displayed
+	TypeProcessed  = "processed"  // The message has been processed without being displayed
+)
+
+// Report is a parsed message/disposition-notification part and the header of the original message.
+type Report struct {
+	ReportingUA       string              // Reporting-UA such as "mua.example.jp; Nekomail 2.2"
+	MDNGateway        *rfc3464.TypedValue // MDN-Gateway, nil if the field does not exist
+	OriginalRecipient *rfc3464.TypedValue // Original-Recipient, nil if the field does not exist
+	FinalRecipient    *rfc3464.TypedValue // Final-Recipient such as {"rfc822", "neko@example.jp"}
+	OriginalMessageID string              // Original-Message-ID such as "<20100429233445.neko@example.jp>"
+	Disposition       *Disposition        // Disposition, nil if the field does not exist or is broken
+	Errors            []string            // Error fields
+	Fields            *rfc5322.Header     // All the fields of the message/disposition-notification part
+	Header            *rfc5322.Header     // Header of the original message, nil if the message does not include it
+}
+
+// Disposition is the value of Disposition field such as "manual-action/MDN-sent-manually; displayed".
+type Disposition struct {
+	ActionMode  string   // "manual-action" or "automatic-action" in lower case
+	SendingMode string   // "mdn-sent-manually" or "mdn-sent-automatically" in lower case
+	Type        string   // "displayed", "deleted", "dispatched", or "processed" in lower case
+	Modifiers   []string // Disposition modifiers in lower case such as "error"
+}
+
+// String returns the field body of Disposition such as "automatic-action/MDN-sent-automatically; deleted".
+func (this *Disposition) String() string {
+	if this == nil { return "" }
+	sendingmode := this.SendingMode
+	if strings.HasPrefix(sendingmode, "mdn-") { sendingmode = "MDN-" + sendingmode[4:] }
+
+	text := this.Type
+	if this.ActionMode != "" || sendingmode != "" {
+		// "manual-action; displayed" without the sending mode
+		mode := this.ActionMode; if sendingmode != "" { mode += "/" + sendingmode }
+		text = mode + "; " + text
+	}
+	if len(this.Modifiers) > 0 { text += "/" + strings.Join(this.Modifiers, ",") }
+	return text
+}
+
+// Failed returns true if the disposition has "error" modifier: the message could not be processed
+// successfully. Other dispositions including "deleted" are not delivery failures.
+func (this *Disposition) Failed() bool {
+	if this == nil { return false }
+	for _, e := range this.Modifiers { if e == "error" { return true } }
+	return false
+}
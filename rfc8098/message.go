@@ -0,0 +1,145 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ___   ___   ___   ___    ____  __                                  
+//  _ __ / _| ___( _ ) / _ \ / _ \ ( _ )  / /  \/  | ___  ___ ___  __ _  __ _  ___ _ 
+// | '__| |_ / __/ _ \| | | | (_) |/ _ \ / /| |\/| |/ _ \/ __/ __|/ _` |/ _` |/ _ (_)
+// | |  |  _| (_| (_) | |_| |\__, | (_) / / | |  | |  __/\__ \__ \ (_| | (_| |  __/_ 
+// |_|  |_|  \___\___/ \___/   /_/ \___/_/  |_|  |_|\___||___/___/\__,_|\__, |\___(_)
+//                                                                      |___/        
+
+package rfc8098
+import "io"
+import "bytes"
+import "libsisimai.org/mailer-goemon/rfc3464"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// Fields written by Format() in this order, other fields in Report.Fields are written after them
+var reportFields = []string{
+	"reporting-ua", "mdn-gateway", "original-recipient", "final-recipient", "original-message-id",
+	"disposition", "error",
+}
+
+// Message composes a multipart/report message of the MDN consisting of the human readable part,
+// the disposition notification part, and the header of the original message or the whole message.
+type Message struct {
+	Header   *rfc5322.HeaderWriter // Header fields such as From, To, Subject, Date, and Message-ID
+	Text     string                // Human readable part, Explain() is used when it is empty
+	Report   *Report               // Disposition notification
+	Original []byte                // The original message, the third part is not written when it is empty
+	Content  bool                  // true: the whole original message as message/rfc822, false: its header only
+	Global   bool                  // Use message/global-disposition-notification, message/global, and message/global-headers
+	Boundary string                // Boundary of the multipart, generated when it is empty
+}
+
+// NewMessage returns a Message with an empty HeaderWriter.
+//   Arguments:
+//     - report (*Report):   Disposition notification.
+//     - original ([]byte): The original message.
+//   Returns:
+//     - (*Message): Message to be written by WriteTo() or Bytes().
+func NewMessage(report *Report, original []byte) *Message {
+	return &Message{Header: rfc5322.NewHeaderWriter(), Report: report, Original: original}
+}
+
+// Validate checks Final-Recipient and the disposition mode and the disposition type of Disposition.
+//   Returns:
+//     - (error): ErrNoFinalRecipient or ErrNoDisposition.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8098#section-3.2.6
+func (this *Report) Validate() error {
+	if this.FinalRecipient == nil || this.FinalRecipient.Value == "" { return ErrNoFinalRecipient }
+
+	cv := this.Disposition
+	if cv == nil || cv.Type == "" { return ErrNoDisposition }
+	if cv.ActionMode  != ActionManual  && cv.ActionMode  != ActionAutomatic  { return ErrNoDisposition }
+	if cv.SendingMode != SendingManual && cv.SendingMode != SendingAutomatic { return ErrNoDisposition }
+	return nil
+}
+
+// Format returns the body of message/disposition-notification or message/global-disposition-notification
+// part after Validate().
+//   Arguments:
+//     - global (bool): true for message/global-disposition-notification which allows UTF-8 characters.
+//   Returns:
+//     - ([]byte): The body with CRLF line terminators.
+//     - (error):  An error returned from Validate(), rfc5322.ErrHeaderInjection, or ErrEightBit.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8098#section-3.1
+func (this *Report) Format(global bool) ([]byte, error) {
+	if nyaan := this.Validate(); nyaan != nil { return nil, nyaan }
+
+	fields := [][2]string{
+		{"Reporting-UA",        this.ReportingUA},
+		{"MDN-Gateway",         this.MDNGateway.String()},
+		{"Original-Recipient",  this.OriginalRecipient.String()},
+		{"Final-Recipient",     this.FinalRecipient.String()},
+		{"Original-Message-ID", this.OriginalMessageID},
+		{"Disposition",         this.Disposition.String()},
+	}
+	for _, e := range this.Errors { fields = append(fields, [2]string{"Error", e}) }
+
+	body := bytes.Buffer{}
+	if nyaan := rfc3464.WriteFields(&body, append(fields, rfc3464.ExtensionFields(this.Fields, reportFields)...), global); nyaan != nil {
+		if nyaan == rfc3464.ErrEightBit { nyaan = ErrEightBit }
+		return nil, nyaan
+	}
+	return body.Bytes(), nil
+}
+
+// WriteTo writes the multipart/report message. MIME-Version, Content-Type, and "Auto-Submitted:
+// auto-replied" are added to the header fields.
+//   Arguments:
+//     - w (io.Writer): Destination.
+//   Returns:
+//     - (int64): The number of bytes written.
+//     - (error): An error returned from Bytes() or the writer.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8098#section-3
+func (this *Message) WriteTo(w io.Writer) (int64, error) {
+	message, nyaan := this.Bytes(); if nyaan != nil { return 0, nyaan }
+	n, nyaan := w.Write(message)
+	return int64(n), nyaan
+}
+
+// Bytes returns the multipart/report message, see WriteTo().
+//   Returns:
+//     - ([]byte): The message with CRLF line terminators.
+//     - (error):  An error returned from Report.Format() or rfc3464.MultipartReport.Bytes().
+func (this *Message) Bytes() ([]byte, error) {
+	if this.Report == nil { return nil, ErrNoFinalRecipient }
+	report, nyaan := this.Report.Format(this.Global); if nyaan != nil { return nil, nyaan }
+
+	multipart := &rfc3464.MultipartReport{
+		Header: this.Header, ReportType: ReportMDN, Text: this.Text, MediaType: "message/disposition-notification",
+		Report: report, Original: this.Original, Content: this.Content, Global: this.Global, Boundary: this.Boundary,
+	}
+	if multipart.Text     == "" { multipart.Text      = Explain(this.Report) }
+	if multipart.Boundary == "" { multipart.Boundary  = rfc3464.GenerateBoundary("MDN") }
+	if this.Global              { multipart.MediaType = "message/global-disposition-notification" }
+	return multipart.Bytes()
+}
+
+// Explain returns the human readable text of the disposition notification.
+//   Arguments:
+//     - report (*Report): Disposition notification.
+//   Returns:
+//     - (string): Text such as "The message sent to <neko@example.jp> has been displayed.".
+func Explain(report *Report) string {
+	if report == nil { return "" }
+	text := "The message"
+	if report.OriginalMessageID != "" { text += " " + report.OriginalMessageID }
+	if report.FinalRecipient != nil && report.FinalRecipient.Value != "" { text += " sent to <" + report.FinalRecipient.Value + ">" }
+
+	disposition := ""; if report.Disposition != nil { disposition = report.Disposition.Type }
+	switch disposition {
+		case TypeDisplayed:  text += " has been displayed. This is no guarantee that the message has been read or understood."
+		case TypeDeleted:    text += " has been deleted without being displayed."
+		case TypeDispatched: text += " has been sent somewhere without being displayed."
+		case TypeProcessed:  text += " has been processed without being displayed."
+		case "":             text += " has been processed."
+		default:             text += " has been " + disposition + "."
+	}
+	text += "\r\n"
+	for _, e := range report.Errors { text += "    " + e + "\r\n" }
+	return text
+}
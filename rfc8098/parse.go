@@ -0,0 +1,162 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//        __      ___   ___   ___   ___    ______                       
+//  _ __ / _| ___( _ ) / _ \ / _ \ ( _ )  / /  _ \ __ _ _ __ ___  ___ _ 
+// | '__| |_ / __/ _ \| | | | (_) |/ _ \ / /| |_) / _` | '__/ __|/ _ (_)
+// | |  |  _| (_| (_) | |_| |\__, | (_) / / |  __/ (_| | |  \__ \  __/_ 
+// |_|  |_|  \___\___/ \___/   /_/ \___/_/  |_|   \__,_|_|  |___/\___(_)
+
+package rfc8098
+import "io"
+import "bufio"
+import "strings"
+import "libsisimai.org/mailer-goemon/rfc2045"
+import "libsisimai.org/mailer-goemon/rfc3464"
+import "libsisimai.org/mailer-goemon/rfc5322"
+
+// Media types of the machine readable part for each report type
+var reportTypes = map[string]string{
+	"message/delivery-status":                 ReportDSN,
+	"message/global-delivery-status":          ReportDSN,
+	"message/disposition-notification":        ReportMDN,
+	"message/global-disposition-notification": ReportMDN,
+	"message/feedback-report":                 ReportARF,
+}
+
+// Classify tells the report type of the message: a DSN, an MDN, or an ARF report. The machine
+// readable part is looked for when "report-type" parameter is missing or unknown, parts in the
+// encapsulated messages are not checked.
+//   Arguments:
+//     - r (io.Reader): The whole message.
+//   Returns:
+//     - (string): ReportDSN, ReportMDN, ReportARF, or empty if the message is not a report.
+//     - (error):  An error returned from the reader.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc6522#section-3
+func Classify(r io.Reader) (string, error) {
+	reporttype := ""
+	nyaan := rfc2045.NewReader(r).Walk(func(p *rfc2045.Part) error {
+		if reporttype != "" { return nil }
+		if p.Depth == 0 && p.MediaType == "multipart/report" {
+			// multipart/report; report-type=delivery-status
+			cv := strings.ToLower(p.Params["report-type"])
+			if cv == ReportDSN || cv == ReportMDN || cv == ReportARF { reporttype = cv }
+			return nil
+		}
+		for e := p.Parent; e != nil; e = e.Parent { if e.IsMessage() { return nil } }
+		reporttype = reportTypes[p.MediaType]
+		return nil
+	})
+	return reporttype, nyaan
+}
+
+// ParseMessage parses the multipart/report message of the MDN and reads the header of the original
+// message in the text/rfc822-headers part or the message/rfc822 part.
+//   Arguments:
+//     - r (io.Reader): The whole message of the MDN.
+//   Returns:
+//     - (*Report): Parsed report, nil when the error is ErrNotDispositionNotification.
+//     - (error):   ErrNotDispositionNotification, an error returned from Parse(), or an error of the reader.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8098#section-3
+func ParseMessage(r io.Reader) (*Report, error) {
+	var report *Report
+	var header *rfc5322.Header
+	var failed error
+
+	nyaan := rfc2045.NewReader(r).Walk(func(p *rfc2045.Part) error {
+		switch {
+			case reportTypes[p.MediaType] == ReportMDN:
+				if report != nil { break }
+				report, failed = Parse(p.Body())
+
+			case p.MediaType == "text/rfc822-headers" || p.MediaType == "message/global-headers":
+				if header != nil { break }
+				cv, nyaan := rfc5322.ReadHeader(p.Body()); if nyaan != nil { return nyaan }
+				header = cv
+
+			case p.Parent != nil && p.Parent.IsMessage() && p.Parent.Depth == 1:
+				// The header of the encapsulated message in the message/rfc822 part of the report
+				if header == nil { header = p.Header }
+		}
+		return nil
+	})
+	if nyaan != nil { return report, nyaan }
+	if report == nil { return nil, ErrNotDispositionNotification }
+
+	report.Header = header
+	return report, failed
+}
+
+// Parse parses the body of message/disposition-notification or message/global-disposition-notification part.
+//   Arguments:
+//     - r (io.Reader): The body of the disposition notification part.
+//   Returns:
+//     - (*Report): Parsed report, valid even when the error is not nil.
+//     - (error):   ErrNoFinalRecipient, ErrNoDisposition, or an error returned from the reader.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8098#section-3.1
+//     - https://datatracker.ietf.org/doc/html/rfc6533#section-6
+func Parse(r io.Reader) (*Report, error) {
+	reader, ok := r.(*bufio.Reader); if ok == false { reader = bufio.NewReader(r) }
+	report := &Report{Fields: &rfc5322.Header{Fields: []*rfc5322.Field{}}, Errors: []string{}}
+
+	for {
+		// Some MUAs insert empty lines between fields
+		header, nyaan := rfc5322.ReadHeader(reader)
+		if nyaan != nil { return report, nyaan }
+		if header.Size == 0 { break }
+		report.Fields.Fields = append(report.Fields.Fields, header.Fields...)
+		report.Fields.Flags |= header.Flags
+	}
+
+	for _, e := range report.Fields.Fields {
+		// Set each field to the member of Report
+		switch strings.ToLower(e.Name) {
+			case "reporting-ua":        report.ReportingUA = e.Value
+			case "mdn-gateway":         report.MDNGateway = rfc3464.ParseTypedValue(e.Value)
+			case "original-recipient":  report.OriginalRecipient = rfc3464.ParseTypedValue(e.Value)
+			case "final-recipient":     report.FinalRecipient = rfc3464.ParseTypedValue(e.Value)
+			case "original-message-id": report.OriginalMessageID = e.Value
+			case "disposition":         report.Disposition = ParseDisposition(e.Value)
+			case "error":               report.Errors = append(report.Errors, e.Value)
+		}
+	}
+	if report.FinalRecipient == nil || report.FinalRecipient.Value == "" { return report, ErrNoFinalRecipient }
+	if report.Disposition == nil { return report, ErrNoDisposition }
+	return report, nil
+}
+
+// ParseDisposition parses the field body of Disposition.
+//   Arguments:
+//     - value (string): The field body such as "manual-action/MDN-sent-manually; displayed".
+//   Returns:
+//     - (*Disposition): Parsed disposition, nil if the disposition type is missing.
+//   See:
+//     - https://datatracker.ietf.org/doc/html/rfc8098#section-3.2.6
+func ParseDisposition(value string) *Disposition {
+	//   disposition-field = "Disposition" ":" OWS disposition-mode ";" OWS disposition-type
+	//                       [ OWS "/" OWS disposition-modifier *( OWS "," OWS disposition-modifier ) ] OWS
+	//   disposition-mode  = action-mode OWS "/" OWS sending-mode
+	value = strings.ToLower(value)
+	if p := strings.IndexByte(value, '('); p > -1 { value = value[:p] } // Remove the comment
+	disposition := &Disposition{Modifiers: []string{}}
+
+	if p := strings.IndexByte(value, ';'); p > -1 {
+		// "manual-action/MDN-sent-manually; displayed"
+		mode := strings.SplitN(value[:p], "/", 2)
+		disposition.ActionMode = strings.TrimSpace(mode[0])
+		if len(mode) == 2 { disposition.SendingMode = strings.TrimSpace(mode[1]) }
+		value = value[p + 1:]
+	}
+
+	dtype := strings.SplitN(value, "/", 2)
+	disposition.Type = strings.TrimSpace(dtype[0]); if disposition.Type == "" { return nil }
+	if len(dtype) == 2 {
+		// "displayed/error", "deleted/expired,mailbox-terminated"
+		for _, e := range strings.Split(dtype[1], ",") {
+			if cv := strings.TrimSpace(e); cv != "" { disposition.Modifiers = append(disposition.Modifiers, cv) }
+		}
+	}
+	return disposition
+}
@@ -1,4 +1,4 @@
-// Copyright (C) 2025 azumakuniyuki and sisimai development team, All rights reserved.
+// Copyright (C) 2025-2026 azumakuniyuki and sisimai development team, All rights reserved.
 // This software is distributed under The BSD 2-Clause License.
 package status
 
@@ -8,7 +8,9 @@ package status
 //   | |  __/\__ \ |_ / /\__ \ | | | | | |_| |_) / /\__ \ || (_| | |_| |_| \__ \
 //   |_|\___||___/\__/_/ |___/_| |_| |_|\__| .__/_/ |___/\__\__,_|\__|\__,_|___/
 //                                         |_|                                  
+import "strings"
 import "testing"
+import "unicode/utf8"
 
 var StatusList = []string{
 	"2.1.5",
@@ -95,6 +97,68 @@ func TestFind(t *testing.T) {
 	t.Logf("The number of tests = %d", cx)
 }
 
+func TestFindAll(t *testing.T) {
+	fn := "smtp/status.FindAll"
+	cx := 0
+
+	for _, e := range SMTPErrors {
+		cv := FindAll(e)
+		cx++; if len(cv) == 0 { t.Errorf("%s(%s) returns empty", fn, e); continue }
+		for _, f := range cv {
+			cx++; if e[f.Offset:f.Offset + len(f.Code)] != f.Code { t.Errorf("%s(%s): wrong offset %d of %s", fn, e, f.Offset, f.Code) }
+			cx++; if strings.Contains(f.Context, f.Code) == false { t.Errorf("%s(%s): wrong context %s", fn, e, f.Context) }
+		}
+		cx++; if Select(cv) != Find(e, "") { t.Errorf("Select(%s(%s)) returns %s", fn, e, Select(cv)) }
+	}
+	for _, e := range p5issue574 {
+		cx++; if cv := FindAll(e); len(cv) != 0 { t.Errorf("%s(%s) returns %v", fn, e, cv) }
+	}
+	cx++; if cv := FindAll(""); cv == nil || len(cv) != 0 { t.Errorf("%s('') returns %v", fn, cv) }
+
+	ce := "550 5.0.0 <neko@example.jp>: host 5.1.1.1 said: 550 5.2.2 Mailbox full (4.4.7)"
+	cv := FindAll(ce)
+	ae := []Match{
+		{Code: "5.0.0", Offset:  4, Ambiguous: true},
+		{Code: "5.1.1", Offset: 34, Masked: true},
+		{Code: "5.2.2", Offset: 52},
+		{Code: "4.4.7", Offset: 72, Ambiguous: true},
+	}
+	cx++; if len(cv) != len(ae) { t.Fatalf("%s(%s) returns %v", fn, ce, cv) }
+	for j, e := range ae {
+		cx++; if cv[j].Code      != e.Code      { t.Errorf("%s()[%d].Code is %s", fn, j, cv[j].Code) }
+		cx++; if cv[j].Offset    != e.Offset    { t.Errorf("%s()[%d].Offset is %d", fn, j, cv[j].Offset) }
+		cx++; if cv[j].Ambiguous != e.Ambiguous { t.Errorf("%s()[%d].Ambiguous is %t", fn, j, cv[j].Ambiguous) }
+		cx++; if cv[j].Masked    != e.Masked    { t.Errorf("%s()[%d].Masked is %t", fn, j, cv[j].Masked) }
+	}
+	cx++; if cv[0].Context != "550 5.0.0 <neko@example.jp>: host 5.1.1.1" { t.Errorf("%s()[0].Context is %s", fn, cv[0].Context) }
+	cx++; if cv := Select(cv);  cv != "5.2.2" { t.Errorf("Select(%s()) returns %s", fn, cv) }
+	cx++; if cv := Find(ce, ""); cv != "5.2.2" { t.Errorf("Find(%s) returns %s", ce, cv) }
+
+	// Do not break a multibyte character in the context
+	cv = FindAll("ねこねこねこねこねこねこ 550 5.2.2 メールボックスがいっぱいです")
+	cx++; if len(cv) != 1 || utf8.ValidString(cv[0].Context) == false { t.Errorf("%s() returns %v", fn, cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestSelect(t *testing.T) {
+	fn := "smtp/status.Select"
+	cx := 0
+	ae := []struct {matches []Match; expected string}{
+		{[]Match{}, ""},
+		{[]Match{{Code: "5.0.0", Ambiguous: true}}, "5.0.0"},
+		{[]Match{{Code: "5.1.1", Masked: true}}, ""},
+		{[]Match{{Code: "5.0.0", Ambiguous: true}, {Code: "5.1.1"}}, "5.1.1"},
+		{[]Match{{Code: "4.0.0", Ambiguous: true}, {Code: "4.4.7", Ambiguous: true}}, "4.4.7"},
+		{[]Match{{Code: "5.1.1", Masked: true}, {Code: "5.2.2"}}, "5.2.2"},
+		{[]Match{{Code: "5.5.1"}, {Code: "5.1.1"}}, "5.1.1"},
+	}
+	for _, e := range ae {
+		cx++; if cv := Select(e.matches); cv != e.expected { t.Errorf("%s(%v) returns %s", fn, e.matches, cv) }
+	}
+	t.Logf("The number of tests = %d", cx)
+}
+
 func TestPrefer(t *testing.T) {
 	fn := "smtp/status.Prefer"
 	ae := []struct {lhs string; rhs string; rep string; exp string}{
@@ -132,6 +196,88 @@ func TestPrefer(t *testing.T) {
 	t.Logf("The number of tests = %d", cx)
 }
 
+func TestDecide(t *testing.T) {
+	fn := "smtp/status.Decide"
+	cx := 0
+	ae := []struct {lhs string; rhs string; rep string; exp string; rule string}{
+		{"", "", "", "", "empty-status"},
+		{"5.2.2", "", "", "5.2.2", "empty-message"},
+		{"5.7", "5.7.26", "421", "5.7.26", "short-status"},
+		{"4.2.1", "5.7.0", "421", "4.2.1", "reply-class"},
+		{"4.0.0", "4.0.0", "421", "4.0.0", "same-code"},
+		{"5.0.0", "5.1.1", "", "5.1.1", "status-x.0.0"},
+		{"5.2.0", "5.2.1", "", "5.2.1", "status-x.y.0"},
+		{"5.1.3", "5.0.0", "", "5.1.3", "message-x.0.0"},
+		{"4.4.7", "4.2.2", "", "4.2.2", "status-4.4.7"},
+		{"5.5.4", "5.1.1", "550", "5.1.1", "status-x.5.z"},
+		{"5.1.1", "5.2.5", "", "5.2.5", "status-5.1.1"},
+		{"5.1.3", "5.7.0", "", "5.7.0", "status-5.1.3"},
+		{"5.1.3", "5.7.1", "", "5.7.1", "status-5.1.3"},
+		{"5.7.8", "4.4.0", "550", "5.7.8", "reply-class"},
+		{"5.2.2", "5.7.1", "", "5.2.2", "default"},
+	}
+	for _, e := range ae {
+		cv := Decide(e.lhs, e.rhs, e.rep)
+		cx++; if cv.Code != e.exp   { t.Errorf("%s(%s, %s, %s).Code is %s", fn, e.lhs, e.rhs, e.rep, cv.Code) }
+		cx++; if cv.Rule != e.rule  { t.Errorf("%s(%s, %s, %s).Rule is %s", fn, e.lhs, e.rhs, e.rep, cv.Rule) }
+		cx++; if cv.Code != Prefer(e.lhs, e.rhs, e.rep) { t.Errorf("%s(%s, %s, %s) differs from Prefer()", fn, e.lhs, e.rhs, e.rep) }
+		cx++; if len(cv.Trace) == 0 || cv.Trace[len(cv.Trace) - 1] != cv.Rule { t.Errorf("%s(%s, %s, %s).Trace is %v", fn, e.lhs, e.rhs, e.rep, cv.Trace) }
+	}
+	cx++; if cv := Decide("5.2.2", "5.7.1", ""); len(cv.Trace) != len(PreferRules()) + 1 { t.Errorf("%s().Trace is %v", fn, cv.Trace) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestPreference(t *testing.T) {
+	fn := "smtp/status.Preference"
+	cx := 0
+
+	rules := PreferRules()
+	for _, e := range rules {
+		cx++; if e.Name == "" || e.Description == "" || e.Choose == nil { t.Errorf("PreferRules() returns %v", e.Name) }
+	}
+	rules[0] = PreferRule{Name: "nyaan"}
+	cx++; if PreferRules()[0].Name != "empty-status" { t.Errorf("PreferRules() returns the default rules") }
+	cx++; if NewPreference(nil).Prefer("5.1.1", "5.2.2", "") != "5.2.2" { t.Errorf("%s{nil}.Prefer() is not Prefer()", fn) }
+
+	// Prefer "5.7.Z" policy codes of our own MTA in the message
+	nekomta := PreferRule{Name: "nekomta-policy", Description: "Prefer 5.7.Z in the message",
+		Choose: func(statuscode, codeinmesg, esmtpreply string) (string, bool) {
+			return codeinmesg, strings.HasPrefix(codeinmesg, "5.7.")
+		},
+	}
+	cv := NewPreference(append([]PreferRule{nekomta}, PreferRules()...)).Decide("5.2.2", "5.7.1", "")
+	cx++; if cv.Code != "5.7.1" || cv.Rule != "nekomta-policy" { t.Errorf("%s.Decide() returns %v", fn, cv) }
+	cx++; if len(cv.Trace) != 1 { t.Errorf("%s.Decide().Trace is %v", fn, cv.Trace) }
+	cv = NewPreference(append([]PreferRule{nekomta}, PreferRules()...)).Decide("5.2.2", "5.1.1", "")
+	cx++; if cv.Code != "5.2.2" || cv.Rule != "default" { t.Errorf("%s.Decide() returns %v", fn, cv) }
+
+	// Remove the rule of "4.4.7"
+	rules = []PreferRule{}
+	for _, e := range PreferRules() { if e.Name != "status-4.4.7" { rules = append(rules, e) } }
+	cx++; if cv := NewPreference(rules).Prefer("4.4.7", "4.2.2", ""); cv != "4.4.7" { t.Errorf("%s.Prefer() returns %s", fn, cv) }
+	cx++; if cv := NewPreference([]PreferRule{}).Decide("", "5.1.1", ""); cv.Code != "" || cv.Rule != "default" { t.Errorf("%s.Decide() returns %v", fn, cv) }
+	cx++; if cv := NewPreference([]PreferRule{{Name: "nil"}}).Decide("5.1.1", "", ""); cv.Code != "5.1.1" || len(cv.Trace) != 1 { t.Errorf("%s.Decide() returns %v", fn, cv) }
+
+	// Each rule guards its own arguments without the preceding rules
+	ae := [][3]string{
+		{"", "", ""}, {"5.1.1", "", ""}, {"", "5.1.1", ""}, {"", "", "550"}, {"5", "", "5"}, {"", "4", "4"},
+		{"5.1.1", "", "550"}, {"", "4.2.2", "452"}, {"5", "4", ""}, {".0.0", ".0", "0"},
+	}
+	for _, e := range PreferRules() {
+		for _, f := range ae {
+			func() {
+				defer func() { if r := recover(); r != nil { t.Errorf("%s(%q).Choose(%q) panics: %v", fn, e.Name, f, r) } }()
+				cv, ok := e.Choose(f[0], f[1], f[2])
+				cx++; if ok && cv != f[0] && cv != f[1] { t.Errorf("%s(%q).Choose(%q) returns %q", fn, e.Name, f, cv) }
+				cx++; if ce := NewPreference([]PreferRule{e}).Decide(f[0], f[1], f[2]); ce.Code != f[0] && ce.Code != f[1] { t.Errorf("%s(%q).Decide(%q) returns %v", fn, e.Name, f, ce) }
+			}()
+		}
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
 func TestIsAmbiguous(t *testing.T) {
 	fn := "smtp/status/IsAmbiguous"
 	cx := 0
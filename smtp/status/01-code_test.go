@@ -0,0 +1,85 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package status
+
+//  _____         _      __             _           __   _        _             
+// |_   _|__  ___| |_   / /__ _ __ ___ | |_ _ __   / /__| |_ __ _| |_ _   _ ___ 
+//   | |/ _ \/ __| __| / / __| '_ ` _ \| __| '_ \ / / __| __/ _` | __| | | / __|
+//   | |  __/\__ \ |_ / /\__ \ | | | | | |_| |_) / /\__ \ || (_| | |_| |_| \__ \
+//   |_|\___||___/\__/_/ |___/_| |_| |_|\__| .__/_/ |___/\__\__,_|\__|\__,_|___/
+//                                         |_|                                  
+import "testing"
+
+func TestParse(t *testing.T) {
+	fn := "smtp/status.Parse"
+	cx := 0
+
+	for _, e := range StatusList {
+		cv, nyaan := Parse(e)
+		cx++; if nyaan != nil    { t.Errorf("%s(%s) returns error: %s", fn, e, nyaan) }
+		cx++; if cv.String() != e { t.Errorf("%s(%s).String() returns %s", fn, e, cv.String()) }
+	}
+	for _, e := range []string{"3.14", "5.0.3.2", "1.0.0", "5.8.0", "5.12.0", "5.2.-2", "192.0.2.25", "", "nekochan"} {
+		cv, nyaan := Parse(e)
+		cx++; if nyaan != ErrInvalidCode { t.Errorf("%s(%s) returns %v", fn, e, nyaan) }
+		cx++; if cv != (Code{})          { t.Errorf("%s(%s) returns %v", fn, e, cv) }
+	}
+
+	ae := []struct {code string; class int; subject Subject; detail int; name string}{
+		{"2.1.5",  2, SubjectAddressing, 5,  "addressing"},
+		{"4.2.2",  4, SubjectMailbox,    2,  "mailbox"},
+		{"5.3.4",  5, SubjectSystem,     4,  "system"},
+		{"4.4.7",  4, SubjectNetwork,    7,  "network"},
+		{"5.5.1",  5, SubjectProtocol,   1,  "protocol"},
+		{"5.6.0",  5, SubjectContent,    0,  "content"},
+		{"5.7.26", 5, SubjectPolicy,     26, "policy"},
+		{"5.0.0",  5, SubjectOther,      0,  "other"},
+	}
+	for _, e := range ae {
+		cv, _ := Parse(e.code)
+		cx++; if cv.Class()   != e.class   { t.Errorf("%s(%s).Class() returns %d", fn, e.code, cv.Class()) }
+		cx++; if cv.Subject() != e.subject { t.Errorf("%s(%s).Subject() returns %d", fn, e.code, cv.Subject()) }
+		cx++; if cv.Detail()  != e.detail  { t.Errorf("%s(%s).Detail() returns %d", fn, e.code, cv.Detail()) }
+		cx++; if cv.Subject().String() != e.name { t.Errorf("%s(%s).Subject().String() returns %s", fn, e.code, cv.Subject()) }
+	}
+	cx++; if cv := Subject(8).String(); cv != "" { t.Errorf("Subject(8).String() returns %s", cv) }
+	cx++; if cv := (Code{}).String();   cv != "" { t.Errorf("Code{}.String() returns %s", cv) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestCode(t *testing.T) {
+	fn := "smtp/status.Code"
+	cx := 0
+	ae := []struct {code string; success bool; transient bool; permanent bool; ambiguous bool}{
+		{"2.0.0", true,  false, false, true},
+		{"2.1.5", true,  false, false, false},
+		{"4.0.0", false, true,  false, true},
+		{"4.4.7", false, true,  false, false},
+		{"5.0.0", false, false, true,  true},
+		{"5.1.0", false, false, true,  false},
+		{"5.0.1", false, false, true,  false},
+	}
+	for _, e := range ae {
+		cv, _ := Parse(e.code)
+		cx++; if cv.IsSuccess()   != e.success   { t.Errorf("%s(%s).IsSuccess() returns %t", fn, e.code, cv.IsSuccess()) }
+		cx++; if cv.IsTransient() != e.transient { t.Errorf("%s(%s).IsTransient() returns %t", fn, e.code, cv.IsTransient()) }
+		cx++; if cv.IsPermanent() != e.permanent { t.Errorf("%s(%s).IsPermanent() returns %t", fn, e.code, cv.IsPermanent()) }
+		cx++; if cv.IsAmbiguous() != e.ambiguous { t.Errorf("%s(%s).IsAmbiguous() returns %t", fn, e.code, cv.IsAmbiguous()) }
+		cx++; if cv.IsAmbiguous() != IsAmbiguous(e.code) { t.Errorf("%s(%s).IsAmbiguous() differs from IsAmbiguous()", fn, e.code) }
+	}
+	cx++; if (Code{}).IsAmbiguous() == false { t.Errorf("%s{}.IsAmbiguous() returns false", fn) }
+
+	be := []struct {lhs string; rhs string; expected int}{
+		{"5.1.1", "5.1.1", 0}, {"4.2.2", "5.2.2", -1}, {"5.2.2", "4.2.2", 1}, {"5.1.10", "5.2.1", -1},
+		{"5.7.26", "5.7.3", 1}, {"5.7.1", "5.7.26", -1}, {"2.0.0", "2.0.0", 0},
+	}
+	for _, e := range be {
+		cl, _ := Parse(e.lhs)
+		cr, _ := Parse(e.rhs)
+		cx++; if cv := cl.Compare(cr); cv != e.expected { t.Errorf("%s(%s).Compare(%s) returns %d", fn, e.lhs, e.rhs, cv) }
+		cx++; if cv := cr.Compare(cl); cv != -e.expected { t.Errorf("%s(%s).Compare(%s) returns %d", fn, e.rhs, e.lhs, cv) }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
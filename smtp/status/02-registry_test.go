@@ -0,0 +1,73 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package status
+
+//  _____         _      __             _           __   _        _             
+// |_   _|__  ___| |_   / /__ _ __ ___ | |_ _ __   / /__| |_ __ _| |_ _   _ ___ 
+//   | |/ _ \/ __| __| / / __| '_ ` _ \| __| '_ \ / / __| __/ _` | __| | | / __|
+//   | |  __/\__ \ |_ / /\__ \ | | | | | |_| |_) / /\__ \ || (_| | |_| |_| \__ \
+//   |_|\___||___/\__/_/ |___/_| |_| |_|\__| .__/_/ |___/\__\__,_|\__|\__,_|___/
+//                                         |_|                                  
+import "strings"
+import "testing"
+
+func TestLookup(t *testing.T) {
+	fn := "smtp/status.Lookup"
+	cx := 0
+	ae := []struct {code string; title string; refer string; reply []string}{
+		{"5.7.26", "Multiple authentication checks failed", "RFC7372", []string{"550"}},
+		{"4.7.26", "Multiple authentication checks failed", "RFC7372", []string{}},
+		{"X.7.26", "Multiple authentication checks failed", "RFC7372", []string{"550"}},
+		{"5.1.1",  "Bad destination mailbox address",       "RFC3463", []string{"550"}},
+		{"4.1.1",  "Bad destination mailbox address",       "RFC3463", []string{"451"}},
+		{"4.2.4",  "Mailing list expansion problem",        "RFC3463", []string{"450", "452"}},
+		{"5.5.4",  "Invalid command arguments",             "RFC3463", []string{"501", "502", "503", "504", "550", "555"}},
+		{"5.7.15", "Priority Level is too low",             "RFC6710", []string{"550", "5XX"}},
+		{"5.1.10", "Recipient address has null MX",         "RFC7505", []string{}},
+		{"4.4.7",  "Delivery time expired",                 "RFC3463", []string{}},
+	}
+	for _, e := range ae {
+		cv, ok := Lookup(e.code)
+		cx++; if ok == false { t.Errorf("%s(%s) returns false", fn, e.code); continue }
+		cx++; if cv.Code  != e.code  { t.Errorf("%s(%s).Code is %s", fn, e.code, cv.Code) }
+		cx++; if cv.Title != e.title { t.Errorf("%s(%s).Title is %s", fn, e.code, cv.Title) }
+		cx++; if cv.Description == "" { t.Errorf("%s(%s).Description is empty", fn, e.code) }
+		cx++; if len(cv.References) == 0 || cv.References[0] != e.refer { t.Errorf("%s(%s).References is %v", fn, e.code, cv.References) }
+		cx++; if strings.Join(cv.ReplyCodes, ",") != strings.Join(e.reply, ",") { t.Errorf("%s(%s).ReplyCodes is %v", fn, e.code, cv.ReplyCodes) }
+	}
+	for _, e := range []string{"", "5.7.99", "5.8.1", "X.8.1", "X.", "nekochan", "3.1.1", "5.1.1.1"} {
+		cx++; if _, ok := Lookup(e); ok == true { t.Errorf("%s(%s) returns true", fn, e) }
+	}
+
+	// The entry returned from Lookup() is a copy of the registry
+	cv, _ := Lookup("X.1.1"); cv.ReplyCodes[0] = "599"
+	cx++; if ce, _ := Lookup("5.1.1"); ce.ReplyCodes[0] != "550" { t.Errorf("%s() returns the registry itself", fn) }
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestRegistry(t *testing.T) {
+	fn := "smtp/status.Registry"
+	cx := 0
+
+	cv := Registry()
+	cx++; if len(cv) < 70 { t.Errorf("%s() returns %d entries", fn, len(cv)) }
+	cx++; if cv[0].Code != "X.0.0" { t.Errorf("%s()[0] is %s", fn, cv[0].Code) }
+	for j, e := range cv {
+		cx++; if strings.HasPrefix(e.Code, "X.") == false { t.Errorf("%s()[%d].Code is %s", fn, j, e.Code) }
+		cx++; if e.Title == "" || e.Description == "" { t.Errorf("%s()[%d] has no title or description", fn, j) }
+		cx++; if len(e.References) == 0 { t.Errorf("%s()[%d] has no reference", fn, j) }
+		for _, f := range e.ReplyCodes { cx++; if len(f) != 3 { t.Errorf("%s()[%d].ReplyCodes has %s", fn, j, f) } }
+		cx++; if Test("5" + e.Code[1:]) == false { t.Errorf("%s()[%d].Code is invalid", fn, j) }
+		if j == 0 { continue }
+		ce, _ := Parse("5" + cv[j - 1].Code[1:])
+		cf, _ := Parse("5" + e.Code[1:])
+		cx++; if ce.Compare(cf) >= 0 { t.Errorf("%s() is not sorted: %s, %s", fn, cv[j - 1].Code, e.Code) }
+	}
+	for _, e := range StatusList {
+		// Every status code in the list is registered
+		cx++; if _, ok := Lookup(e); ok == false { t.Errorf("%s(): %s is not registered", fn, e) }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
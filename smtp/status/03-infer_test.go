@@ -0,0 +1,99 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+package status
+
+//  _____         _      __             _           __   _        _             
+// |_   _|__  ___| |_   / /__ _ __ ___ | |_ _ __   / /__| |_ __ _| |_ _   _ ___ 
+//   | |/ _ \/ __| __| / / __| '_ ` _ \| __| '_ \ / / __| __/ _` | __| | | / __|
+//   | |  __/\__ \ |_ / /\__ \ | | | | | |_| |_) / /\__ \ || (_| | |_| |_| \__ \
+//   |_|\___||___/\__/_/ |___/_| |_| |_|\__| .__/_/ |___/\__\__,_|\__|\__,_|___/
+//                                         |_|                                  
+import "strings"
+import "testing"
+
+func TestInfer(t *testing.T) {
+	fn := "smtp/status.Infer"
+	cx := 0
+	ae := []struct {text string; replycode string; comm string; expected string; phrase string}{
+		{"552 message too large", "", "", "5.3.4", "message too large"},
+		{"Message too large", "552", "", "5.3.4", "message too large"},
+		{"550 Mailbox quota exceeded", "", "", "5.2.2", "quota exceeded"},
+		{"Mailbox quota exceeded, 250 MB limit", "", "", "5.2.2", "quota exceeded"},
+		{"452 4.2.2 Mailbox full", "", "", "4.2.2", ""},
+		{"452 Mailbox full", "", "", "4.2.2", "mailbox full"},
+		{"Requested action aborted: user not found", "", "", "5.1.1", "user not found"},
+		{"550 user unknown", "", "MAIL", "5.1.7", "user unknown"},
+		{"553 Sender address rejected: Domain not found", "", "", "5.1.8", "sender address rejected: domain not found"},
+		{"550 Host unknown (in reply to RCPT TO command)", "", "", "5.1.2", "host unknown"},
+		{"554 Relay access denied", "", "", "5.7.1", "relay access denied"},
+		{"550 Message rejected as spam", "", "", "5.7.1", "rejected as spam"},
+		{"550 Unauthenticated email from example.jp is not accepted due to domain's DMARC policy", "", "", "5.7.26", "dmarc policy"},
+		{"550 DKIM check failed", "", "", "5.7.20", "dkim check failed"},
+		{"550 Client host rejected: cannot find your reverse hostname", "", "", "5.7.25", "cannot find your reverse hostname"},
+		{"550 Nyaan: dkim=pass, dmarc=pass, ptr record ok, rdns ok", "", "", "", ""},
+		{"550 Your message was blocked; antispam scan passed", "", "", "", ""},
+		{"550 User not found, see the spam folder", "", "", "5.1.1", "user not found"},
+		{"421 Connection timed out", "", "", "4.4.2", "connection timed out"},
+		{"Delivery time expired", "", "", "4.4.7", "delivery time expired"},
+		{"501 Syntax error in parameters", "", "", "5.5.2", "syntax error"},
+		{"Too many recipients", "452", "", "4.5.3", "too many recipients"},
+		{"550 Benutzer unbekannt", "", "", "5.1.1", "benutzer unbekannt"},
+		{"550 Utilisateur inconnu", "", "", "5.1.1", "utilisateur inconnu"},
+		{"550 Buzón lleno", "", "", "5.2.2", "buzón lleno"},
+		{"552 Mensagem muito grande", "", "", "5.3.4", "mensagem muito grande"},
+		{"550 存在しないユーザです", "", "", "5.1.1", "存在しないユーザ"},
+		{"554 邮箱已满", "", "", "5.2.2", "邮箱已满"},
+		{"550 Пользователь не найден", "", "", "5.1.1", "пользователь не найден"},
+		{"250 2.0.0 Ok", "", "", "2.0.0", ""},
+		{"250 Ok", "", "", "", ""},
+		{"Nyaan", "", "", "", ""},
+		{"", "", "", "", ""},
+	}
+	for _, e := range ae {
+		cv := Infer(e.text, e.replycode, e.comm)
+		cx++; if cv.Code   != e.expected { t.Errorf("%s(%q, %q, %q).Code is %s", fn, e.text, e.replycode, e.comm, cv.Code) }
+		cx++; if cv.Phrase != e.phrase   { t.Errorf("%s(%q, %q, %q).Phrase is %s", fn, e.text, e.replycode, e.comm, cv.Phrase) }
+		if e.expected == "" {
+			cx++; if cv.Confidence != 0.0 { t.Errorf("%s(%q).Confidence is %f", fn, e.text, cv.Confidence) }
+			continue
+		}
+		cx++; if cv.Confidence <= 0.0 || cv.Confidence > 1.0 { t.Errorf("%s(%q).Confidence is %f", fn, e.text, cv.Confidence) }
+		cx++; if _, ok := Lookup(cv.Code); ok == false { t.Errorf("%s(%q): %s is not registered", fn, e.text, cv.Code) }
+		if e.phrase == "" { cx++; if cv.Confidence != 1.0 { t.Errorf("%s(%q).Confidence is %f", fn, e.text, cv.Confidence) } }
+	}
+
+	// The reply code given as an argument, and the reply code in the registry raise the confidence
+	c0 := Infer("message too large", "", "")
+	c1 := Infer("552 message too large", "", "")
+	c2 := Infer("message too large", "552", "")
+	c3 := Infer("message too large", "554", "")
+	cx++; if c0.Code != "5.3.4" || c0.Confidence >= c1.Confidence { t.Errorf("%s() returns %v, %v", fn, c0, c1) }
+	cx++; if c1.Confidence >= c2.Confidence { t.Errorf("%s() returns %v, %v", fn, c1, c2) }
+	cx++; if c2.Confidence != c3.Confidence { t.Errorf("%s() returns %v, %v", fn, c2, c3) }
+	cx++; if c4 := Infer("message too large", "521", ""); c4.Code != "5.3.4" || c4.Confidence >= c3.Confidence { t.Errorf("%s() returns %v", fn, c4) }
+
+	codes := map[string]bool{}
+	for _, e := range inferphrases {
+		// Every status code in the phrase table is registered and appears once
+		cx++; if codes[e.code] { t.Errorf("%s: %s appears twice", fn, e.code) }; codes[e.code] = true
+		cx++; if _, ok := Lookup(e.class + e.code[1:]); ok == false { t.Errorf("%s: %s is not registered", fn, e.code) }
+		for _, f := range e.phrases { cx++; if f != strings.ToLower(f) { t.Errorf("%s: %s is not lower-cased", fn, f) } }
+	}
+
+	t.Logf("The number of tests = %d", cx)
+}
+
+func TestReplyCodeOf(t *testing.T) {
+	fn := "smtp/status.replyCodeOf"
+	cx := 0
+	ae := []struct {text string; expected string}{
+		{"552 message too large", "552"}, {"smtp; 550-Mailbox full", "550"}, {"<neko@example.jp>: 421 Timeout", "421"},
+		{"host mx.example.jp said: 550 5.1.1 User unknown", "550"}, {"Nyaan\r\n  452 Mailbox full", "452"},
+		{"Mailbox quota exceeded, 250 MB limit", ""}, {"Nyaan 550 Nyaan", ""},
+		{"192.0.2.250 Timeout", ""}, {"5.5.0 Nyaan", ""}, {"1550 Nyaan", ""}, {"55", ""}, {"Nyaan 45", ""}, {"", ""},
+	}
+	for _, e := range ae {
+		cx++; if cv := replyCodeOf(e.text); cv != e.expected { t.Errorf("%s(%q) returns %s", fn, e.text, cv) }
+	}
+	t.Logf("The number of tests = %d", cx)
+}
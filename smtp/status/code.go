@@ -0,0 +1,105 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                _           __   _        _             
+//  ___ _ __ ___ | |_ _ __   / /__| |_ __ _| |_ _   _ ___ 
+// / __| '_ ` _ \| __| '_ \ / / __| __/ _` | __| | | / __|
+// \__ \ | | | | | |_| |_) / /\__ \ || (_| | |_| |_| \__ \
+// |___/_| |_| |_|\__| .__/_/ |___/\__\__,_|\__|\__,_|___/
+//                   |_|                                  
+
+package status
+import "errors"
+import "strconv"
+import "strings"
+
+var ErrInvalidCode = errors.New("smtp/status: invalid status code")
+
+// Subject is the 2nd element of an SMTP status code, X.[Y].Z, which tells the category of the status.
+type Subject uint8
+const (
+	SubjectOther      Subject = iota // X.0.X Other or Undefined Status
+	SubjectAddressing                // X.1.X Addressing Status
+	SubjectMailbox                   // X.2.X Mailbox Status
+	SubjectSystem                    // X.3.X Mail System Status
+	SubjectNetwork                   // X.4.X Network and Routing Status
+	SubjectProtocol                  // X.5.X Mail Delivery Protocol Status
+	SubjectContent                   // X.6.X Message Content or Media Status
+	SubjectPolicy                    // X.7.X Security or Policy Status
+)
+var subjectname = [8]string{"other", "addressing", "mailbox", "system", "network", "protocol", "content", "policy"}
+
+// String returns the name of the subject such as "mailbox", "policy".
+func(this Subject) String() string {
+	if int(this) < len(subjectname) { return subjectname[this] }
+	return ""
+}
+
+// Code is a parsed SMTP status code such as 5.1.1. The zero value is not a valid code.
+type Code struct {
+	class   uint8
+	subject Subject
+	detail  uint16
+}
+
+// Parse converts the SMTP status code string into a Code.
+//   Arguments:
+//     - code (string): SMTP status code such as "5.1.1", "4.7.26".
+//   Returns:
+//     - (Code): Parsed status code.
+//     - (error): ErrInvalidCode when the code is not a valid SMTP status code.
+func Parse(code string) (Code, error) {
+	if Test(code) == false { return Code{}, ErrInvalidCode }
+
+	token := strings.Split(code, ".")
+	class,   _ := strconv.Atoi(token[0])
+	subject, _ := strconv.Atoi(token[1])
+	detail,  _ := strconv.Atoi(token[2])
+	return Code{class: uint8(class), subject: Subject(subject), detail: uint16(detail)}, nil
+}
+
+// Class returns the 1st element of the status code: 2, 4, or 5. It returns 0 when the code is the zero value.
+func(this Code) Class() int { return int(this.class) }
+
+// Subject returns the 2nd element of the status code.
+func(this Code) Subject() Subject { return this.subject }
+
+// Detail returns the 3rd element of the status code.
+func(this Code) Detail() int { return int(this.detail) }
+
+// String returns the status code as a string like "5.1.1". It returns "" when the code is the zero value.
+func(this Code) String() string {
+	if this.class == 0 { return "" }
+	return strconv.Itoa(int(this.class)) + "." + strconv.Itoa(int(this.subject)) + "." + strconv.Itoa(int(this.detail))
+}
+
+// IsSuccess returns true if the class of the status code is 2.
+func(this Code) IsSuccess() bool { return this.class == 2 }
+
+// IsTransient returns true if the class of the status code is 4, a persistent transient failure.
+func(this Code) IsTransient() bool { return this.class == 4 }
+
+// IsPermanent returns true if the class of the status code is 5, a permanent failure.
+func(this Code) IsPermanent() bool { return this.class == 5 }
+
+// IsAmbiguous returns true if the code is the zero value or "X.0.0" as same as IsAmbiguous function.
+func(this Code) IsAmbiguous() bool {
+	return this.class == 0 || this.subject == SubjectOther && this.detail == 0
+}
+
+// Compare compares the status code with the argument in the order of the class, the subject, and the detail.
+//   Arguments:
+//     - that (Code): Status code to be compared.
+//   Returns:
+//     - (int): -1 if this is less than that, 0 if both are the same, +1 if this is greater than that.
+func(this Code) Compare(that Code) int {
+	for _, e := range [3][2]int{
+		{int(this.class),   int(that.class)},
+		{int(this.subject), int(that.subject)},
+		{int(this.detail),  int(that.detail)},
+	} {
+		if e[0] < e[1] { return -1 }
+		if e[0] > e[1] { return  1 }
+	}
+	return 0
+}
+
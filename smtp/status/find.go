@@ -1,4 +1,4 @@
-// Copyright (C) 2020-2021,2024-2025 azumakuniyuki and sisimai development team, All rights reserved.
+// Copyright (C) 2020-2021,2024-2026 azumakuniyuki and sisimai development team, All rights reserved.
 // This software is distributed under The BSD 2-Clause License.
 //                _           __   _        _             
 //  ___ _ __ ___ | |_ _ __   / /__| |_ __ _| |_ _   _ ___ 
@@ -11,9 +11,22 @@ package status
 import "fmt"
 import "sort"
 import "strings"
+import "unicode/utf8"
 import "libsisimai.org/mailer-goemon/moji"
 import "libsisimai.org/mailer-goemon/rfc791"
 
+// Match is an SMTP status code found by FindAll() with its position in the given string.
+type Match struct {
+	Code      string // SMTP status code such as "5.1.1"
+	Offset    int    // Byte offset of the code in the given string
+	Context   string // Text around the code
+	Ambiguous bool   // true if the code is "X.0.0" or "4.4.7" which Select() uses only when no other code exists
+	Masked    bool   // true if the code is a part of an IPv4 address like "5.1.1.1", Select() ignores it
+}
+
+// contextsize is the number of bytes before and after the status code in Match.Context
+const contextsize = 32
+
 // Find returns a delivery status code found from the given string.
 //   Arguments:
 //     - logs (string): String including DSN; SMTP status code.
@@ -25,17 +38,81 @@ func Find(logs string, hint string) string {
 	if len(hint) < 1 { hint = " " }
 
 	eestatuses := make([]string, 0, 3)
-	esmtperror := " " + logs + "   " // Why 3 space characters? see https://github.com/sisimai/p5-sisimai/issues/574
-	lookingfor := make(map[string]string, 10)
-	indextable := make([]int, 0, 10)
 	givenclass := hint[0:1]; switch givenclass {
 		case "2", "4", "5": eestatuses = append(eestatuses, givenclass + ".")
 		default:            eestatuses = append(eestatuses, []string{"5.", "4.", "2."}...)
 	}
+	return Select(findAll(logs, eestatuses))
+}
+
+// FindAll returns every SMTP status code found from the given string in the order of appearances,
+// including ambiguous codes and codes in IPv4 addresses which Find() does not return.
+//   Arguments:
+//     - logs (string): String including DSN; SMTP status code.
+//   Returns:
+//     - ([]Match): SMTP status codes with the positions, the context, and the flags.
+func FindAll(logs string) []Match {
+	if len(logs) < 7 { return []Match{} }
+	return findAll(logs, []string{"5.", "4.", "2."})
+}
+
+// Select returns the preferred status code selected from the matches as same as Find() does: it
+// ignores masked codes, uses the last ambiguous code only at the end, and calls Prefer() in order.
+//   Arguments:
+//     - matches ([]Match): SMTP status codes returned from FindAll().
+//   Returns:
+//     - (string): Selected SMTP status code.
+func Select(matches []Match) string {
+	statuscode := make([]string, 0, 2) // List of SMTP Status Code, Keep the order of appearances
+	anotherone := ""                   // Alternative code
+	for _, e := range matches {
+		if e.Masked    { continue }
+		if e.Ambiguous { anotherone = e.Code; continue }
+		statuscode = append(statuscode, e.Code)
+	}
 
-	// Rewrite an IPv4 address in the given string(logs) with '***.***.***.***'
+	if len(anotherone) > 0 { statuscode = append(statuscode, anotherone) }
+	if len(statuscode) < 1 { return "" }
+
+	cv := ""; for j, e := range statuscode {
+		// Select one from picked status codes
+		if j == 0 { cv = e; continue }
+		cv = Prefer(cv, e, "");
+	}
+	return cv
+}
+
+// findAll finds status codes beginning with the classes in the string, and codes in IPv4 addresses.
+func findAll(logs string, eestatuses []string) []Match {
+	esmtperror := " " + logs + "   " // Why 3 space characters? see https://github.com/sisimai/p5-sisimai/issues/574
+	maskedtext := esmtperror
+
+	// Rewrite an IPv4 address in the given string(logs) with '*' characters of the same length
 	ip4address := rfc791.FindIPv4Address(esmtperror)
-	for _, e := range ip4address { esmtperror = strings.ReplaceAll(esmtperror, e, "***.***.***.***") }
+	for _, e := range ip4address { maskedtext = strings.ReplaceAll(maskedtext, e, strings.Repeat("*", len(e))) }
+
+	matches := scanCodes(maskedtext, eestatuses)
+	if maskedtext != esmtperror {
+		// Status codes in the masked IPv4 addresses like "5.1.1.1"
+		for _, e := range scanCodes(esmtperror, eestatuses) {
+			if strings.IndexByte(maskedtext[e.Offset:e.Offset + len(e.Code)], '*') < 0 { continue }
+			e.Masked = true; matches = append(matches, e)
+		}
+		sort.SliceStable(matches, func(a, b int) bool { return matches[a].Offset < matches[b].Offset })
+	}
+
+	for j := range matches {
+		// Convert the offset in esmtperror to the offset in logs, and set the text around the code
+		matches[j].Offset -= 1
+		matches[j].Context = contextOf(logs, matches[j].Offset, len(matches[j].Code))
+	}
+	return matches
+}
+
+// scanCodes returns status codes in the string, the Offset of each match is the offset in the string.
+func scanCodes(esmtperror string, eestatuses []string) []Match {
+	lookingfor := make(map[string]string, 10)
+	indextable := make([]int, 0, 10)
 	for _, e := range eestatuses {
 		// Count the number of "5.", "4.", and "2." in the error message
 		p0, p1 := 0, 0; for p0 > -1 {
@@ -46,10 +123,9 @@ func Find(logs string, hint string) string {
 			indextable = append(indextable, p0)
 		}
 	}
-	if len(lookingfor) == 0 { return "" }
+	if len(lookingfor) == 0 { return []Match{} }
 
-	statuscode := make([]string, 0, 2) // List of SMTP Status Code, Keep the order of appearances
-	anotherone := ""                   // Alternative code
+	matches    := make([]Match, 0, 2)
 	stringsize := len(esmtperror)
 	readbuffer := strings.Builder{}; readbuffer.Grow(5)
 
@@ -84,29 +160,29 @@ func Find(logs string, hint string) string {
 
 		if cv := readbuffer.String(); IsAmbiguous(cv) || cv == "4.4.7" {
 			// Find another status code except *.0.0, 4.4.7
-			anotherone = cv; continue
+			matches = append(matches, Match{Code: cv, Offset: ci, Ambiguous: true}); continue
 		}
 
 		// The 2nd digit of the detail is not a number
-		if cx[4] < 48 || cx[4] > 57 { statuscode = append(statuscode, readbuffer.String()); continue }
+		if cx[4] < 48 || cx[4] > 57 { matches = append(matches, Match{Code: readbuffer.String(), Offset: ci}); continue }
 		readbuffer.WriteByte(cx[4]) // The 2nd digit of the detail is a number
 
 		// The 3rd digit of the detail is not a number
-		if cx[5] < 48 || cx[5] > 57 { statuscode = append(statuscode, readbuffer.String()); continue }
+		if cx[5] < 48 || cx[5] > 57 { matches = append(matches, Match{Code: readbuffer.String(), Offset: ci}); continue }
 		readbuffer.WriteByte(cx[5]) // The 3rd digit of the detail is a number
 
 		if cx[6] > 47 && cx[6] < 58 { continue }
-		statuscode = append(statuscode, readbuffer.String())
+		matches = append(matches, Match{Code: readbuffer.String(), Offset: ci})
 	}
+	return matches
+}
 
-	if len(anotherone) > 0 { statuscode = append(statuscode, anotherone) }
-	if len(statuscode) < 1 { return "" }
-
-	cv := ""; for j, e := range statuscode {
-		// Select one from picked status codes
-		if j == 0 { cv = e; continue }
-		cv = Prefer(cv, e, "");
-	}
-	return cv
+// contextOf returns the text around the status code at the offset without breaking UTF-8 characters.
+func contextOf(logs string, offset, size int) string {
+	p0 := offset - contextsize;        if p0 < 0         { p0 = 0 }
+	p1 := offset + size + contextsize; if p1 > len(logs) { p1 = len(logs) }
+	for p0 > 0 && utf8.RuneStart(logs[p0]) == false         { p0-- }
+	for p1 < len(logs) && utf8.RuneStart(logs[p1]) == false { p1++ }
+	return strings.TrimSpace(logs[p0:p1])
 }
 
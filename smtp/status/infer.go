@@ -0,0 +1,197 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                _           __   _        _             
+//  ___ _ __ ___ | |_ _ __   / /__| |_ __ _| |_ _   _ ___ 
+// / __| '_ ` _ \| __| '_ \ / / __| __/ _` | __| | | / __|
+// \__ \ | | | | | |_| |_) / /\__ \ || (_| | |_| |_| \__ \
+// |___/_| |_| |_|\__| .__/_/ |___/\__\__,_|\__|\__,_|___/
+//                   |_|                                  
+
+package status
+import "math"
+import "slices"
+import "strings"
+import "libsisimai.org/mailer-goemon/smtp/command"
+
+// Inference is an SMTP status code inferred from an error message by Infer().
+type Inference struct {
+	Code       string  // Inferred SMTP status code such as "5.3.4", empty when nothing is inferred
+	Confidence float64 // Confidence of the code from 0.0 to 1.0, 1.0 when the code is written in the message
+	Phrase     string  // Phrase matched in the message
+}
+
+// inferphrases is the list of phrases in error messages for each registered status code in order of
+// the priority: more specific phrases are checked earlier, for example "sender address rejected" is
+// X.1.7 before "address rejected". The class is used when no SMTP reply code is available.
+var inferphrases = []struct {code string; class string; weight float64; phrases []string}{
+	{"X.7.27", "5", 0.9, []string{"sender address has null mx"}},
+	{"X.1.10", "5", 0.9, []string{"null mx"}},
+	{"X.1.8",  "5", 0.8, []string{"sender address rejected: domain not found", "domain of sender address", "sender domain"}},
+	{"X.1.7",  "5", 0.8, []string{
+		"sender address rejected", "invalid sender", "sender verify failed", "sender verification failed", "bad sender",
+		"sender unknown",
+	}},
+	{"X.7.25", "5", 0.9, []string{
+		"reverse dns", "no ptr record", "missing ptr record", "ptr record not found", "cannot find your hostname",
+		"cannot find your reverse hostname", "no rdns", "rdns lookup failed",
+	}},
+	{"X.7.10", "5", 0.8, []string{"must issue a starttls command first", "tls is required", "starttls is required"}},
+	{"X.7.26", "5", 0.9, []string{
+		"dmarc policy", "dmarc check failed", "dmarc verification failed", "failed dmarc", "unauthenticated email",
+		"authentication checks failed",
+	}},
+	{"X.7.23", "5", 0.9, []string{"spf check failed", "spf validation failed", "spf fail", "failed spf", "sender policy framework"}},
+	{"X.7.24", "4", 0.8, []string{"spf temperror", "spf permerror", "spf validation error"}},
+	{"X.7.20", "5", 0.8, []string{"no passing dkim", "dkim check failed", "dkim verification failed", "dkim signature", "failed dkim"}},
+	{"X.7.8",  "5", 0.8, []string{
+		"authentication credentials invalid", "authentication failed", "invalid credentials", "incorrect authentication data",
+		"認証に失敗", "authentifizierung fehlgeschlagen", "échec de l'authentification", "error de autenticación",
+		"falha na autenticação", "身份验证失败",
+	}},
+	{"X.7.0",  "5", 0.8, []string{"authentication required", "authentication is required", "must be authenticated"}},
+	{"X.2.1",  "5", 0.8, []string{
+		"mailbox disabled", "account disabled", "account has been disabled", "account is disabled", "account suspended",
+		"account has been suspended", "mailbox is inactive", "account is inactive",
+		"アカウントが無効", "アカウントが停止", "konto deaktiviert", "konto gesperrt", "postfach deaktiviert",
+		"compte désactivé", "compte suspendu", "cuenta desactivada", "cuenta suspendida", "conta desativada",
+		"conta suspensa", "account disattivato", "учетная запись отключена", "账户已停用",
+	}},
+	{"X.2.2",  "5", 0.9, []string{
+		"mailbox full", "mailbox is full", "over quota", "overquota", "quota exceeded", "exceeded storage allocation",
+		"mailbox size limit exceeded", "inbox is full", "disk quota exceeded", "exceeds quota",
+		"メールボックスがいっぱい", "メールボックスの容量", "容量オーバー", "postfach voll", "postfach ist voll",
+		"speicherplatz überschritten", "quota überschritten", "boîte aux lettres pleine", "boite aux lettres pleine",
+		"quota dépassé", "buzón lleno", "buzon lleno", "cuota excedida", "caixa postal cheia", "caixa de correio cheia",
+		"cota excedida", "casella piena", "quota superata", "ящик переполнен", "превышена квота", "邮箱已满", "信箱已滿",
+		"邮箱空间不足",
+	}},
+	{"X.3.4",  "5", 0.9, []string{
+		"message too large", "message is too large", "message too big", "message is too big", "message size exceeds",
+		"exceeds the maximum message size", "message size limit", "mail size limit", "message length exceeds",
+		"size limit exceeded", "メールサイズが大き", "サイズ制限を超", "nachricht zu groß", "nachricht ist zu groß",
+		"message trop volumineux", "message trop gros", "mensaje demasiado grande", "mensagem muito grande",
+		"messaggio troppo grande", "сообщение слишком большое", "слишком большое сообщение", "邮件过大", "邮件太大",
+	}},
+	{"X.3.1",  "4", 0.8, []string{"insufficient system storage", "disk full", "no space left on device", "system storage"}},
+	{"X.1.6",  "5", 0.8, []string{"has moved", "address has changed", "no longer in use"}},
+	{"X.1.2",  "5", 0.9, []string{
+		"host unknown", "unknown host", "host not found", "domain not found", "no such domain", "domain does not exist",
+		"unrouteable mail domain", "unknown domain", "name or service not known", "nxdomain",
+		"ドメインが存在しません", "ドメインが見つかりません", "domain existiert nicht", "unbekannte domain",
+		"domaine inconnu", "domaine inexistant", "domaine introuvable", "dominio no existe", "dominio desconocido",
+		"dominio inexistente", "domínio não existe", "domínio desconhecido", "domínio inexistente", "dominio sconosciuto",
+		"домен не существует", "домен не найден", "域名不存在",
+	}},
+	{"X.1.1",  "5", 0.9, []string{
+		"user unknown", "unknown user", "no such user", "user not found", "mailbox not found", "no such mailbox",
+		"recipient not found", "unknown recipient", "recipient unknown", "invalid recipient", "no such recipient",
+		"user does not exist", "user doesn't exist", "mailbox does not exist", "account does not exist",
+		"address does not exist", "recipient does not exist", "mailbox unavailable",
+		"存在しないユーザ", "ユーザーが存在しません", "ユーザが存在しません", "宛先不明",
+		"unbekannter empfänger", "empfänger unbekannt", "benutzer unbekannt", "unbekannter benutzer", "postfach existiert nicht",
+		"utilisateur inconnu", "destinataire inconnu", "destinataire inexistant", "adresse inexistante",
+		"usuario desconocido", "destinatario desconocido", "usuario no existe", "buzón no existe", "buzon no existe",
+		"usuário desconhecido", "usuario desconhecido", "destinatário desconhecido", "utilizador desconhecido",
+		"usuário não existe", "utente sconosciuto", "destinatario sconosciuto", "utente inesistente", "casella inesistente",
+		"onbekende gebruiker", "gebruiker onbekend", "пользователь не найден", "нет такого пользователя",
+		"неизвестный пользователь", "пользователь не существует", "用户不存在", "用戶不存在", "收件人不存在",
+	}},
+	{"X.1.3",  "5", 0.8, []string{
+		"bad address syntax", "address syntax", "invalid address", "malformed address", "ungültige adresse",
+		"adresse invalide", "dirección no válida", "dirección inválida", "endereço inválido",
+	}},
+	{"X.5.3",  "4", 0.9, []string{
+		"too many recipients", "宛先が多すぎ", "zu viele empfänger", "trop de destinataires", "demasiados destinatarios",
+		"destinatários demais", "troppi destinatari",
+	}},
+	{"X.4.7",  "4", 0.9, []string{
+		"delivery time expired", "message expired", "retry timeout exceeded", "retry time exceeded", "queue time expired",
+		"too long in queue",
+	}},
+	{"X.4.6",  "5", 0.9, []string{"mail loop", "routing loop", "loops back to myself", "too many hops", "hop count exceeded"}},
+	{"X.4.4",  "4", 0.8, []string{"unable to route", "no route to host"}},
+	{"X.4.1",  "4", 0.8, []string{"no answer from host", "connection refused"}},
+	{"X.4.2",  "4", 0.8, []string{"connection timed out", "lost connection", "connection reset"}},
+	{"X.4.5",  "4", 0.8, []string{"mail system congestion", "system congestion"}},
+	{"X.5.1",  "5", 0.8, []string{
+		"command unrecognized", "command not recognized", "unrecognized command", "bad sequence of commands",
+		"command not implemented",
+	}},
+	{"X.5.4",  "5", 0.8, []string{"invalid argument", "invalid parameter", "parameter not recognized", "unrecognized parameter"}},
+	{"X.5.2",  "5", 0.8, []string{"syntax error", "構文エラー", "syntaxfehler", "erreur de syntaxe", "error de sintaxis", "erro de sintaxe"}},
+	{"X.6.0",  "5", 0.7, []string{"message content rejected", "content rejected", "illegal attachment", "attachment not allowed"}},
+	{"X.7.1",  "5", 0.8, []string{
+		"relay access denied", "relaying denied", "relay not permitted", "unable to relay", "not permitted to relay",
+		"we do not relay", "relaying not allowed", "delivery not authorized", "not authorized to send",
+		"rejected for policy reasons", "policy violation", "rejected as spam", "detected as spam", "classified as spam",
+		"looks like spam", "spam detected", "virus found", "virus detected", "contains a virus", "malware detected",
+		"blacklisted", "blocklisted", "blocked using", "ip address is blocked", "access denied",
+		"受信拒否", "迷惑メールと判定", "zugriff verweigert", "als spam erkannt", "accès refusé", "considéré comme spam",
+		"acceso denegado", "detectado como spam", "acesso negado", "identificado como spam", "accesso negato",
+		"доступ запрещен", "распознано как спам", "被判定为垃圾邮件", "拒收",
+	}},
+}
+
+// Infer returns an SMTP status code inferred from the phrases in the error message when the message
+// has no status code. The class of the code is decided by the SMTP reply code, and the code is one
+// of the registered codes in the IANA registry.
+//   Arguments:
+//     - text      (string): Error message such as "552 message too large".
+//     - replycode (string): SMTP reply code like "552", found from the text when it is empty.
+//     - comm      (string): SMTP command like "MAIL", found from the text when it is empty.
+//   Returns:
+//     - (*Inference): Inferred status code, the confidence, and the phrase matched.
+func Infer(text, replycode, comm string) *Inference {
+	if cv := Find(text, replycode); cv != "" { return &Inference{Code: cv, Confidence: 1.0} }
+
+	esmtperror := strings.Join(strings.Fields(strings.ToLower(text)), " "); if esmtperror == "" { return &Inference{} }
+	certainty  := 1.0 // Certainty of the class of the status code
+	if len(replycode) == 0 { replycode = replyCodeOf(text); certainty = 0.95 }
+	if len(replycode)  > 0 && replycode[0] == '2' { return &Inference{} } // Successful reply, there is no error
+	if len(replycode)  > 0 && replycode[0] != '4' && replycode[0] != '5' { replycode = "" }
+	if len(replycode) == 0 { certainty = 0.8 }
+	if len(comm)      == 0 { comm = command.Find(text) }
+
+	for _, e := range inferphrases {
+		phrase := ""; for _, f := range e.phrases { if strings.Contains(esmtperror, f) { phrase = f; break } }
+		if phrase == "" { continue }
+
+		code := e.code; if comm == command.CeMAIL {
+			// The address in the MAIL command is the address of the sender
+			switch code {
+				case "X.1.1", "X.1.3": code = "X.1.7"
+				case "X.1.2":          code = "X.1.8"
+			}
+		}
+		class := e.class; if len(replycode) > 0 { class = replycode[0:1] }
+		entry, ok := Lookup(class + code[1:]); if ok == false { continue }
+
+		confidence := e.weight * certainty
+		if slices.Contains(entry.ReplyCodes, replycode) || slices.Contains(entry.ReplyCodes, class + "XX") {
+			// The reply code is one of the associated basic status codes in the registry
+			confidence += 0.05
+		}
+		return &Inference{Code: entry.Code, Confidence: math.Round(confidence * 100) / 100, Phrase: phrase}
+	}
+	return &Inference{}
+}
+
+// replyCodeOf returns the first SMTP reply code like "550" at the beginning of the text or a line, or
+// right after ":" or ";" such as "smtp; 550 ...". A number in the middle of a sentence like "250 MB"
+// is not a reply code. Find() of smtp/reply is not available in this package because the test of
+// smtp/reply imports this package.
+func replyCodeOf(text string) string {
+	isdigit := func(c byte) bool { return c > 47 && c < 58 }
+	for j := 0; j + 3 <= len(text); j++ {
+		if text[j] != '2' && text[j] != '4' && text[j] != '5'     { continue }
+		if isdigit(text[j + 1]) == false || isdigit(text[j + 2]) == false { continue }
+		if j > 0 && text[j - 1] > 45 && text[j - 1] < 58             { continue } // '.' => '9'
+		if j + 3 < len(text) && text[j + 3] > 45 && text[j + 3] < 58 { continue } // '.' => '9'
+
+		former := strings.TrimRight(text[:j], " \t")
+		if former != "" && strings.ContainsAny(former[len(former) - 1:], "\n:;") == false { continue }
+		return text[j:j + 3]
+	}
+	return ""
+}
+
@@ -12,6 +12,8 @@
 package status
 import "strings"
 
+// The enumerated status codes listed below are available at runtime with Lookup() and Registry(),
+// and the table of them in table.go is generated by mkregistry.go from the CSV file of IANA.
 /* http://www.iana.org/assignments/smtp-enhanced-status-codes/smtp-enhanced-status-codes.xhtml
 ---------------------------------------------------------------------------------------------------
  [Class Sub-Codes]
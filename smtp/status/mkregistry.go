@@ -0,0 +1,161 @@
+//go:build ignore
+
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                _           __   _        _             
+//  ___ _ __ ___ | |_ _ __   / /__| |_ __ _| |_ _   _ ___ 
+// / __| '_ ` _ \| __| '_ \ / / __| __/ _` | __| | | / __|
+// \__ \ | | | | | |_| |_) / /\__ \ || (_| | |_| |_| \__ \
+// |___/_| |_| |_|\__| .__/_/ |___/\__\__,_|\__|\__,_|___/
+//                   |_|                                  
+
+// mkregistry.go rebuilds table.go from the CSV file of the IANA registry "Enumerated Status Codes",
+// smtp-enhanced-status-codes-3.csv downloaded from the URL below:
+//   https://www.iana.org/assignments/smtp-enhanced-status-codes/smtp-enhanced-status-codes-3.csv
+//
+//   $ go run mkregistry.go -o table.go /path/to/smtp-enhanced-status-codes-3.csv
+package main
+import "bytes"
+import "encoding/csv"
+import "flag"
+import "fmt"
+import "go/format"
+import "os"
+import "path/filepath"
+import "regexp"
+import "sort"
+import "strconv"
+import "strings"
+
+type entry struct {
+	code        string
+	title       string
+	description string
+	references  []string
+	replycodes  []string
+}
+
+var columnname = []string{"Code", "Sample Text", "Associated basic status code", "Description", "Reference"}
+var codepattern = regexp.MustCompile(`^X\.[0-7]\.[0-9]{1,3}$`)
+var replypattern = regexp.MustCompile(`^[245][0-9X][0-9X]$`)
+var referpattern = regexp.MustCompile(`\[([^\]]+)\]`)
+
+func main() {
+	output := flag.String("o", "table.go", "Path to the generated Go source file")
+	flag.Parse(); if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: go run mkregistry.go [-o table.go] smtp-enhanced-status-codes-3.csv")
+		os.Exit(2)
+	}
+
+	entries, nyaan := readRegistry(flag.Arg(0)); if nyaan != nil {
+		fmt.Fprintln(os.Stderr, nyaan); os.Exit(1)
+	}
+	source, nyaan := generateTable(entries, flag.Arg(0)); if nyaan != nil {
+		fmt.Fprintln(os.Stderr, nyaan); os.Exit(1)
+	}
+	if nyaan := os.WriteFile(*output, source, 0644); nyaan != nil {
+		fmt.Fprintln(os.Stderr, nyaan); os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d status codes\n", *output, len(entries))
+}
+
+// readRegistry reads the CSV file and returns the entries sorted by the subject and the detail.
+func readRegistry(path string) ([]entry, error) {
+	fh, nyaan := os.Open(path); if nyaan != nil { return nil, nyaan }
+	defer fh.Close()
+
+	reader := csv.NewReader(fh); reader.FieldsPerRecord = -1
+	records, nyaan := reader.ReadAll(); if nyaan != nil { return nil, nyaan }
+	if len(records) < 2 { return nil, fmt.Errorf("%s: no status code", path) }
+
+	columns := make([]int, len(columnname))
+	for j, e := range columnname {
+		// Find the position of each column from the header row
+		columns[j] = -1; for k, f := range records[0] {
+			if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(f, "\uFEFF")), e) { columns[j] = k; break }
+		}
+		if columns[j] < 0 { return nil, fmt.Errorf("%s: no %q column", path, e) }
+	}
+
+	entries := make([]entry, 0, len(records))
+	for _, e := range records[1:] {
+		field := make([]string, len(columns))
+		for j, k := range columns { if k < len(e) { field[j] = strings.Join(strings.Fields(e[k]), " ") } }
+		if codepattern.MatchString(field[0]) == false { continue }
+
+		entries = append(entries, entry{
+			code:        field[0],
+			title:       field[1],
+			description: field[3],
+			references:  splitReferences(field[4]),
+			replycodes:  splitReplyCodes(field[2]),
+		})
+	}
+	if len(entries) == 0 { return nil, fmt.Errorf("%s: no status code", path) }
+
+	sort.SliceStable(entries, func(a, b int) bool {
+		sa, da := splitCode(entries[a].code)
+		sb, db := splitCode(entries[b].code)
+		if sa != sb { return sa < sb }
+		return da < db
+	})
+	return entries, nil
+}
+
+// splitCode returns the subject and the detail of the code like "X.7.26" as integers.
+func splitCode(code string) (int, int) {
+	token := strings.Split(code, ".")
+	subject, _ := strconv.Atoi(token[1])
+	detail,  _ := strconv.Atoi(token[2])
+	return subject, detail
+}
+
+// splitReferences returns the list of references from "[RFC7372][RFC6476]".
+func splitReferences(text string) []string {
+	references := []string{}
+	for _, e := range referpattern.FindAllStringSubmatch(text, -1) {
+		references = append(references, strings.ReplaceAll(e[1], " ", ""))
+	}
+	if len(references) == 0 && text != "" { references = append(references, text) }
+	return references
+}
+
+// splitReplyCodes returns the list of reply codes from "450, 550, 4XX" or "501-503".
+func splitReplyCodes(text string) []string {
+	replycodes := []string{}
+	duplicated := map[string]bool{}
+	for _, e := range strings.FieldsFunc(strings.ToUpper(text), func(r rune) bool { return r == ',' || r == ' ' }) {
+		// Expand "501-503" to "501", "502", "503"
+		candidates := []string{e}
+		if p := strings.IndexByte(e, '-'); p > 0 {
+			v0, nyaan0 := strconv.Atoi(e[:p])
+			v1, nyaan1 := strconv.Atoi(e[p + 1:])
+			if nyaan0 == nil && nyaan1 == nil && v0 <= v1 && v1 - v0 < 100 {
+				candidates = candidates[:0]
+				for j := v0; j <= v1; j++ { candidates = append(candidates, strconv.Itoa(j)) }
+			}
+		}
+		for _, f := range candidates {
+			if replypattern.MatchString(f) == false || duplicated[f] { continue }
+			duplicated[f] = true
+			replycodes = append(replycodes, f)
+		}
+	}
+	return replycodes
+}
+
+// generateTable returns the Go source code of table.go.
+func generateTable(entries []entry, path string) ([]byte, error) {
+	source := bytes.Buffer{}
+	source.WriteString("// Code generated by mkregistry.go from " + filepath.Base(path) + "; DO NOT EDIT.\n\n")
+	source.WriteString("package status\n\n")
+	source.WriteString("// registry is the table of the IANA registry \"Enumerated Status Codes\"\n")
+	source.WriteString("var registry = []Entry{\n")
+	for _, e := range entries {
+		fmt.Fprintf(&source, "\t{\n\t\tCode: %q,\n\t\tTitle: %q,\n\t\tDescription: %q,\n", e.code, e.title, e.description)
+		fmt.Fprintf(&source, "\t\tReferences: %#v,\n\t\tReplyCodes: %#v,\n\t},\n", e.references, e.replycodes)
+	}
+	source.WriteString("}\n")
+	return format.Source(source.Bytes())
+}
+
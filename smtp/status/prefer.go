@@ -1,4 +1,4 @@
-// Copyright (C) 2024-2025 azumakuniyuki and sisimai development team, All rights reserved.
+// Copyright (C) 2024-2026 azumakuniyuki and sisimai development team, All rights reserved.
 // This software is distributed under The BSD 2-Clause License.
 //                _           __   _        _             
 //  ___ _ __ ___ | |_ _ __   / /__| |_ __ _| |_ _   _ ___ 
@@ -10,6 +10,101 @@
 package status
 import "strings"
 
+// PreferRule is a rule to select one of two status codes. Choose returns the preferred code and true
+// when the rule decides, or false to pass the decision to the next rule.
+type PreferRule struct {
+	Name        string // Name of the rule such as "status-4.4.7"
+	Description string // What the rule does
+	Choose      func(statuscode, codeinmesg, esmtpreply string) (string, bool)
+}
+
+// Decision is the result of Preference.Decide() with the trace of the rules.
+type Decision struct {
+	Code  string   // Preferred status code
+	Rule  string   // Name of the rule which decided the code, "default" when no rule decided
+	Trace []string // Names of the rules checked in order, the last one is the rule which decided
+}
+
+// Preference selects one of two status codes with the ordered rules, the first rule decided wins.
+type Preference struct {
+	Rules []PreferRule
+}
+
+// defaultPreference is used by Prefer() and Decide()
+var defaultPreference = NewPreference(nil)
+
+// preferrules is the default rules of Prefer() in order of the priority.
+var preferrules = []PreferRule{
+	{"empty-status", "Use the code in the message when the Status: field is empty",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) { return codeinmesg, statuscode == "" }},
+	{"empty-message", "Use the Status: field when no code is in the message",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) { return statuscode, codeinmesg == "" }},
+	{"short-status", "Use the code in the message when the Status: field is too short like \"5.7\"",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) { return codeinmesg, len(statuscode) < 5 }},
+	{"short-message", "Use the Status: field when the code in the message is too short like \"5.7\"",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) { return statuscode, len(codeinmesg) < 5 }},
+	{"reply-class", "Use the code which begins with the same class as the SMTP reply code",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) {
+			if statuscode == "" || codeinmesg == "" { return "", false }
+			if esmtpreply == "" { esmtpreply = "0" }
+			if statuscode[0] == codeinmesg[0] { return "", false }
+			if esmtpreply[0] == statuscode[0] { return statuscode, true }
+			if esmtpreply[0] == codeinmesg[0] { return codeinmesg, true }
+			return "", false
+		}},
+	{"same-code", "Both codes are the same",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) { return statuscode, statuscode == codeinmesg }},
+	{"status-x.0.0", "The Status: field is \"X.0.0\", use the code in the message unless it is \"X.0.0\"",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) {
+			if strings.Index(statuscode, ".0.0") < 1 { return "", false }
+			if strings.Index(codeinmesg, ".0.0") < 0 { return codeinmesg, true }
+			return statuscode, true
+		}},
+	{"status-x.y.0", "The Status: field is \"X.Y.0\" or \"X.0.Z\", use the code in the message without \".0\"",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) {
+			return codeinmesg, strings.Index(statuscode, ".0") > 0 && strings.Index(codeinmesg, ".0") < 0
+		}},
+	{"message-x.0.0", "The code in the message is \"X.0.0\", use the Status: field",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) { return statuscode, strings.Index(codeinmesg, ".0.0") > 0 }},
+	{"status-4.4.7", "\"4.4.7\" is an ambiguous code, use the code in the message",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) { return codeinmesg, statuscode == "4.4.7" }},
+	{"status-4.7.0", "\"4.7.0\" indicates \"too many errors\", use the code in the message",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) { return codeinmesg, statuscode == "4.7.0" }},
+	{"status-5.3.z", "\"5.3.Z\" is a system error, use the code in the message",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) { return codeinmesg, strings.Index(statuscode, "5.3.") == 0 }},
+	{"status-x.5.z", "\"X.5.1\", \"X.5.2\", \"X.5.4\", and \"X.5.5\" are protocol errors, use the code in the message",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) {
+			for _, e := range []string{".5.1", ".5.2", ".5.4", ".5.5"} { if strings.Index(statuscode, e) == 1 { return codeinmesg, true } }
+			return "", false
+		}},
+	{"status-5.1.1", "\"5.1.1\" is a code of UserUnknown, use it unless the code in the message is more specific",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) {
+			if statuscode != "5.1.1" { return "", false }
+			if strings.Index(codeinmesg, ".0") > 0 || strings.Index(codeinmesg, "5.5.") == 0 { return statuscode, true }
+			return codeinmesg, true
+		}},
+	{"status-5.1.3", "Use \"5.7.Z\" in the message rather than \"5.1.3\"",
+		func(statuscode, codeinmesg, esmtpreply string) (string, bool) {
+			return codeinmesg, statuscode == "5.1.3" && strings.Index(codeinmesg, "5.7.") == 0
+		}},
+}
+
+// PreferRules returns the copy of the default rules of Prefer(). Add, remove or reorder the rules and
+// pass them to NewPreference() to customize the preference.
+func PreferRules() []PreferRule {
+	return append([]PreferRule{}, preferrules...)
+}
+
+// NewPreference returns a Preference with the rules, the default rules are used when rules is nil.
+//   Arguments:
+//     - rules ([]PreferRule): Ordered rules, nil means the default rules.
+//   Returns:
+//     - (*Preference): Preference with the rules.
+func NewPreference(rules []PreferRule) *Preference {
+	if rules == nil { rules = PreferRules() }
+	return &Preference{Rules: rules}
+}
+
 // Prefer returns the preferred value selected from the arguments.
 //   Arguments:
 //     - argv0 (string): Value of Status: field.
@@ -18,53 +113,48 @@ import "strings"
 //   Returns:
 //     - (string): Preferred value.
 func Prefer(argv0, argv1, argv2 string) string {
-	if argv0 == "" { return argv1 }
-	if argv1 == "" { return argv0 }
-
-	statuscode := argv0; if len(statuscode) < 5 { return argv1     }
-	codeinmesg := argv1; if len(codeinmesg) < 5 { return argv0     }
-	esmtpreply := argv2; if len(esmtpreply) < 1 { esmtpreply = "0" }
-
-	if the1stchar := [3]byte{
-		[]byte(statuscode[0:1])[0], // argv0: The "Status:" field
-		[]byte(codeinmesg[0:1])[0], // argv1: The delivery status value in the error message
-		[]byte(esmtpreply[0:1])[0], // argv2: SMTP Reply code
-	}; the1stchar[2] > 0 && the1stchar[0] != the1stchar[1] {
-		// There is the 3rd argument (an SMTP Reply Code)
-		// Returns the value of argv0 or argv1 which begins with the 1st character of argv2
-		if the1stchar[2] == the1stchar[0] { return statuscode }
-		if the1stchar[2] == the1stchar[1] { return codeinmesg }
-	}
-	if statuscode == codeinmesg { return statuscode }
+	cv, _ := defaultPreference.decide(argv0, argv1, argv2, nil)
+	return cv
+}
 
-	// Find and check "X.Y.0" and "X.0.0" in the 1st and 2nd argument
-	zeroindex1 := [2]int{strings.Index(statuscode, ".0"),   strings.Index(codeinmesg, ".0")}
-	zeroindex2 := [2]int{strings.Index(statuscode, ".0.0"), strings.Index(codeinmesg, ".0.0")}
+// Decide is Prefer() with the trace of the default rules.
+//   Arguments:
+//     - argv0 (string): Value of Status: field.
+//     - argv1 (string): SMTP status code value picked from the error message.
+//     - argv2 (string): Value of the SMTP reply code.
+//   Returns:
+//     - (*Decision): Preferred value and the rule which decided it.
+func Decide(argv0, argv1, argv2 string) *Decision {
+	return defaultPreference.Decide(argv0, argv1, argv2)
+}
 
-	if zeroindex2[0] > 0 {
-		// The "Status:" field is "X.0.0"
-		if zeroindex2[1] < 0 { return codeinmesg }
-		return statuscode
-	}
-	if zeroindex1[0] > 0 && zeroindex1[1] < 0 { return codeinmesg } // The "Status:" field is "X.Y.0" or "X.0.Z"
-	if zeroindex2[1] > 0                      { return statuscode } // The SMTP status code is "X.0.0"
-	if statuscode == "4.4.7"                  { return codeinmesg } // "4.4.7" is an ambigous code
-	if statuscode == "4.7.0"                  { return codeinmesg } // "4.7.0" indicates "too many errors"
-	if strings.Index(statuscode, "5.3.") == 0 { return codeinmesg } // "5.3.Z" is a system error
-	if strings.Index(statuscode, ".5.1") == 1 { return codeinmesg } // "X.5.1" indicates an invalid command
-	if strings.Index(statuscode, ".5.2") == 1 { return codeinmesg } // "X.5.2" indicates a syntax error
-	if strings.Index(statuscode, ".5.4") == 1 { return codeinmesg } // "X.5.4" indicates an invalid command argument
-	if strings.Index(statuscode, ".5.5") == 1 { return codeinmesg } // "X.5.5" indicates a wrong protocol version
+// Prefer returns the preferred value selected from the arguments with the rules.
+func(this *Preference) Prefer(argv0, argv1, argv2 string) string {
+	cv, _ := this.decide(argv0, argv1, argv2, nil)
+	return cv
+}
 
-	if statuscode == "5.1.1" {
-		// "5.1.1" is a code of UserUnknown
-		if zeroindex1[1] > 0 || strings.Index(codeinmesg, "5.5.") == 0 { return statuscode }
-		return codeinmesg
+// Decide returns the preferred value selected from the arguments with the rules and the trace.
+//   Arguments:
+//     - argv0 (string): Value of Status: field.
+//     - argv1 (string): SMTP status code value picked from the error message.
+//     - argv2 (string): Value of the SMTP reply code.
+//   Returns:
+//     - (*Decision): Preferred value, the rule which decided it, and the rules checked.
+func(this *Preference) Decide(argv0, argv1, argv2 string) *Decision {
+	trace := make([]string, 0, len(this.Rules))
+	cv, rule := this.decide(argv0, argv1, argv2, &trace)
+	return &Decision{Code: cv, Rule: rule, Trace: trace}
+}
 
-	} else if statuscode == "5.1.3" {
-		// "5.1.3"
-		if strings.Index(codeinmesg, "5.7.") == 0 { return codeinmesg }
+// decide checks the rules in order and returns the preferred value and the name of the rule.
+func(this *Preference) decide(argv0, argv1, argv2 string, trace *[]string) (string, string) {
+	for _, e := range this.Rules {
+		if e.Choose == nil { continue }
+		if trace != nil    { *trace = append(*trace, e.Name) }
+		if cv, ok := e.Choose(argv0, argv1, argv2); ok { return cv, e.Name }
 	}
-	return statuscode
+	if trace != nil { *trace = append(*trace, "default") }
+	return argv0, "default"
 }
 
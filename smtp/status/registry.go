@@ -0,0 +1,68 @@
+// Copyright (C) 2026 azumakuniyuki and sisimai development team, All rights reserved.
+// This software is distributed under The BSD 2-Clause License.
+//                _           __   _        _             
+//  ___ _ __ ___ | |_ _ __   / /__| |_ __ _| |_ _   _ ___ 
+// / __| '_ ` _ \| __| '_ \ / / __| __/ _` | __| | | / __|
+// \__ \ | | | | | |_| |_) / /\__ \ || (_| | |_| |_| \__ \
+// |___/_| |_| |_|\__| .__/_/ |___/\__\__,_|\__|\__,_|___/
+//                   |_|                                  
+
+package status
+import "strings"
+
+//go:generate go run mkregistry.go -o table.go smtp-enhanced-status-codes-3.csv
+
+// Entry is a registered SMTP enhanced status code in the IANA registry.
+type Entry struct {
+	Code        string   // Status code like "X.7.26" in the registry, or the code given to Lookup() like "5.7.26"
+	Title       string   // Sample text such as "Multiple authentication checks failed"
+	Description string   // Description of the status code
+	References  []string // References such as "RFC3463", "RFC7372"
+	ReplyCodes  []string // Associated basic status codes (SMTP reply codes) such as "550", "5XX"
+}
+
+// registryindex is the index of each entry in the registry table: "7.26" => 72
+var registryindex = func() map[string]int {
+	table := make(map[string]int, len(registry))
+	for j, e := range registry { table[strings.TrimPrefix(e.Code, "X.")] = j }
+	return table
+}()
+
+// Lookup returns the registered entry of the SMTP status code. The Code of the entry is the given code,
+// and the ReplyCodes of the entry are limited to the reply codes of the same class as the given code.
+//   Arguments:
+//     - code (string): SMTP status code such as "5.7.26", or "X.7.26".
+//   Returns:
+//     - (Entry): Registered entry of the status code.
+//     - (bool):  false if the status code is not registered.
+func Lookup(code string) (Entry, bool) {
+	class := ""; if strings.HasPrefix(code, "X.") == false {
+		// "5.7.26" => "X.7.26"
+		_, nyaan := Parse(code); if nyaan != nil { return Entry{}, false }
+		class = code[0:1]
+		code  = "X." + code[2:]
+	}
+	j, ok := registryindex[code[2:]]; if ok == false { return Entry{}, false }
+
+	entry := copyEntry(registry[j]); if class == "" { return entry, true }
+	entry.Code = class + code[1:]
+	replycodes := make([]string, 0, len(entry.ReplyCodes))
+	for _, e := range entry.ReplyCodes { if e[0:1] == class { replycodes = append(replycodes, e) } }
+	entry.ReplyCodes = replycodes
+	return entry, true
+}
+
+// Registry returns the copy of all the entries in the registry in the order of the status codes.
+func Registry() []Entry {
+	entries := make([]Entry, 0, len(registry))
+	for _, e := range registry { entries = append(entries, copyEntry(e)) }
+	return entries
+}
+
+// copyEntry returns the copy of the entry not to modify the registry table through the slices.
+func copyEntry(entry Entry) Entry {
+	entry.References = append([]string{}, entry.References...)
+	entry.ReplyCodes = append([]string{}, entry.ReplyCodes...)
+	return entry
+}
+
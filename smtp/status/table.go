@@ -0,0 +1,546 @@
+// Code generated by mkregistry.go from smtp-enhanced-status-codes-3.csv; DO NOT EDIT.
+
+package status
+
+// registry is the table of the IANA registry "Enumerated Status Codes"
+var registry = []Entry{
+	{
+		Code:        "X.0.0",
+		Title:       "Other undefined Status",
+		Description: "Other undefined status is the only undefined error code. It should be used for all errors for which only the class of the error is known.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.1.0",
+		Title:       "Other address status",
+		Description: "Something about the address specified in the message caused this DSN.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.1.1",
+		Title:       "Bad destination mailbox address",
+		Description: "The mailbox specified in the address does not exist. For Internet mail names, this means the address portion to the the left of the \"@\" sign is invalid. This code is only useful for permanent failures.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"451", "550"},
+	},
+	{
+		Code:        "X.1.2",
+		Title:       "Bad destination system address",
+		Description: "The destination system specified in the address does not exist or is incapable of accepting mail. For Internet mail names, this means the address portion to the right of the \"@\" is invalid for mail. This code is only useful for permanent failures.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.1.3",
+		Title:       "Bad destination mailbox address syntax",
+		Description: "The destination address was syntactically invalid. This can apply to any field in the address. This code is only useful for permanent failures.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"501"},
+	},
+	{
+		Code:        "X.1.4",
+		Title:       "Destination mailbox address ambiguous",
+		Description: "The mailbox address as specified matches one or more recipients on the destination system. This may result if a heuristic address mapping algorithm is used to map the specified address to a local mailbox name.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.1.5",
+		Title:       "Destination address valid",
+		Description: "This mailbox address as specified was valid. This status code should be used for positive delivery reports.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"250"},
+	},
+	{
+		Code:        "X.1.6",
+		Title:       "Destination mailbox has moved, No forwarding address",
+		Description: "The mailbox address provided was at one time valid, but mail is no longer being accepted for that address. This code is only useful for permanent failures.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.1.7",
+		Title:       "Bad sender's mailbox address syntax",
+		Description: "The sender's address was syntactically invalid. This can apply to any field in the address.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.1.8",
+		Title:       "Bad sender's system address",
+		Description: "The sender's system specified in the address does not exist or is incapable of accepting return mail. For domain names, this means the address portion to the right of the \"@\" is invalid for mail.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"451", "501"},
+	},
+	{
+		Code:        "X.1.9",
+		Title:       "Message relayed to non-compliant mailer",
+		Description: "The mailbox address specified was valid, but the message has been relayed to a system that does not speak this protocol; no further information can be provided.",
+		References:  []string{"RFC5248", "RFC3886"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.1.10",
+		Title:       "Recipient address has null MX",
+		Description: "This status code is returned when the associated address is marked as invalid using a null MX.",
+		References:  []string{"RFC7505"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.2.0",
+		Title:       "Other or undefined mailbox status",
+		Description: "The mailbox exists, but something about the destination mailbox has caused the sending of this DSN.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.2.1",
+		Title:       "Mailbox disabled, not accepting messages",
+		Description: "The mailbox exists, but is not accepting messages. This may be a permanent error if the mailbox will never be re-enabled or a transient error if the mailbox is only temporarily disabled.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.2.2",
+		Title:       "Mailbox full",
+		Description: "The mailbox is full because the user has exceeded a per-mailbox administrative quota or physical capacity. The general semantics implies that the recipient can delete messages to make more space available. This code should be used as a persistent transient failure.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"552"},
+	},
+	{
+		Code:        "X.2.3",
+		Title:       "Message length exceeds administrative limit",
+		Description: "A per-mailbox administrative message length limit has been exceeded. This status code should be used when the per-mailbox message length limit is less than the general system limit. This code should be used as a permanent failure.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"552"},
+	},
+	{
+		Code:        "X.2.4",
+		Title:       "Mailing list expansion problem",
+		Description: "The mailbox is a mailing list address and the mailing list was unable to be expanded. This code may represent a permanent failure or a persistent transient failure.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"450", "452"},
+	},
+	{
+		Code:        "X.3.0",
+		Title:       "Other or undefined mail system status",
+		Description: "The destination system exists and normally accepts mail, but something about the system has caused the generation of this DSN.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"221", "250", "421", "451", "550", "554"},
+	},
+	{
+		Code:        "X.3.1",
+		Title:       "Mail system full",
+		Description: "Mail system storage has been exceeded. The general semantics imply that the individual recipient may not be able to delete material to make room for additional messages. This is useful only as a persistent transient error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"452"},
+	},
+	{
+		Code:        "X.3.2",
+		Title:       "System not accepting network messages",
+		Description: "The host on which the mailbox is resident is not accepting messages. Examples of such conditions include an imminent shutdown, excessive load, or system maintenance. This is useful for both permanent and persistent transient errors.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"453", "521"},
+	},
+	{
+		Code:        "X.3.3",
+		Title:       "System not capable of selected features",
+		Description: "Selected features specified for the message are not supported by the destination system. This can occur in gateways when features from one domain cannot be mapped onto the supported feature in another.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.3.4",
+		Title:       "Message too big for system",
+		Description: "The message is larger than per-message size limit. This limit may either be for physical or administrative reasons. This is useful only as a permanent error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"552", "554"},
+	},
+	{
+		Code:        "X.3.5",
+		Title:       "System incorrectly configured",
+		Description: "The system is not configured in a manner that will permit it to accept this message.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.4.0",
+		Title:       "Other or undefined network or routing status",
+		Description: "Something went wrong with the networking, but it is not clear what the problem is, or the problem cannot be well expressed with any of the other provided detail codes.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.4.1",
+		Title:       "No answer from host",
+		Description: "The outbound connection attempt was not answered, because either the remote system was busy, or was unable to take a call. This is useful only as a persistent transient error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"451"},
+	},
+	{
+		Code:        "X.4.2",
+		Title:       "Bad connection",
+		Description: "The outbound connection was established, but was unable to complete the message transaction, either because of time-out, or inadequate connection quality. This is useful only as a persistent transient error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"421"},
+	},
+	{
+		Code:        "X.4.3",
+		Title:       "Directory server failure",
+		Description: "The network system was unable to forward the message, because a directory server was unavailable. This is useful only as a persistent transient error. The inability to connect to an Internet DNS server is one example of the directory server failure error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"451", "550"},
+	},
+	{
+		Code:        "X.4.4",
+		Title:       "Unable to route",
+		Description: "The mail system was unable to determine the next hop for the message because the necessary routing information was unavailable from the directory server. This is useful for both permanent and persistent transient errors. A DNS lookup returning only an SOA (Start of Administration) record for a domain name is one example of the unable to route error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.4.5",
+		Title:       "Mail system congestion",
+		Description: "The mail system was unable to deliver the message because the mail system was congested. This is useful only as a persistent transient error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"451"},
+	},
+	{
+		Code:        "X.4.6",
+		Title:       "Routing loop detected",
+		Description: "A routing loop caused the message to be forwarded too many times, either because of incorrect routing tables or a user-forwarding loop. This is useful only as a persistent transient error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.4.7",
+		Title:       "Delivery time expired",
+		Description: "The message was considered too old by the rejecting system, either because it remained on that host too long or because the time-to-live value specified by the sender of the message was exceeded. If possible, the code for the actual problem found when delivery was attempted should be returned rather than this code.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.5.0",
+		Title:       "Other or undefined protocol status",
+		Description: "Something was wrong with the protocol necessary to deliver the message to the next hop and the problem cannot be well expressed with any of the other provided detail codes.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"220", "250", "251", "252", "253", "451", "452", "454", "458", "459", "554", "501", "502", "503"},
+	},
+	{
+		Code:        "X.5.1",
+		Title:       "Invalid command",
+		Description: "A mail transaction protocol command was issued which was either out of sequence or unsupported. This is useful only as a permanent error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"430", "500", "501", "503", "530", "550", "554", "555"},
+	},
+	{
+		Code:        "X.5.2",
+		Title:       "Syntax error",
+		Description: "A mail transaction protocol command was issued which could not be interpreted, either because the syntax was wrong or the command is unrecognized. This is useful only as a permanent error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"500", "501", "502", "550", "555"},
+	},
+	{
+		Code:        "X.5.3",
+		Title:       "Too many recipients",
+		Description: "More recipients were specified for the message than could have been delivered by the protocol. This error should normally result in the segmentation of the message into two, the remainder of the recipients to be delivered on a subsequent delivery attempt. It is included in this list in the event that such segmentation is not possible.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"451"},
+	},
+	{
+		Code:        "X.5.4",
+		Title:       "Invalid command arguments",
+		Description: "A valid mail transaction protocol command was issued with invalid arguments, either because the arguments were out of range or represented unrecognized features. This is useful only as a permanent error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"451", "501", "502", "503", "504", "550", "555"},
+	},
+	{
+		Code:        "X.5.5",
+		Title:       "Wrong protocol version",
+		Description: "A protocol version mis-match existed which could not be automatically resolved by the communicating parties.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.5.6",
+		Title:       "Authentication Exchange line is too long",
+		Description: "This enhanced status code SHOULD be returned when the server fails the AUTH command due to the client sending a [BASE64] response which is longer than the maximum buffer size available for the currently selected SASL mechanism. This is useful for both permanent and persistent transient errors.",
+		References:  []string{"RFC4954"},
+		ReplyCodes:  []string{"550"},
+	},
+	{
+		Code:        "X.6.0",
+		Title:       "Other or undefined media error",
+		Description: "Something about the content of a message caused it to be considered undeliverable and the problem cannot be well expressed with any of the other provided detail codes.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.6.1",
+		Title:       "Media not supported",
+		Description: "The media of the message is not supported by either the delivery protocol or the next system in the forwarding path. This is useful only as a permanent error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.6.2",
+		Title:       "Conversion required and prohibited",
+		Description: "The content of the message must be converted before it can be delivered and such conversion is not permitted. Such prohibitions may be the expression of the sender in the message itself or the policy of the sending host.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.6.3",
+		Title:       "Conversion required but not supported",
+		Description: "The message content must be converted in order to be forwarded but such conversion is not possible or is not practical by a host in the forwarding path. This condition may result when an ESMTP gateway supports 8bit transport but is not able to downgrade the message to 7 bit as required for the next hop.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"554"},
+	},
+	{
+		Code:        "X.6.4",
+		Title:       "Conversion with loss performed",
+		Description: "This is a warning sent to the sender when message delivery was successfully but when the delivery required a conversion in which some data was lost. This may also be a permanent error if the sender has indicated that conversion with loss is prohibited for the message.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"250"},
+	},
+	{
+		Code:        "X.6.5",
+		Title:       "Conversion Failed",
+		Description: "A conversion was required but was unsuccessful. This may be useful as a permanent or persistent temporary notification.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.6.6",
+		Title:       "Message content not available",
+		Description: "The message content could not be fetched from a remote system. This may be useful as a permanent or persistent temporary notification.",
+		References:  []string{"RFC4468"},
+		ReplyCodes:  []string{"554"},
+	},
+	{
+		Code:        "X.6.7",
+		Title:       "The ALT-ADDRESS is required but not specified",
+		Description: "This indicates the reception of a MAIL or RCPT command that non-ASCII addresses are not permitted",
+		References:  []string{"RFC6531"},
+		ReplyCodes:  []string{"553", "550"},
+	},
+	{
+		Code:        "X.6.8",
+		Title:       "UTF-8 string reply is required, but not permitted by the client",
+		Description: "This indicates that a reply containing a UTF-8 string is required to show the mailbox name, but that form of response is not permitted by the SMTP client.",
+		References:  []string{"RFC6531"},
+		ReplyCodes:  []string{"252", "553", "550"},
+	},
+	{
+		Code:        "X.6.9",
+		Title:       "UTF8SMTP downgrade failed",
+		Description: "This indicates that transaction failed after the final \".\" of the DATA command.",
+		References:  []string{"RFC6531"},
+		ReplyCodes:  []string{"550"},
+	},
+	{
+		Code:        "X.6.10",
+		Title:       "UTF-8 string reply is required, but not permitted by the client",
+		Description: "This is a duplicate of X.6.8 and is thus deprecated.",
+		References:  []string{"RFC6531"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.7.0",
+		Title:       "Other or undefined security status",
+		Description: "Something related to security caused the message to be returned, and the problem cannot be well expressed with any of the other provided detail codes. This status code may also be used when the condition cannot be further described because of security policies in force.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"220", "235", "450", "454", "500", "501", "503", "504", "530", "535", "550"},
+	},
+	{
+		Code:        "X.7.1",
+		Title:       "Delivery not authorized, message refused",
+		Description: "The sender is not authorized to send to the destination. This can be the result of per-host or per-recipient filtering. This memo does not discuss the merits of any such filtering, but provides a mechanism to report such. This is useful only as a permanent error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"451", "454", "502", "503", "533", "550", "551"},
+	},
+	{
+		Code:        "X.7.2",
+		Title:       "Mailing list expansion prohibited",
+		Description: "The sender is not authorized to send a message to the intended mailing list. This is useful only as a permanent error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"550"},
+	},
+	{
+		Code:        "X.7.3",
+		Title:       "Security conversion required but not possible",
+		Description: "A conversion from one secure messaging protocol to another was required for delivery and such conversion was not possible. This is useful only as a permanent error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.7.4",
+		Title:       "Security features not supported",
+		Description: "A message contained security features such as secure authentication that could not be supported on the delivery protocol. This is useful only as a permanent error.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{"504"},
+	},
+	{
+		Code:        "X.7.5",
+		Title:       "Cryptographic failure",
+		Description: "A transport system otherwise authorized to validate or decrypt a message in transport was unable to do so because necessary information such as key was not available or such information was invalid.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.7.6",
+		Title:       "Cryptographic algorithm not supported",
+		Description: "A transport system otherwise authorized to validate or decrypt a message was unable to do so because the necessary algorithm was not supported.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.7.7",
+		Title:       "Message integrity failure",
+		Description: "A transport system otherwise authorized to validate a message was unable to do so because the message was corrupted or altered. This may be useful as a permanent, transient persistent, or successful delivery code.",
+		References:  []string{"RFC3463"},
+		ReplyCodes:  []string{},
+	},
+	{
+		Code:        "X.7.8",
+		Title:       "Authentication credentials invalid",
+		Description: "This response to the AUTH command indicates that the authentication failed due to invalid or insufficient authentication credentials. In this case, the client SHOULD ask the user to supply new credentials (such as by presenting a password dialog box).",
+		References:  []string{"RFC4954"},
+		ReplyCodes:  []string{"535", "554"},
+	},
+	{
+		Code:        "X.7.9",
+		Title:       "Authentication mechanism is too weak",
+		Description: "This response to the AUTH command indicates that the selected authentication mechanism is weaker than server policy permits for that user. The client SHOULD retry with a new authentication mechanism.",
+		References:  []string{"RFC4954"},
+		ReplyCodes:  []string{"534"},
+	},
+	{
+		Code:        "X.7.10",
+		Title:       "Encryption Needed",
+		Description: "This indicates that external strong privacy layer is needed in order to use the requested authentication mechanism. This is primarily intended for use with clear text authentication mechanisms. A client which receives this may activate a security layer such as TLS prior to authenticating, or attempt to use a stronger mechanism.",
+		References:  []string{"RFC5248"},
+		ReplyCodes:  []string{"523"},
+	},
+	{
+		Code:        "X.7.11",
+		Title:       "Encryption required for requested authentication mechanism",
+		Description: "This response to the AUTH command indicates that the selected authentication mechanism may only be used when the underlying SMTP connection is encrypted. Note that this response code is documented here for historical purposes only. Modern implementations SHOULD NOT advertise mechanisms that are not permitted due to lack of encryption, unless an encryption layer of sufficient strength is currently being employed.",
+		References:  []string{"RFC4954"},
+		ReplyCodes:  []string{"524", "538"},
+	},
+	{
+		Code:        "X.7.12",
+		Title:       "A password transition is needed",
+		Description: "This response to the AUTH command indicates that the user needs to transition to the selected authentication mechanism. This is typically done by authenticating once using the [PLAIN] authentication mechanism. The selected mechanism SHOULD then work for authentications in subsequent sessions.",
+		References:  []string{"RFC4954"},
+		ReplyCodes:  []string{"422", "432"},
+	},
+	{
+		Code:        "X.7.13",
+		Title:       "User Account Disabled",
+		Description: "Sometimes a system administrator will have to disable a user's account (e.g., due to lack of payment, abuse, evidence of a break-in attempt, etc). This error code occurs after a successful authentication to a disabled account. This informs the client that the failure is permanent until the user contacts their system administrator to get the account re-enabled. It differs from a generic authentication failure where the client's best option is to present the passphrase entry dialog in case the user simply mistyped their passphrase.",
+		References:  []string{"RFC5248"},
+		ReplyCodes:  []string{"525"},
+	},
+	{
+		Code:        "X.7.14",
+		Title:       "Trust relationship required",
+		Description: "The submission server requires a configured trust relationship with a third-party server in order to access the message content. This value replaces the prior use of X.7.8 for this error condition. thereby updating [RFC4468].",
+		References:  []string{"RFC5248"},
+		ReplyCodes:  []string{"535", "554"},
+	},
+	{
+		Code:        "X.7.15",
+		Title:       "Priority Level is too low",
+		Description: "The specified priority level is below the lowest priority acceptable for the receiving SMTP server. This condition might be temporary, for example the server is operating in a mode where only higher priority messages are accepted for transfer and delivery, while lower priority messages are rejected.",
+		References:  []string{"RFC6710"},
+		ReplyCodes:  []string{"450", "550", "4XX", "5XX"},
+	},
+	{
+		Code:        "X.7.16",
+		Title:       "Message is too big for the specified priority",
+		Description: "The message is too big for the specified priority. This condition might be temporary, for example the server is operating in a mode where only higher priority messages below certain size are accepted for transfer and delivery.",
+		References:  []string{"RFC6710"},
+		ReplyCodes:  []string{"552", "4XX", "5XX"},
+	},
+	{
+		Code:        "X.7.17",
+		Title:       "Mailbox owner has changed",
+		Description: "This status code is returned when a message is received with a Require-Recipient-Valid-Since field or RRVS extension and the receiving system is able to determine that the intended recipient mailbox has not been under continuous ownership since the specified date-time.",
+		References:  []string{"RFC6710"},
+		ReplyCodes:  []string{"5XX"},
+	},
+	{
+		Code:        "X.7.18",
+		Title:       "Domain owner has changed",
+		Description: "This status code is returned when a message is received with a Require-Recipient-Valid-Since field or RRVS extension and the receiving system wishes to disclose that the owner of the domain name of the recipient has changed since the specified date-time.",
+		References:  []string{"RFC7293"},
+		ReplyCodes:  []string{"5XX"},
+	},
+	{
+		Code:        "X.7.19",
+		Title:       "RRVS test cannot be completed",
+		Description: "This status code is returned when a message is received with a Require-Recipient-Valid-Since field or RRVS extension and the receiving system cannot complete the requested evaluation because the required timestamp was not recorded. The message originator needs to decide whether to reissue the message without RRVS protection.",
+		References:  []string{"RFC7293"},
+		ReplyCodes:  []string{"5XX"},
+	},
+	{
+		Code:        "X.7.20",
+		Title:       "No passing DKIM signature found",
+		Description: "This status code is returned when a message did not contain any passing DKIM signatures. (This violates the advice of Section 6.1 of [RFC6376].)",
+		References:  []string{"RFC7372"},
+		ReplyCodes:  []string{"550"},
+	},
+	{
+		Code:        "X.7.21",
+		Title:       "No acceptable DKIM signature found",
+		Description: "This status code is returned when a message contains one or more passing DKIM signatures, but none are acceptable. (This violates the advice of Section 6.1 of [RFC6376].)",
+		References:  []string{"RFC7372", "RFC6476"},
+		ReplyCodes:  []string{"550"},
+	},
+	{
+		Code:        "X.7.22",
+		Title:       "No valid author-matched DKIM signature found",
+		Description: "This status code is returned when a message contains one or more passing DKIM signatures, but none are acceptable because none have an identifier(s) that matches the author address(es) found in the From header field. This is a special case of X.7.21. (This violates the advice of Section 6.1 of [RFC6376].)",
+		References:  []string{"RFC7372"},
+		ReplyCodes:  []string{"550"},
+	},
+	{
+		Code:        "X.7.23",
+		Title:       "SPF validation failed",
+		Description: "This status code is returned when a message completed an SPF check that produced a \"fail\" result, contrary to local policy requirements. Used in place of 5.7.1 as described in Section 8.4 of [RFC7208].",
+		References:  []string{"RFC7273", "RFC7208"},
+		ReplyCodes:  []string{"550"},
+	},
+	{
+		Code:        "X.7.24",
+		Title:       "SPF validation error",
+		Description: "This status code is returned when evaluation of SPF relative to an arriving message resulted in an error. Used in place of 4.4.3 or 5.5.2 as described in Sections 8.6 and 8.7 of [RFC7208].",
+		References:  []string{"RFC7372", "RFC7208"},
+		ReplyCodes:  []string{"451", "550"},
+	},
+	{
+		Code:        "X.7.25",
+		Title:       "Reverse DNS validation failed",
+		Description: "This status code is returned when an SMTP client's IP address failed a reverse DNS validation check, contrary to local policy requirements.",
+		References:  []string{"RFC7372", "RFC7601"},
+		ReplyCodes:  []string{"550"},
+	},
+	{
+		Code:        "X.7.26",
+		Title:       "Multiple authentication checks failed",
+		Description: "This status code is returned when a message failed more than one message authentication check, contrary to local policy requirements. The particular mechanisms that failed are not specified.",
+		References:  []string{"RFC7372"},
+		ReplyCodes:  []string{"550"},
+	},
+	{
+		Code:        "X.7.27",
+		Title:       "Sender address has null MX",
+		Description: "This status code is returned when the associated sender address has a null MX, and the SMTP receiver is configured to reject mail from such sender (e.g., because it could not return a DSN).",
+		References:  []string{"RFC7505"},
+		ReplyCodes:  []string{"550"},
+	},
+}